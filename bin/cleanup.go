@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/litmuschaos/litmus-go/pkg/cleanup"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// isChaosCommand returns true, if the go-runner is invoked with the revert or cleanup subcommand
+// the experiments are invoked with the (-name) flag, which keeps working as before
+func isChaosCommand(args []string) bool {
+	return len(args) > 1 && (args[1] == "revert" || args[1] == "cleanup")
+}
+
+// chaosCommands returns the revert & cleanup commands, which recovers the cluster after the failed or aborted experiments
+func chaosCommands() *cobra.Command {
+
+	var namespace, chaosUID, engineName string
+	var allNamespaces, all, dryRun bool
+	clients := clients.ClientSets{}
+
+	// resolve derives the namespace & chaosUID of the artifacts from the flags
+	// all the experiments are considered only with the --all flag, the ones with an active chaosengine are skipped
+	resolve := func() (string, string, error) {
+		switch {
+		case all && (chaosUID != "" || engineName != ""):
+			return "", "", errors.Errorf("--all can't be used along with --chaos-uid or --engine")
+		case !all && chaosUID == "" && engineName == "":
+			return "", "", errors.Errorf("either --engine, --chaos-uid or --all is required")
+		}
+		if allNamespaces {
+			if engineName != "" {
+				return "", "", errors.Errorf("--engine can't be used along with --all-namespaces")
+			}
+			namespace = ""
+		}
+		if engineName != "" {
+			// the chaosUID of the experiments is the uid of the chaosengine
+			engine, err := clients.LitmusClient.ChaosEngines(namespace).Get(engineName, metav1.GetOptions{})
+			if err != nil {
+				return "", "", errors.Errorf("unable to get the %v chaosengine in %v namespace, err: %v", engineName, namespace, err)
+			}
+			if chaosUID != "" && chaosUID != string(engine.UID) {
+				return "", "", errors.Errorf("the chaosUID %v doesn't belong to the %v chaosengine", chaosUID, engineName)
+			}
+			chaosUID = string(engine.UID)
+		}
+		return namespace, chaosUID, nil
+	}
+
+	revert := &cobra.Command{
+		Use:   "revert [flags]",
+		Short: "Revert the outstanding chaos, recorded in the chaos ledgers",
+		Long: "Revert the outstanding chaos (node taints, cordoned nodes, scaled applications, stopped ec2 instances & detached ebs volumes),\n" +
+			"recorded in the chaos ledgers by the experiments which died before reverting it",
+		Example: "go-runner revert -n litmus --engine nginx-chaos",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, chaosUID, err := resolve()
+			if err != nil {
+				return err
+			}
+			active, err := activeChaosUIDs(all, namespace, clients)
+			if err != nil {
+				return err
+			}
+			records, err := printPendingReverts(namespace, chaosUID, active, clients)
+			if err != nil || dryRun {
+				return err
+			}
+			return recoverAll(namespace, chaosUID, active, records, clients)
+		},
+	}
+
+	cleanupCmd := &cobra.Command{
+		Use:   "cleanup [flags]",
+		Short: "Revert the outstanding chaos and delete the chaos artifacts",
+		Long: "Revert the outstanding chaos, recorded in the chaos ledgers, and delete the chaos artifacts\n" +
+			"(helper pods, probe pods, powerfulseal deployments & configmaps, kafka & cassandra liveness resources)\n" +
+			"left behind by the failed or aborted experiments, or retained by the job cleanup policy",
+		Example: "go-runner cleanup -n litmus --chaos-uid 2f1e1fd8-0ae7-4a6b-a1a5-5b0b1e9b5a9e --dry-run\ngo-runner cleanup -A --all",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, chaosUID, err := resolve()
+			if err != nil {
+				return err
+			}
+			active, err := activeChaosUIDs(all, namespace, clients)
+			if err != nil {
+				return err
+			}
+			records, err := printPendingReverts(namespace, chaosUID, active, clients)
+			if err != nil {
+				return err
+			}
+			artifacts, err := cleanup.Find(clients, namespace, chaosUID)
+			if err != nil {
+				return err
+			}
+			artifacts = cleanup.SkipActive(artifacts, active)
+			printArtifacts(artifacts)
+			if dryRun {
+				return nil
+			}
+
+			// the chaos is reverted before deleting the artifacts, as the reverts don't depend on them
+			revertErr := recoverAll(namespace, chaosUID, active, records, clients)
+			if err := cleanup.Delete(clients, artifacts); err != nil {
+				return err
+			}
+			return revertErr
+		},
+	}
+
+	root := &cobra.Command{
+		Use:           "go-runner",
+		Short:         "Revert and cleanup the chaos, left behind by the litmus experiments",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			//Getting kubeConfig and Generate ClientSets
+			if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
+				return errors.Errorf("unable to get the kubeconfig, err: %v", err)
+			}
+			return nil
+		},
+	}
+	root.PersistentFlags().StringVarP(&namespace, "namespace", "n", "litmus", "namespace of the chaos artifacts & chaosengine")
+	root.PersistentFlags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "look for the chaos artifacts across all the namespaces")
+	root.PersistentFlags().StringVar(&chaosUID, "chaos-uid", "", "chaosUID of the experiments")
+	root.PersistentFlags().BoolVar(&all, "all", false, "consider all the experiments, except the ones with an active chaosengine")
+	root.PersistentFlags().StringVar(&engineName, "engine", "", "name of the chaosengine, its uid is used as the chaosUID")
+	root.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "only list the outstanding chaos & artifacts")
+
+	root.AddCommand(revert, cleanupCmd)
+	return root
+}
+
+// runChaosCommand runs the revert or cleanup subcommand and exits with non-zero code, if it fails
+func runChaosCommand() {
+	if err := chaosCommands().Execute(); err != nil {
+		log.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+// activeChaosUIDs returns the chaosUIDs of the active chaosengines, which are skipped with the --all flag
+func activeChaosUIDs(all bool, namespace string, clients clients.ClientSets) (map[string]bool, error) {
+	if !all {
+		return nil, nil
+	}
+	return cleanup.Active(clients, namespace)
+}
+
+// recoverAll replays the given outstanding undo records, the records of the active chaosengines are already skipped
+func recoverAll(namespace, chaosUID string, active map[string]bool, records []ledger.Record, clients clients.ClientSets) error {
+	if len(active) == 0 {
+		return ledger.RecoverAll(clients, namespace, chaosUID)
+	}
+	var failed []string
+	replayed := map[string]bool{}
+	for _, record := range records {
+		if replayed[record.ChaosUID] {
+			continue
+		}
+		replayed[record.ChaosUID] = true
+		if err := ledger.RecoverAll(clients, namespace, record.ChaosUID); err != nil {
+			log.Errorf("Unable to revert the outstanding chaos of %v chaosUID, err: %v", record.ChaosUID, err)
+			failed = append(failed, record.ChaosUID)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("unable to revert the outstanding chaos of %v chaosUIDs", strings.Join(failed, ","))
+	}
+	return nil
+}
+
+// printPendingReverts prints and returns the outstanding undo records of the chaos ledgers
+// the records of the given active chaosUIDs (and the ones without chaosUID, while any chaosengine is active) are skipped
+func printPendingReverts(namespace, chaosUID string, active map[string]bool, clients clients.ClientSets) ([]ledger.Record, error) {
+	pending, err := ledger.Pending(clients, namespace, chaosUID)
+	if err != nil {
+		return nil, err
+	}
+	var records []ledger.Record
+	for _, record := range pending {
+		if len(active) != 0 && (record.ChaosUID == "" || active[record.ChaosUID]) {
+			log.Infof("[Revert]: Skipping the %v of %v, as its chaosengine may be active", record.Action, record.Target)
+			continue
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		fmt.Println("No outstanding chaos found")
+		return nil, nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tNAMESPACE\tTARGET\tCHAOSUID\tSINCE")
+	for _, record := range records {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", record.Action, record.Namespace, record.Target, record.ChaosUID, record.Timestamp)
+	}
+	return records, w.Flush()
+}
+
+// printArtifacts prints the chaos artifacts
+func printArtifacts(artifacts []cleanup.Artifact) {
+	if len(artifacts) == 0 {
+		fmt.Println("No chaos artifacts found")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tCHAOSUID")
+	for _, artifact := range artifacts {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", artifact.Kind, artifact.Namespace, artifact.Name, artifact.ChaosUID)
+	}
+	w.Flush()
+}
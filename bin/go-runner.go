@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
 
 	// Uncomment to load all auth plugins
 	// _ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -12,33 +17,45 @@ import (
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/openstack"
 
-	cassandraPodDelete "github.com/litmuschaos/litmus-go/experiments/cassandra/pod-delete/experiment"
-	containerKill "github.com/litmuschaos/litmus-go/experiments/generic/container-kill/experiment"
-	diskFill "github.com/litmuschaos/litmus-go/experiments/generic/disk-fill/experiment"
-	kubeletServiceKill "github.com/litmuschaos/litmus-go/experiments/generic/kubelet-service-kill/experiment"
-	nodeCPUHog "github.com/litmuschaos/litmus-go/experiments/generic/node-cpu-hog/experiment"
-	nodeDrain "github.com/litmuschaos/litmus-go/experiments/generic/node-drain/experiment"
-	nodeIOStress "github.com/litmuschaos/litmus-go/experiments/generic/node-io-stress/experiment"
-	nodeMemoryHog "github.com/litmuschaos/litmus-go/experiments/generic/node-memory-hog/experiment"
-	nodeRestart "github.com/litmuschaos/litmus-go/experiments/generic/node-restart/experiment"
-	nodeTaint "github.com/litmuschaos/litmus-go/experiments/generic/node-taint/experiment"
-	podAutoscaler "github.com/litmuschaos/litmus-go/experiments/generic/pod-autoscaler/experiment"
-	podCPUHog "github.com/litmuschaos/litmus-go/experiments/generic/pod-cpu-hog/experiment"
-	podDelete "github.com/litmuschaos/litmus-go/experiments/generic/pod-delete/experiment"
-	podDNSChaos "github.com/litmuschaos/litmus-go/experiments/generic/pod-dns-chaos/experiment"
-	podIOStress "github.com/litmuschaos/litmus-go/experiments/generic/pod-io-stress/experiment"
-	podMemoryHog "github.com/litmuschaos/litmus-go/experiments/generic/pod-memory-hog/experiment"
-	podNetworkCorruption "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-corruption/experiment"
-	podNetworkDuplication "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-duplication/experiment"
-	podNetworkLatency "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-latency/experiment"
-	podNetworkLoss "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-loss/experiment"
-	kafkaBrokerPodFailure "github.com/litmuschaos/litmus-go/experiments/kafka/kafka-broker-pod-failure/experiment"
-	ebsLoss "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss/experiment"
-	ec2TerminateByID "github.com/litmuschaos/litmus-go/experiments/kube-aws/ec2-terminate-by-id/experiment"
-	ec2TerminateByTag "github.com/litmuschaos/litmus-go/experiments/kube-aws/ec2-terminate-by-tag/experiment"
+	// experiments are registered through the init function of their packages
+	_ "github.com/litmuschaos/litmus-go/experiments/cassandra/pod-delete/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/container-kill/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/disk-fill/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/kubelet-service-kill/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/node-cpu-hog/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/node-drain/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/node-io-stress/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/node-memory-hog/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/node-restart/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/node-taint/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-autoscaler/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-cpu-hog/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-delete/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-dns-chaos/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-io-stress/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-memory-hog/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-corruption/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-duplication/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-latency/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/generic/pod-network-loss/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/kafka/kafka-broker-pod-failure/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/kube-aws/ebs-loss/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/kube-aws/ec2-terminate-by-id/experiment"
+	_ "github.com/litmuschaos/litmus-go/experiments/kube-aws/ec2-terminate-by-tag/experiment"
 
+	"github.com/litmuschaos/litmus-go/experiments"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
+	"github.com/litmuschaos/litmus-go/pkg/notify"
+	"github.com/litmuschaos/litmus-go/pkg/report"
+	"github.com/litmuschaos/litmus-go/pkg/standalone"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -53,10 +70,85 @@ func init() {
 
 func main() {
 
+	// the revert & cleanup subcommands operates on the chaos, left behind by the experiments
+	if isChaosCommand(os.Args) {
+		runChaosCommand()
+		return
+	}
+
 	clients := clients.ClientSets{}
 
 	// parse the experiment name
 	experimentName := flag.String("name", "pod-delete", "name of the chaos experiment")
+	listExperiments := flag.Bool("list", false, "list all the registered chaos experiments")
+	listTunables := flag.Bool("tunables", false, "print the tunable env variables of the experiment (-name) or of all the registered experiments, in markdown format")
+	// standalone mode, runs the experiment without the chaosengine & chaosresult CRDs
+	specFile := flag.String("spec", "", "path of the experiment spec file (env & probes), enables the standalone mode")
+	resultFile := flag.String("result", "chaosresult.yaml", "path of the result file in standalone & workflow mode, json format is used for .json extension")
+	// workflow mode, runs the steps of the workflow (sequential, parallel & pause) along with the shared probes
+	workflowFile := flag.String("workflow", "", "path of the workflow file, runs its experiments in the standalone mode and writes the aggregated result")
+	// prometheus metrics of the experiment, served at /metrics
+	metricsAddress := flag.String("metrics-address", "", "address to serve the prometheus metrics at /metrics, e.g. :8080, the metrics are not served if empty")
+	// reverts the chaos, left behind by an earlier run of the experiment which died in between
+	recoverChaos := flag.Bool("recover", false, "revert the outstanding chaos of the experiment (-name), recorded in its ledger, and exit")
+	metricsGracePeriod := flag.Duration("metrics-grace-period", 0, "duration to keep serving the metrics after the experiment completes, so that the final values are scraped")
+	// report of the run (timeline, targets, parameters & probes), the format is derived from the extension of the file
+	reportFile := flag.String("report", "", "path of the report file, written once the experiment completes, .md for markdown, .html for html and .xml for junit xml")
+	flag.Parse()
+
+	if *listExperiments {
+		for _, name := range experiments.List() {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	if *listTunables {
+		names := experiments.List()
+		if isFlagSet("name") {
+			names = []string{*experimentName}
+		}
+		if err := writeTunables(os.Stdout, names); err != nil {
+			log.Errorf("Unable to print the tunables, err: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// the workflow exits with non-zero code, if it fails, so that the pipelines can gate on it
+	if *workflowFile != "" {
+		if err := runWorkflow(*workflowFile, *resultFile); err != nil {
+			log.Errorf("Workflow failed, err: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *specFile != "" {
+		if err := standalone.Load(*specFile, *resultFile); err != nil {
+			log.Errorf("Unable to load the experiment spec, err: %v", err)
+			return
+		}
+		// the experiment name is derived from the spec file, unless the (-name) flag is provided
+		if !isFlagSet("name") {
+			*experimentName = standalone.ExperimentName()
+		}
+		log.Infof("[Standalone]: Running the experiment in standalone mode, the result will be written to %v", *resultFile)
+	}
+
+	if *reportFile != "" {
+		if _, err := report.Format(*reportFile); err != nil {
+			log.Errorf("Invalid -report, err: %v", err)
+			return
+		}
+	}
+
+	// resolve the experiment, registered with the (-name) flag
+	experiment, err := experiments.Get(*experimentName)
+	if err != nil {
+		log.Errorf("Unsupported -name %v, please provide the correct value of -name args, err: %v", *experimentName, err)
+		return
+	}
 
 	//Getting kubeConfig and Generate ClientSets
 	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
@@ -66,58 +158,143 @@ func main() {
 
 	log.Infof("Experiment Name: %v", *experimentName)
 
-	// invoke the corresponding experiment based on the the (-name) flag
-	switch *experimentName {
-	case "container-kill":
-		containerKill.ContainerKill(clients)
-	case "disk-fill":
-		diskFill.DiskFill(clients)
-	case "kafka-broker-pod-failure":
-		kafkaBrokerPodFailure.KafkaBrokerPodFailure(clients)
-	case "kubelet-service-kill":
-		kubeletServiceKill.KubeletServiceKill(clients)
-	case "node-cpu-hog":
-		nodeCPUHog.NodeCPUHog(clients)
-	case "node-drain":
-		nodeDrain.NodeDrain(clients)
-	case "node-io-stress":
-		nodeIOStress.NodeIOStress(clients)
-	case "node-memory-hog":
-		nodeMemoryHog.NodeMemoryHog(clients)
-	case "node-taint":
-		nodeTaint.NodeTaint(clients)
-	case "pod-autoscaler":
-		podAutoscaler.PodAutoscaler(clients)
-	case "pod-cpu-hog":
-		podCPUHog.PodCPUHog(clients)
-	case "pod-delete":
-		podDelete.PodDelete(clients)
-	case "pod-io-stress":
-		podIOStress.PodIOStress(clients)
-	case "pod-memory-hog":
-		podMemoryHog.PodMemoryHog(clients)
-	case "pod-network-corruption":
-		podNetworkCorruption.PodNetworkCorruption(clients)
-	case "pod-network-duplication":
-		podNetworkDuplication.PodNetworkDuplication(clients)
-	case "pod-network-latency":
-		podNetworkLatency.PodNetworkLatency(clients)
-	case "pod-network-loss":
-		podNetworkLoss.PodNetworkLoss(clients)
-	case "cassandra-pod-delete":
-		cassandraPodDelete.CasssandraPodDelete(clients)
-	case "ec2-terminate-by-id":
-		ec2TerminateByID.EC2TerminateByID(clients)
-	case "ec2-terminate-by-tag":
-		ec2TerminateByTag.EC2TerminateByTag(clients)
-	case "ebs-loss":
-		ebsLoss.EBSLoss(clients)
-	case "node-restart":
-		nodeRestart.NodeRestart(clients)
-	case "pod-dns-chaos":
-		podDNSChaos.PodDNSExperiment(clients)
-	default:
-		log.Errorf("Unsupported -name %v, please provide the correct value of -name args", *experimentName)
+	if *recoverChaos {
+		chaosDetails := ledgerDetails(*experimentName)
+		log.Infof("[Recovery]: Reverting the outstanding chaos, recorded in the %v ledger", ledger.Name(&chaosDetails))
+		if err := ledger.Recover(clients, &chaosDetails); err != nil {
+			log.Errorf("Unable to revert the outstanding chaos, err: %v", err)
+			os.Exit(1)
+		}
+		log.Info("[Recovery]: The outstanding chaos has been reverted")
 		return
 	}
+
+	// the context is cancelled once the abort signal is received, the experiment reverts the chaos and returns
+	ctx, cancel := common.AbortContext(context.Background())
+	defer cancel()
+
+	if *metricsAddress != "" {
+		if err := metrics.Serve(*metricsAddress, *experimentName); err != nil {
+			log.Errorf("Unable to serve the metrics, err: %v", err)
+			return
+		}
+		log.Infof("[Metrics]: Serving the metrics at %v/metrics", *metricsAddress)
+	}
+
+	experiment.Run(ctx, clients)
+
+	if *reportFile != "" {
+		if err := writeReport(*experimentName, *reportFile); err != nil {
+			log.Errorf("Unable to write the report, err: %v", err)
+		} else {
+			log.Infof("[Report]: The report has been written to %v", *reportFile)
+		}
+	}
+
+	if *metricsAddress != "" && *metricsGracePeriod > 0 {
+		log.Infof("[Metrics]: Waiting for %v before exiting, to scrape the final metrics", *metricsGracePeriod)
+		select {
+		case <-time.After(*metricsGracePeriod):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// tunablesHeader is the header of the tunables doc, it is written along with the tunables so that the doc can be regenerated as it is
+const tunablesHeader = `# Experiment Tunables
+
+The tunable env variables of all the experiments, along with their defaults and constraints.
+The env variables are validated before the chaos injection and the experiment fails at PreReq, if any of them is invalid.
+
+This file is generated from the struct tags of the experiment details, regenerate it with:
+
+` + "```" + `
+go run ./bin -tunables > experiments/TUNABLES.md
+` + "```" + `
+
+`
+
+// writeTunables writes the tunables of the given experiments in markdown format, along with the header of the doc
+func writeTunables(w io.Writer, names []string) error {
+	if _, err := io.WriteString(w, tunablesHeader); err != nil {
+		return err
+	}
+	for _, name := range names {
+		tunables, err := experiments.Tunables(name)
+		if err != nil {
+			return errors.Errorf("unable to derive the tunables of %v experiment, err: %v", name, err)
+		}
+		if tunables == nil {
+			continue
+		}
+		if err := config.WriteMarkdown(w, name, tunables); err != nil {
+			return err
+		}
+	}
+
+	// the guardrail policies and the webhook settings are shared by all the experiments
+	tunables, err := guardrails.Tunables()
+	if err != nil {
+		return errors.Errorf("unable to derive the tunables of the guardrails, err: %v", err)
+	}
+	if err := config.WriteMarkdown(w, "guardrails (all experiments)", tunables); err != nil {
+		return err
+	}
+	tunables, err = notify.Tunables()
+	if err != nil {
+		return errors.Errorf("unable to derive the tunables of the webhooks, err: %v", err)
+	}
+	return config.WriteMarkdown(w, "webhooks (all experiments)", tunables)
+}
+
+// writeReport writes the report of the experiment, the parameters are derived from the tunables of the experiment
+// the value of a tunable is taken from the env, as used by the experiment, or its default value
+func writeReport(experimentName, reportFile string) error {
+	tunables, err := experiments.Tunables(experimentName)
+	if err != nil {
+		return errors.Errorf("unable to derive the tunables of %v experiment, err: %v", experimentName, err)
+	}
+	var parameters []report.Parameter
+	for _, t := range tunables {
+		value := os.Getenv(t.Name)
+		if value == "" {
+			value = t.Default
+		}
+		parameters = append(parameters, report.Parameter{Name: t.Name, Value: value})
+	}
+
+	r, err := report.Build(parameters)
+	if err != nil {
+		return err
+	}
+	return report.Write(r, reportFile)
+}
+
+// ledgerDetails returns the chaos details, needed to locate the ledger of the given experiment
+// the experiment name, chaos namespace, engine name and instance id are derived from the env, as used by the experiment
+func ledgerDetails(experimentName string) types.ChaosDetails {
+	chaosDetails := types.ChaosDetails{
+		ExperimentName: experimentName,
+		ChaosNamespace: os.Getenv("CHAOS_NAMESPACE"),
+		EngineName:     os.Getenv("CHAOSENGINE"),
+		InstanceID:     os.Getenv("INSTANCE_ID"),
+	}
+	if name := os.Getenv("EXPERIMENT_NAME"); name != "" {
+		chaosDetails.ExperimentName = name
+	}
+	if chaosDetails.ChaosNamespace == "" {
+		chaosDetails.ChaosNamespace = "litmus"
+	}
+	return chaosDetails
+}
+
+// isFlagSet returns true, if the given flag is provided in the command line
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
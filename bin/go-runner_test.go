@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/experiments"
+)
+
+func TestTunablesDoc(t *testing.T) {
+	committed, err := ioutil.ReadFile("../experiments/TUNABLES.md")
+	if err != nil {
+		t.Fatalf("unable to read the tunables doc, err: %v", err)
+	}
+
+	var generated bytes.Buffer
+	if err := writeTunables(&generated, experiments.List()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if generated.String() != string(committed) {
+		t.Fatalf("experiments/TUNABLES.md is outdated, regenerate it with: go run ./bin -tunables > experiments/TUNABLES.md")
+	}
+}
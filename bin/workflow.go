@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/litmuschaos/litmus-go/experiments"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/workflow"
+	"github.com/pkg/errors"
+)
+
+// runWorkflow runs the steps of the workflow and writes the aggregated result to the result file
+// every experiment runs in a separate go-runner process, in the standalone mode
+// it returns an error, if the workflow can't be run or its aggregated verdict is not Pass
+func runWorkflow(workflowFile, resultFile string) error {
+
+	spec, err := workflow.Load(workflowFile)
+	if err != nil {
+		return errors.Errorf("unable to load the workflow, err: %v", err)
+	}
+	// verify all the experiments before running the first step
+	for _, experiment := range spec.Experiments() {
+		if _, err := experiments.Get(experiment.Name); err != nil {
+			return errors.Errorf("unsupported experiment %v in the workflow, err: %v", experiment.Name, err)
+		}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.Errorf("unable to derive the go-runner executable, err: %v", err)
+	}
+
+	//Getting kubeConfig and Generate ClientSets
+	clients := clients.ClientSets{}
+	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
+		return errors.Errorf("unable to get the kubeconfig, err: %v", err)
+	}
+
+	// the context is cancelled once the abort signal is received, the running experiments are stopped as well
+	ctx, cancel := common.AbortContext(context.Background())
+	defer cancel()
+
+	chaosNamespace := os.Getenv("CHAOS_NAMESPACE")
+	if chaosNamespace == "" {
+		chaosNamespace = "litmus"
+	}
+
+	log.Infof("[Workflow]: Running the %v workflow, the result will be written to %v", spec.Name, resultFile)
+	result := workflow.Run(ctx, spec, workflow.ExecRunner(executable), clients, workflow.NewChaosDetails(spec, chaosNamespace))
+	if err := workflow.WriteResult(result, resultFile); err != nil {
+		return errors.Errorf("unable to write the workflow result, err: %v", err)
+	}
+	if result.Verdict != types.PassVerdict {
+		return errors.Errorf("%v workflow verdict is %v, fail step: %v", spec.Name, result.Verdict, result.FailStep)
+	}
+	return nil
+}
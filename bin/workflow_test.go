@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunWorkflowInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "workflow")
+	if err != nil {
+		t.Fatalf("unable to create the temporary directory, err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	workflowFile := filepath.Join(dir, "workflow.yaml")
+	if err := ioutil.WriteFile(workflowFile, []byte("name: checkout-resiliency\nsteps:\n- experiment:\n    name: unknown-chaos\n"), 0644); err != nil {
+		t.Fatalf("unable to write the workflow file, err: %v", err)
+	}
+
+	tests := map[string]struct {
+		workflowFile string
+		wantErr      string
+	}{
+		"missing workflow":   {workflowFile: filepath.Join(dir, "missing.yaml"), wantErr: "unable to load the workflow"},
+		"unknown experiment": {workflowFile: workflowFile, wantErr: "unsupported experiment unknown-chaos"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := runWorkflow(tt.workflowFile, filepath.Join(dir, "result.json"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected %q error, found %v", tt.wantErr, err)
+			}
+		})
+	}
+}
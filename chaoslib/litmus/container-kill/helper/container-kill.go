@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"os/exec"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/container-kill/types"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/openebs/maya/pkg/util/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -28,6 +30,10 @@ func main() {
 	eventsDetails := types.EventDetails{}
 	chaosDetails := types.ChaosDetails{}
 
+	// ctx is cancelled, once the abort signal is received
+	ctx, cancel := common.AbortContext(context.Background())
+	defer cancel()
+
 	//Getting kubeConfig and Generate ClientSets
 	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
 		log.Fatalf("Unable to Get the kubeconfig, err: %v", err)
@@ -40,7 +46,7 @@ func main() {
 	// Intialise the chaos attributes
 	experimentEnv.InitialiseChaosVariables(&chaosDetails, &experimentsDetails)
 
-	err := KillContainer(&experimentsDetails, clients, &eventsDetails, &chaosDetails)
+	err := KillContainer(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails)
 	if err != nil {
 		log.Fatalf("helper pod failed, err: %v", err)
 	}
@@ -49,14 +55,19 @@ func main() {
 
 // KillContainer kill the random application container
 // it will kill the container till the chaos duration
-// the execution will stop after timestamp passes the given chaos duration
-func KillContainer(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+// the execution will stop after timestamp passes the given chaos duration or once the abort signal is received
+func KillContainer(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	// getting the current timestamp, it will help to kepp track the total chaos duration
 	ChaosStartTimeStamp := time.Now().Unix()
 
 	for iteration := 0; iteration < experimentsDetails.Iterations; iteration++ {
 
+		// stopping the chaos execution, if abort signal recieved
+		if err := common.CheckAbort(ctx); err != nil {
+			return err
+		}
+
 		//GetRestartCount return the restart count of target container
 		restartCountBefore, err := GetRestartCount(experimentsDetails, experimentsDetails.TargetPods, clients)
 		if err != nil {
@@ -99,7 +110,9 @@ func KillContainer(experimentsDetails *experimentTypes.ExperimentDetails, client
 		//Waiting for the chaos interval after chaos injection
 		if experimentsDetails.ChaosInterval != 0 {
 			log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaosInterval)
-			waitForChaosInterval(experimentsDetails)
+			if err := waitForChaosInterval(ctx, experimentsDetails); err != nil {
+				return err
+			}
 		}
 
 		//Check the status of restarted container
@@ -210,8 +223,8 @@ func CheckContainerStatus(experimentsDetails *experimentTypes.ExperimentDetails,
 }
 
 //waitForChaosInterval waits for the given ramp time duration (in seconds)
-func waitForChaosInterval(experimentsDetails *experimentTypes.ExperimentDetails) {
-	time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+func waitForChaosInterval(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails) error {
+	return common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosInterval)
 }
 
 //GetRestartCount return the restart count of target container
@@ -1,13 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
@@ -25,8 +24,6 @@ import (
 	clientTypes "k8s.io/apimachinery/pkg/types"
 )
 
-var inject, abort chan os.Signal
-
 func main() {
 
 	experimentsDetails := experimentTypes.ExperimentDetails{}
@@ -35,15 +32,9 @@ func main() {
 	chaosDetails := types.ChaosDetails{}
 	resultDetails := types.ResultDetails{}
 
-	// inject channel is used to transmit signal notifications.
-	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
-
-	// abort channel is used to transmit signal notifications.
-	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// ctx is cancelled, once the abort signal is received
+	ctx, cancel := common.AbortContext(context.Background())
+	defer cancel()
 
 	//Getting kubeConfig and Generate ClientSets
 	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
@@ -63,14 +54,14 @@ func main() {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	err := DiskFill(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	err := DiskFill(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
 	if err != nil {
 		log.Fatalf("helper pod failed, err: %v", err)
 	}
 }
 
 //DiskFill contains steps to inject disk-fill chaos
-func DiskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func DiskFill(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	// Derive the container id of the target container
 	containerID, err := GetContainerID(experimentsDetails, clients)
@@ -124,19 +115,22 @@ func DiskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	}
 
-	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, clients, containerID)
-
 	if sizeTobeFilled > 0 {
 
-		if err := fillDisk(containerID, sizeTobeFilled, experimentsDetails.DataBlockSize); err != nil {
+		if err := fillDisk(ctx, containerID, sizeTobeFilled, experimentsDetails.DataBlockSize); err != nil {
+			// the disk fill is interrupted, if the abort signal is received in between
+			if ctx.Err() != nil {
+				return revertChaos(experimentsDetails, clients, containerID)
+			}
 			log.Error(string(out))
 			return err
 		}
 
 		log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-		common.WaitForDuration(experimentsDetails.ChaosDuration)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration); err != nil {
+			return revertChaos(experimentsDetails, clients, containerID)
+		}
 
 		log.Info("[Chaos]: Stopping the experiment")
 
@@ -153,22 +147,20 @@ func DiskFill(experimentsDetails *experimentTypes.ExperimentDetails, clients cli
 }
 
 // fillDisk fill the ephemeral disk by creating files
-func fillDisk(containerID string, sizeTobeFilled, bs int) error {
+func fillDisk(ctx context.Context, containerID string, sizeTobeFilled, bs int) error {
 
-	select {
-	case <-inject:
-		// stopping the chaos execution, if abort signal recieved
-		os.Exit(1)
-	default:
-		// Creating files to fill the required ephemeral storage size of block size of 4K
-		log.Infof("[Fill]: Filling ephemeral storage, size: %vKB", sizeTobeFilled)
-		dd := fmt.Sprintf("sudo dd if=/dev/urandom of=/diskfill/%v/diskfill bs=%vK count=%v", containerID, bs, strconv.Itoa(sizeTobeFilled/bs))
-		log.Infof("dd: {%v}", dd)
-		cmd := exec.Command("/bin/bash", "-c", dd)
-		_, err := cmd.CombinedOutput()
+	// stopping the chaos execution, if abort signal recieved
+	if err := common.CheckAbort(ctx); err != nil {
 		return err
 	}
-	return nil
+
+	// Creating files to fill the required ephemeral storage size of block size of 4K
+	log.Infof("[Fill]: Filling ephemeral storage, size: %vKB", sizeTobeFilled)
+	dd := fmt.Sprintf("sudo dd if=/dev/urandom of=/diskfill/%v/diskfill bs=%vK count=%v", containerID, bs, strconv.Itoa(sizeTobeFilled/bs))
+	log.Infof("dd: {%v}", dd)
+	cmd := exec.CommandContext(ctx, "/bin/bash", "-c", dd)
+	_, err := cmd.CombinedOutput()
+	return err
 }
 
 // GetEphemeralStorageAttributes derive the ephemeral storage attributes from the target pod
@@ -302,25 +294,20 @@ func Getenv(key string, defaultValue string) string {
 	return value
 }
 
-// abortWatcher continuosly watch for the abort signals
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, containerID string) {
-
-	for {
-		select {
-		case <-abort:
-			log.Info("[Chaos]: Killing process started because of terminated signal received")
-			log.Info("Chaos Revert Started")
-			// retry thrice for the chaos revert
-			retry := 3
-			for retry > 0 {
-				if err := Remedy(experimentsDetails, clients, containerID); err != nil {
-					log.Errorf("Unable to perform remedy operation, err: %v", err)
-				}
-				retry--
-				time.Sleep(1 * time.Second)
-			}
-			log.Info("Chaos Revert Completed")
-			os.Exit(1)
+// revertChaos reverts the chaos, once the abort signal is received
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, containerID string) error {
+
+	log.Info("[Chaos]: Killing process started because of terminated signal received")
+	log.Info("Chaos Revert Started")
+	// retry thrice for the chaos revert
+	retry := 3
+	for retry > 0 {
+		if err := Remedy(experimentsDetails, clients, containerID); err != nil {
+			log.Errorf("Unable to perform remedy operation, err: %v", err)
 		}
+		retry--
+		time.Sleep(1 * time.Second)
 	}
+	log.Info("Chaos Revert Completed")
+	return common.ErrChaosStopped
 }
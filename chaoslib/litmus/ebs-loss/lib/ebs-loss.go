@@ -1,6 +1,7 @@
 package lib
 
 import (
+	"context"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -9,7 +10,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	ebs "github.com/litmuschaos/litmus-go/pkg/cloud/aws"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ebs-loss/types"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -20,42 +23,77 @@ import (
 )
 
 //InjectEBSLoss contains the chaos injection steps for ebs loss
-func InjectEBSLoss(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func InjectEBSLoss(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	var err error
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
+	}
+
+	// verify the volume & its instance against the blast radius guardrails, before injecting the chaos
+	if err := guardrails.CheckInstances(chaosDetails.Guardrails, 0, []string{experimentsDetails.EBSVolumeID}); err != nil {
+		return err
+	}
+	if err := guardrails.CheckInstanceNodes(clients, chaosDetails.Guardrails, []string{experimentsDetails.Ec2InstanceID}); err != nil {
+		return err
+	}
+
+	// record the volume in the ledger, so that it is attached even if the experiment dies in between
+	record := ledger.Record{
+		Action: ledger.AttachEBSVolume,
+		Target: experimentsDetails.EBSVolumeID,
+		Values: map[string]string{
+			"instanceID": experimentsDetails.Ec2InstanceID,
+			"deviceName": experimentsDetails.DeviceName,
+			"region":     experimentsDetails.Region,
+		},
 	}
+	if err := ledger.Add(clients, chaosDetails, record); err != nil {
+		return err
+	}
+	common.SetTargets(chaosDetails, types.TargetedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
+	common.SetParameters(chaosDetails, map[string]string{
+		"instanceID": experimentsDetails.Ec2InstanceID,
+		"deviceName": experimentsDetails.DeviceName,
+		"region":     experimentsDetails.Region,
+	})
 
 	//Detaching the ebs volume from the instance
 	log.Info("[Chaos]: Detaching the EBS volume from the instance")
 	err = EBSVolumeDetach(experimentsDetails)
 	if err != nil {
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
 		return errors.Errorf("ebs detachment failed, err: %v", err)
 	}
 
 	//Wait for ebs volume detachment
 	log.Info("[Wait]: Wait for EBS volume detachment")
 	if err = WaitForVolumeDetachment(experimentsDetails); err != nil {
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
 		return errors.Errorf("unable to detach the ebs volume to the ec2 instance, err: %v", err)
 	}
+	common.SetTargets(chaosDetails, types.InjectedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
 
 	//Wait for chaos duration
 	log.Infof("[Wait]: Waiting for the chaos duration of %vs", experimentsDetails.ChaosDuration)
-	time.Sleep(time.Duration(experimentsDetails.ChaosDuration) * time.Second)
+	// the volume is attached back before returning, if the abort signal is received in between
+	abortErr := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration)
 
 	//Getting the EBS volume attachment status
 	EBSStatus, err := ebs.GetEBSStatus(experimentsDetails)
 	if err != nil {
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
 		return errors.Errorf("failed to get the ebs status, err: %v", err)
 	}
 
@@ -64,22 +102,33 @@ func InjectEBSLoss(experimentsDetails *experimentTypes.ExperimentDetails, client
 		log.Info("[Chaos]: Attaching the EBS volume from the instance")
 		err = EBSVolumeAttach(experimentsDetails)
 		if err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
 			return errors.Errorf("ebs attachment failed, err: %v", err)
 		}
 
 		//Wait for ebs volume attachment
 		log.Info("[Wait]: Wait for EBS volume attachment")
 		if err = WaitForVolumeAttachment(experimentsDetails); err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
 			return errors.Errorf("unable to attach the ebs volume to the ec2 instance, err: %v", err)
 		}
 	} else {
 		log.Info("[Skip]: The EBS volume is already attached")
 	}
+	if err := ledger.Remove(clients, chaosDetails, record); err != nil {
+		log.Warnf("The EBS volume is attached, but the undo record is not removed, err: %v", err)
+	}
+	common.SetTargets(chaosDetails, types.RevertedStatus, common.Targets("ebs-volume", experimentsDetails.EBSVolumeID)...)
+	if abortErr != nil {
+		return abortErr
+	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -122,44 +171,10 @@ func EBSVolumeDetach(experimentsDetails *experimentTypes.ExperimentDetails) erro
 	return nil
 }
 
-// EBSVolumeAttach will detach the ebs vol from ec2 node
+// EBSVolumeAttach will attach the ebs vol to the ec2 node
 func EBSVolumeAttach(experimentsDetails *experimentTypes.ExperimentDetails) error {
-
-	// Load session from shared config
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
-		SharedConfigState: session.SharedConfigEnable,
-		Config:            aws.Config{Region: aws.String(experimentsDetails.Region)},
-	}))
-
-	// Create new EC2 client
-	ec2Svc := ec2.New(sess)
-
 	//Attaching the ebs volume after chaos
-	input := &ec2.AttachVolumeInput{
-		Device:     aws.String(experimentsDetails.DeviceName),
-		InstanceId: aws.String(experimentsDetails.Ec2InstanceID),
-		VolumeId:   aws.String(experimentsDetails.EBSVolumeID),
-	}
-
-	result, err := ec2Svc.AttachVolume(input)
-	if err != nil {
-		if aerr, ok := err.(awserr.Error); ok {
-			switch aerr.Code() {
-			default:
-				return errors.Errorf(aerr.Error())
-			}
-		} else {
-			return errors.Errorf(err.Error())
-		}
-	}
-
-	log.InfoWithValues("Attaching ebs having:", logrus.Fields{
-		"VolumeId":   *result.VolumeId,
-		"State":      *result.State,
-		"Device":     *result.Device,
-		"InstanceId": *result.InstanceId,
-	})
-	return nil
+	return ebs.EBSVolumeAttach(experimentsDetails.EBSVolumeID, experimentsDetails.Ec2InstanceID, experimentsDetails.DeviceName, experimentsDetails.Region)
 }
 
 // WaitForVolumeDetachment will wait the ebs volume to completely detach
@@ -1,13 +1,17 @@
 package lib
 
 import (
+	"context"
+	"strconv"
 	"strings"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws"
 	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ec2-terminate-by-id/types"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -16,13 +20,15 @@ import (
 )
 
 //PrepareEC2TerminateByID contains the prepration and injection steps for the experiment
-func PrepareEC2TerminateByID(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareEC2TerminateByID(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	var err error
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	//get the instance id or list of instance ids
@@ -30,26 +36,42 @@ func PrepareEC2TerminateByID(experimentsDetails *experimentTypes.ExperimentDetai
 	if len(instanceIDList) == 0 {
 		return errors.Errorf("no instance id found to terminate")
 	}
+	// verify the instances against the blast radius guardrails, before injecting the chaos
+	// the candidates aren't known for the explicit instance ids, so only the absolute max targets is checked
+	if err := guardrails.CheckInstances(chaosDetails.Guardrails, 0, instanceIDList); err != nil {
+		return err
+	}
+	if err := guardrails.CheckInstanceNodes(clients, chaosDetails.Guardrails, instanceIDList); err != nil {
+		return err
+	}
+	common.SetTargets(chaosDetails, types.TargetedStatus, common.Targets("ec2-instance", instanceIDList...)...)
+	common.SetParameters(chaosDetails, map[string]string{
+		"chaosInterval":    strconv.Itoa(experimentsDetails.ChaosInterval),
+		"region":           experimentsDetails.Region,
+		"managedNodegroup": experimentsDetails.ManagedNodegroup,
+	})
 
 	if strings.ToLower(experimentsDetails.Sequence) == "serial" {
-		if err = InjectChaosInSerialMode(experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = InjectChaosInSerialMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return err
 		}
 	} else {
-		if err = InjectChaosInParallelMode(experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = InjectChaosInParallelMode(ctx, experimentsDetails, instanceIDList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return err
 		}
 	}
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 //InjectChaosInSerialMode will inject the ec2 instance termination in serial mode that is one after other
-func InjectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 	ChaosStartTimeStamp := time.Now().Unix()
@@ -68,43 +90,60 @@ loop:
 		//PowerOff the instance
 		for _, id := range instanceIDList {
 
+			// record the instance in the ledger, so that it is started even if the experiment dies in between
+			if err := addUndoRecord(experimentsDetails, clients, id, chaosDetails); err != nil {
+				return err
+			}
+
 			//Stoping the EC2 instance
 			log.Info("[Chaos]: Stoping the desired EC2 instance")
 			err := awslib.EC2Stop(id, experimentsDetails.Region)
 			if err != nil {
+				common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 				return errors.Errorf("ec2 instance failed to stop, err: %v", err)
 			}
 
 			//Wait for ec2 instance to completely stop
 			log.Infof("[Wait]: Wait for EC2 instance '%v' to come in stopped state", id)
 			if err := awslib.WaitForEC2Down(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+				common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 				return errors.Errorf("unable to stop the ec2 instance, err: %v", err)
 			}
+			common.SetTargets(chaosDetails, types.InjectedStatus, common.Targets("ec2-instance", id)...)
 
 			// run the probes during chaos
 			if len(resultDetails.ProbeDetails) != 0 {
-				if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+				if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 					return err
 				}
 			}
 
 			//Wait for chaos interval
 			log.Infof("[Wait]: Waiting for chaos interval of %vs before starting the instance", experimentsDetails.ChaosInterval)
-			time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+			// the instance is started back before returning, if the abort signal is received in between
+			abortErr := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosInterval)
 
 			//Starting the EC2 instance
 			if experimentsDetails.ManagedNodegroup != "enable" {
 				log.Info("[Chaos]: Starting back the EC2 instance")
 				err = awslib.EC2Start(id, experimentsDetails.Region)
 				if err != nil {
+					common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 					return errors.Errorf("ec2 instance failed to start, err: %v", err)
 				}
 
 				//Wait for ec2 instance to come in running state
 				log.Infof("[Wait]: Wait for EC2 instance '%v' to get in running state", id)
 				if err := awslib.WaitForEC2Up(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+					common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 					return errors.Errorf("unable to start the ec2 instance, err: %v", err)
 				}
+				removeUndoRecord(experimentsDetails, clients, id, chaosDetails)
+				common.SetTargets(chaosDetails, types.RevertedStatus, common.Targets("ec2-instance", id)...)
+			}
+
+			if abortErr != nil {
+				return abortErr
 			}
 
 			//ChaosCurrentTimeStamp contains the current timestamp
@@ -126,7 +165,7 @@ loop:
 }
 
 // InjectChaosInParallelMode will inject the ec2 instance termination in parallel mode that is all at once
-func InjectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, instanceIDList []string, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//ChaosStartTimeStamp contains the start timestamp, when the chaos injection begin
 	ChaosStartTimeStamp := time.Now().Unix()
@@ -144,10 +183,16 @@ loop:
 
 		//PowerOff the instance
 		for _, id := range instanceIDList {
+			// record the instance in the ledger, so that it is started even if the experiment dies in between
+			if err := addUndoRecord(experimentsDetails, clients, id, chaosDetails); err != nil {
+				return err
+			}
+
 			//Stoping the EC2 instance
 			log.Info("[Chaos]: Stoping the desired EC2 instance")
 			err := awslib.EC2Stop(id, experimentsDetails.Region)
 			if err != nil {
+				common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 				return errors.Errorf("ec2 instance failed to stop, err: %v", err)
 			}
 		}
@@ -156,20 +201,23 @@ loop:
 			//Wait for ec2 instance to completely stop
 			log.Infof("[Wait]: Wait for EC2 instance '%v' to come in stopped state", id)
 			if err := awslib.WaitForEC2Down(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+				common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 				return errors.Errorf("unable to stop the ec2 instance, err: %v", err)
 			}
+			common.SetTargets(chaosDetails, types.InjectedStatus, common.Targets("ec2-instance", id)...)
 		}
 
 		// run the probes during chaos
 		if len(resultDetails.ProbeDetails) != 0 {
-			if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+			if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 				return err
 			}
 		}
 
 		//Wait for chaos interval
 		log.Infof("[Wait]: Waiting for chaos interval of %vs before starting the instance", experimentsDetails.ChaosInterval)
-		time.Sleep(time.Duration(experimentsDetails.ChaosInterval) * time.Second)
+		// the instance is started back before returning, if the abort signal is received in between
+		abortErr := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosInterval)
 
 		//Starting the EC2 instance
 		if experimentsDetails.ManagedNodegroup != "enable" {
@@ -178,6 +226,7 @@ loop:
 				log.Info("[Chaos]: Starting back the EC2 instance")
 				err := awslib.EC2Start(id, experimentsDetails.Region)
 				if err != nil {
+					common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 					return errors.Errorf("ec2 instance failed to start, err: %v", err)
 				}
 			}
@@ -187,11 +236,18 @@ loop:
 				log.Infof("[Wait]: Wait for EC2 instance '%v' to get in running state", id)
 				experimentsDetails.Ec2InstanceID = id
 				if err := awslib.WaitForEC2Up(experimentsDetails.Timeout, experimentsDetails.Delay, experimentsDetails.ManagedNodegroup, experimentsDetails.Region, id); err != nil {
+					common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("ec2-instance", id)...)
 					return errors.Errorf("unable to start the ec2 instance, err: %v", err)
 				}
+				removeUndoRecord(experimentsDetails, clients, id, chaosDetails)
+				common.SetTargets(chaosDetails, types.RevertedStatus, common.Targets("ec2-instance", id)...)
 			}
 		}
 
+		if abortErr != nil {
+			return abortErr
+		}
+
 		//ChaosCurrentTimeStamp contains the current timestamp
 		ChaosCurrentTimeStamp := time.Now().Unix()
 
@@ -227,3 +283,28 @@ func InstanceStatusCheckByID(experimentsDetails *experimentTypes.ExperimentDetai
 	}
 	return nil
 }
+
+// undoRecord returns the undo record of the stopped instance
+func undoRecord(experimentsDetails *experimentTypes.ExperimentDetails, instanceID string) ledger.Record {
+	return ledger.Record{
+		Action: ledger.StartEC2Instance,
+		Target: instanceID,
+		Values: map[string]string{"region": experimentsDetails.Region},
+	}
+}
+
+// addUndoRecord writes the undo record of the instance in the ledger
+// the instances of the managed nodegroup are not recorded, as they are replaced instead of being started back
+func addUndoRecord(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, instanceID string, chaosDetails *types.ChaosDetails) error {
+	if experimentsDetails.ManagedNodegroup == "enable" {
+		return nil
+	}
+	return ledger.Add(clients, chaosDetails, undoRecord(experimentsDetails, instanceID))
+}
+
+// removeUndoRecord removes the undo record of the instance from the ledger, once it is started back
+func removeUndoRecord(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, instanceID string, chaosDetails *types.ChaosDetails) {
+	if err := ledger.Remove(clients, chaosDetails, undoRecord(experimentsDetails, instanceID)); err != nil {
+		log.Warnf("The %v instance is started, but the undo record is not removed, err: %v", instanceID, err)
+	}
+}
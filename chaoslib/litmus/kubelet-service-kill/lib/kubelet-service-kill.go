@@ -1,12 +1,15 @@
 package lib
 
 import (
+	"context"
 	"strconv"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/kubelet-service-kill/types"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -18,7 +21,7 @@ import (
 )
 
 // PrepareKubeletKill contains prepration steps before chaos injection
-func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareKubeletKill(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	var err error
 	if experimentsDetails.TargetNode == "" {
@@ -29,6 +32,12 @@ func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, c
 		}
 	}
 
+	// verify the target node against the blast radius guardrails, before injecting the chaos
+	if err := guardrails.CheckNodes(clients, chaosDetails.Guardrails, []string{experimentsDetails.TargetNode}); err != nil {
+		return err
+	}
+	common.SetTargets(chaosDetails, types.TargetedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+
 	log.InfoWithValues("[Info]: Details of node under chaos injection", logrus.Fields{
 		"NodeName": experimentsDetails.TargetNode,
 	})
@@ -38,7 +47,9 @@ func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, c
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	if experimentsDetails.EngineName != "" {
@@ -75,15 +86,17 @@ func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, c
 
 	//Checking the status of helper pod
 	log.Info("[Status]: Checking the status of the helper pod")
-	err = status.CheckApplicationStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckApplicationStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		common.DeleteHelperPodBasedOnJobCleanupPolicy(experimentsDetails.ExperimentName+"-"+experimentsDetails.RunID, appLabel, chaosDetails, clients)
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return errors.Errorf("helper pod is not in running state, err: %v", err)
 	}
+	common.SetTargets(chaosDetails, types.InjectedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return err
 		}
@@ -91,27 +104,35 @@ func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, c
 
 	// Checking for the node to be in not-ready state
 	log.Info("[Status]: Check for the node to be in NotReady state")
-	err = status.CheckNodeNotReadyState(experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckNodeNotReadyState(ctx, experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		common.DeleteHelperPodBasedOnJobCleanupPolicy(experimentsDetails.ExperimentName+"-"+experimentsDetails.RunID, appLabel, chaosDetails, clients)
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return errors.Errorf("application node is not in NotReady state, err: %v", err)
 	}
 
 	// Wait till the completion of helper pod
 	log.Infof("[Wait]: Waiting for %vs till the completion of the helper pod", experimentsDetails.ChaosDuration+30)
 
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+30, experimentsDetails.ExperimentName)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+30, experimentsDetails.ExperimentName)
+	if ctx.Err() != nil {
+		return common.StopHelperPods(appLabel, chaosDetails, clients, common.Targets("node", experimentsDetails.TargetNode)...)
+	}
 	if err != nil || podStatus == "Failed" {
 		common.DeleteHelperPodBasedOnJobCleanupPolicy(experimentsDetails.ExperimentName+"-"+experimentsDetails.RunID, appLabel, chaosDetails, clients)
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return errors.Errorf("helper pod failed, err: %v", err)
 	}
 
 	// Checking the status of target nodes
 	log.Info("[Status]: Getting the status of target nodes")
-	err = status.CheckNodeStatus(experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckNodeStatus(ctx, experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		common.DeleteHelperPodBasedOnJobCleanupPolicy(experimentsDetails.ExperimentName+"-"+experimentsDetails.RunID, appLabel, chaosDetails, clients)
 		log.Warnf("Target nodes are not in the ready state, you may need to manually recover the node, err: %v", err)
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+	} else {
+		common.SetTargets(chaosDetails, types.RevertedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 	}
 
 	//Deleting the helper pod
@@ -124,7 +145,9 @@ func PrepareKubeletKill(experimentsDetails *experimentTypes.ExperimentDetails, c
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -138,7 +161,7 @@ func CreateHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 			Name:      experimentsDetails.ExperimentName + "-" + experimentsDetails.RunID,
 			Namespace: experimentsDetails.ChaosNamespace,
 			Labels: map[string]string{
-				"app":                       experimentsDetails.ExperimentName,
+				"app":                       experimentsDetails.ExperimentName + "-helper",
 				"name":                      experimentsDetails.ExperimentName + "-" + experimentsDetails.RunID,
 				"chaosUID":                  string(experimentsDetails.ChaosUID),
 				"app.kubernetes.io/part-of": "litmus",
@@ -200,5 +223,6 @@ func CreateHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 	}
 
 	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	metrics.HelperPodCreated(err)
 	return err
 }
@@ -1,14 +1,13 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
@@ -30,7 +29,6 @@ const (
 )
 
 var err error
-var inject, abort chan os.Signal
 
 func main() {
 
@@ -40,15 +38,9 @@ func main() {
 	chaosDetails := types.ChaosDetails{}
 	resultDetails := types.ResultDetails{}
 
-	// inject channel is used to transmit signal notifications.
-	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
-
-	// abort channel is used to transmit signal notifications.
-	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+	// ctx is cancelled, once the abort signal is received
+	ctx, cancel := common.AbortContext(context.Background())
+	defer cancel()
 
 	//Getting kubeConfig and Generate ClientSets
 	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
@@ -68,7 +60,7 @@ func main() {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, clients, &chaosDetails)
 
-	err := PreparePodNetworkChaos(&experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
+	err := PreparePodNetworkChaos(ctx, &experimentsDetails, clients, &eventsDetails, &chaosDetails, &resultDetails)
 	if err != nil {
 		log.Fatalf("helper pod failed, err: %v", err)
 	}
@@ -76,7 +68,7 @@ func main() {
 }
 
 //PreparePodNetworkChaos contains the prepration steps before chaos injection
-func PreparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func PreparePodNetworkChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	containerID, err := GetContainerID(experimentsDetails, clients)
 	if err != nil {
@@ -95,17 +87,16 @@ func PreparePodNetworkChaos(experimentsDetails *experimentTypes.ExperimentDetail
 		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	}
 
-	// watching for the abort signal and revert the chaos
-	go abortWatcher(targetPID)
-
 	// injecting network chaos inside target container
-	if err = InjectChaos(experimentsDetails, targetPID); err != nil {
+	if err = InjectChaos(ctx, experimentsDetails, targetPID); err != nil {
 		return err
 	}
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration); err != nil {
+		return revertChaos(targetPID)
+	}
 
 	log.Info("[Chaos]: Stopping the experiment")
 
@@ -284,79 +275,78 @@ func parsePIDFromJSON(j []byte, runtime string) (int, error) {
 // InjectChaos inject the network chaos in target container
 // it is using nsenter command to enter into network namespace of target container
 // and execute the netem command inside it.
-func InjectChaos(experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
+func InjectChaos(ctx context.Context, experimentDetails *experimentTypes.ExperimentDetails, pid int) error {
 
 	netemCommands := os.Getenv("NETEM_COMMAND")
 	destinationIPs := os.Getenv("DESTINATION_IPS")
 
-	select {
-	case <-inject:
-		// stopping the chaos execution, if abort signal recieved
-		os.Exit(1)
-	default:
-		if destinationIPs == "" {
-			tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc replace dev %s root netem %v", pid, experimentDetails.NetworkInterface, netemCommands)
-			cmd := exec.Command("/bin/bash", "-c", tc)
-			out, err := cmd.CombinedOutput()
-			log.Info(cmd.String())
-			if err != nil {
-				log.Error(string(out))
-				return err
-			}
-		} else {
-
-			ips := strings.Split(destinationIPs, ",")
-			var uniqueIps []string
-
-			// removing duplicates ips from the list, if any
-			for i := range ips {
-				isPresent := false
-				for j := range uniqueIps {
-					if ips[i] == uniqueIps[j] {
-						isPresent = true
-					}
-				}
-				if !isPresent {
-					uniqueIps = append(uniqueIps, ips[i])
-				}
+	// stopping the chaos execution, if abort signal recieved
+	if err := common.CheckAbort(ctx); err != nil {
+		return err
+	}
 
-			}
+	if destinationIPs == "" {
+		tc := fmt.Sprintf("sudo nsenter -t %d -n tc qdisc replace dev %s root netem %v", pid, experimentDetails.NetworkInterface, netemCommands)
+		cmd := exec.Command("/bin/bash", "-c", tc)
+		out, err := cmd.CombinedOutput()
+		log.Info(cmd.String())
+		if err != nil {
+			log.Error(string(out))
+			return err
+		}
+	} else {
 
-			// Create a priority-based queue
-			// This instantly creates classes 1:1, 1:2, 1:3
-			priority := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v root handle 1: prio", pid, experimentDetails.NetworkInterface)
-			cmd := exec.Command("/bin/bash", "-c", priority)
-			out, err := cmd.CombinedOutput()
-			log.Info(cmd.String())
-			if err != nil {
-				log.Error(string(out))
-				return err
-			}
+		ips := strings.Split(destinationIPs, ",")
+		var uniqueIps []string
 
-			// Add queueing discipline for 1:3 class.
-			// No traffic is going through 1:3 yet
-			traffic := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v parent 1:3 netem %v", pid, experimentDetails.NetworkInterface, netemCommands)
-			cmd = exec.Command("/bin/bash", "-c", traffic)
-			out, err = cmd.CombinedOutput()
-			log.Info(cmd.String())
-			if err != nil {
-				log.Error(string(out))
-				return err
+		// removing duplicates ips from the list, if any
+		for i := range ips {
+			isPresent := false
+			for j := range uniqueIps {
+				if ips[i] == uniqueIps[j] {
+					isPresent = true
+				}
+			}
+			if !isPresent {
+				uniqueIps = append(uniqueIps, ips[i])
 			}
 
-			for _, ip := range uniqueIps {
-
-				// redirect traffic to specific IP through band 3
-				// It allows ipv4 addresses only
-				if !strings.Contains(ip, ":") {
-					tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip dst %v flowid 1:3", pid, experimentDetails.NetworkInterface, ip)
-					cmd = exec.Command("/bin/bash", "-c", tc)
-					out, err = cmd.CombinedOutput()
-					log.Info(cmd.String())
-					if err != nil {
-						log.Error(string(out))
-						return err
-					}
+		}
+
+		// Create a priority-based queue
+		// This instantly creates classes 1:1, 1:2, 1:3
+		priority := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v root handle 1: prio", pid, experimentDetails.NetworkInterface)
+		cmd := exec.Command("/bin/bash", "-c", priority)
+		out, err := cmd.CombinedOutput()
+		log.Info(cmd.String())
+		if err != nil {
+			log.Error(string(out))
+			return err
+		}
+
+		// Add queueing discipline for 1:3 class.
+		// No traffic is going through 1:3 yet
+		traffic := fmt.Sprintf("sudo nsenter -t %v -n tc qdisc replace dev %v parent 1:3 netem %v", pid, experimentDetails.NetworkInterface, netemCommands)
+		cmd = exec.Command("/bin/bash", "-c", traffic)
+		out, err = cmd.CombinedOutput()
+		log.Info(cmd.String())
+		if err != nil {
+			log.Error(string(out))
+			return err
+		}
+
+		for _, ip := range uniqueIps {
+
+			// redirect traffic to specific IP through band 3
+			// It allows ipv4 addresses only
+			if !strings.Contains(ip, ":") {
+				tc := fmt.Sprintf("sudo nsenter -t %v -n tc filter add dev %v protocol ip parent 1:0 prio 3 u32 match ip dst %v flowid 1:3", pid, experimentDetails.NetworkInterface, ip)
+				cmd = exec.Command("/bin/bash", "-c", tc)
+				out, err = cmd.CombinedOutput()
+				log.Info(cmd.String())
+				if err != nil {
+					log.Error(string(out))
+					return err
 				}
 			}
 		}
@@ -412,25 +402,20 @@ func Getenv(key string, defaultValue string) string {
 	return value
 }
 
-// abortWatcher continuosly watch for the abort signals
-func abortWatcher(targetPID int) {
-
-	for {
-		select {
-		case <-abort:
-			log.Info("[Chaos]: Killing process started because of terminated signal received")
-			log.Info("Chaos Revert Started")
-			// retry thrice for the chaos revert
-			retry := 3
-			for retry > 0 {
-				if err = Killnetem(targetPID); err != nil {
-					log.Errorf("unable to kill netem process, err :%v", err)
-				}
-				retry--
-				time.Sleep(1 * time.Second)
-			}
-			log.Info("Chaos Revert Completed")
-			os.Exit(1)
+// revertChaos reverts the chaos, once the abort signal is received
+func revertChaos(targetPID int) error {
+
+	log.Info("[Chaos]: Killing process started because of terminated signal received")
+	log.Info("Chaos Revert Started")
+	// retry thrice for the chaos revert
+	retry := 3
+	for retry > 0 {
+		if err = Killnetem(targetPID); err != nil {
+			log.Errorf("unable to kill netem process, err :%v", err)
 		}
+		retry--
+		time.Sleep(1 * time.Second)
 	}
+	log.Info("Chaos Revert Completed")
+	return common.ErrChaosStopped
 }
@@ -2,19 +2,18 @@ package lib
 
 import (
 	"bytes"
-	"os"
+	"context"
 	"os/exec"
-	"os/signal"
 	"strconv"
-	"syscall"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-drain/types"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
-	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -24,25 +23,16 @@ import (
 )
 
 var err error
-var inject, abort chan os.Signal
 
 //PrepareNodeDrain contains the prepration steps before chaos injection
-func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
-
-	// inject channel is used to transmit signal notifications.
-	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
-
-	// abort channel is used to transmit signal notifications.
-	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+func PrepareNodeDrain(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	if experimentsDetails.TargetNode == "" {
@@ -53,6 +43,12 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 		}
 	}
 
+	// verify the target node against the blast radius guardrails, before injecting the chaos
+	if err := guardrails.CheckNodes(clients, chaosDetails.Guardrails, []string{experimentsDetails.TargetNode}); err != nil {
+		return err
+	}
+	common.SetTargets(chaosDetails, types.TargetedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+
 	if experimentsDetails.EngineName != "" {
 		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + experimentsDetails.TargetNode + " node"
 		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -61,23 +57,25 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
 
-	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, clients, resultDetails, chaosDetails, eventsDetails)
-
 	// Drain the application node
-	err := DrainNode(experimentsDetails, clients)
-	if err != nil {
+	if err := DrainNode(ctx, experimentsDetails, clients, chaosDetails); err != nil {
+		// the drain is interrupted, if the abort signal is received in between
+		if ctx.Err() != nil {
+			return revertChaos(experimentsDetails, clients, chaosDetails)
+		}
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return err
 	}
+	common.SetTargets(chaosDetails, types.InjectedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 
 	// Verify the status of AUT after reschedule
 	log.Info("[Status]: Verify the status of AUT after reschedule")
-	err = status.CheckApplicationStatus(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckApplicationStatus(ctx, experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		return errors.Errorf("Application status check failed, err: %v", err)
 	}
@@ -85,7 +83,7 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	// Verify the status of Auxiliary Applications after reschedule
 	if experimentsDetails.AuxiliaryAppInfo != "" {
 		log.Info("[Status]: Verify that the Auxiliary Applications are running")
-		err = status.CheckAuxiliaryApplicationStatus(experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+		err = status.CheckAuxiliaryApplicationStatus(ctx, experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 		if err != nil {
 			return errors.Errorf("Auxiliary Applications status check failed, err: %v", err)
 		}
@@ -93,18 +91,23 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration); err != nil {
+		return revertChaos(experimentsDetails, clients, chaosDetails)
+	}
 
 	log.Info("[Chaos]: Stopping the experiment")
 
 	// Uncordon the application node
 	if err := UncordonNode(experimentsDetails, clients); err != nil {
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return err
 	}
+	removeUndoRecord(experimentsDetails, clients, chaosDetails)
+	common.SetTargets(chaosDetails, types.RevertedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 
 	// Checking the status of target nodes
 	log.Info("[Status]: Getting the status of target nodes")
-	err = status.CheckNodeStatus(experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckNodeStatus(ctx, experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		log.Warnf("Target nodes are not in the ready state, you may need to manually recover the node, err: %v", err)
 	}
@@ -112,45 +115,51 @@ func PrepareNodeDrain(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // DrainNode drain the application node
-func DrainNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
-
-	select {
-	case <-inject:
-		// stopping the chaos execution, if abort signal recieved
-		os.Exit(0)
-	default:
-		log.Infof("[Inject]: Draining the %v node", experimentsDetails.TargetNode)
-
-		command := exec.Command("kubectl", "drain", experimentsDetails.TargetNode, "--ignore-daemonsets", "--delete-local-data", "--force", "--timeout", strconv.Itoa(experimentsDetails.ChaosDuration)+"s")
-		var out, stderr bytes.Buffer
-		command.Stdout = &out
-		command.Stderr = &stderr
-		if err := command.Run(); err != nil {
-			log.Infof("Error String: %v", stderr.String())
-			return errors.Errorf("Unable to drain the %v node, err: %v", experimentsDetails.TargetNode, err)
-		}
+func DrainNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
-		return retry.
-			Times(90).
-			Wait(1 * time.Second).
-			Try(func(attempt uint) error {
-				nodeSpec, err := clients.KubeClient.CoreV1().Nodes().Get(experimentsDetails.TargetNode, v1.GetOptions{})
-				if err != nil {
-					return err
-				}
-				if !nodeSpec.Spec.Unschedulable {
-					return errors.Errorf("%v node is not in unschedulable state", experimentsDetails.TargetNode)
-				}
-				return nil
-			})
+	// stopping the chaos execution, if abort signal recieved
+	if err := common.CheckAbort(ctx); err != nil {
+		return err
 	}
-	return nil
+
+	// record the drain in the ledger, so that the node is uncordoned even if the experiment dies in between
+	if err := ledger.Add(clients, chaosDetails, undoRecord(experimentsDetails)); err != nil {
+		return err
+	}
+
+	log.Infof("[Inject]: Draining the %v node", experimentsDetails.TargetNode)
+
+	command := exec.CommandContext(ctx, "kubectl", "drain", experimentsDetails.TargetNode, "--ignore-daemonsets", "--delete-local-data", "--force", "--timeout", strconv.Itoa(experimentsDetails.ChaosDuration)+"s")
+	var out, stderr bytes.Buffer
+	command.Stdout = &out
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		log.Infof("Error String: %v", stderr.String())
+		return errors.Errorf("Unable to drain the %v node, err: %v", experimentsDetails.TargetNode, err)
+	}
+
+	return retry.
+		Times(90).
+		Wait(1 * time.Second).
+		Context(ctx).
+		Try(func(attempt uint) error {
+			nodeSpec, err := clients.KubeClient.CoreV1().Nodes().Get(experimentsDetails.TargetNode, v1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if !nodeSpec.Spec.Unschedulable {
+				return errors.Errorf("%v node is not in unschedulable state", experimentsDetails.TargetNode)
+			}
+			return nil
+		})
 }
 
 // UncordonNode uncordon the application node
@@ -182,39 +191,40 @@ func UncordonNode(experimentsDetails *experimentTypes.ExperimentDetails, clients
 		})
 }
 
-// abortWatcher continuosly watch for the abort signals
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
-
-	for {
-		select {
-		case <-abort:
-			log.Info("[Chaos]: Killing process started because of terminated signal received")
-			log.Info("Chaos Revert Started")
-			// retry thrice for the chaos revert
-			retry := 3
-			for retry > 0 {
-				if err := UncordonNode(experimentsDetails, clients); err != nil {
-					log.Errorf("Unable to uncordon the node, err: %v", err)
-				}
-				retry--
-				time.Sleep(1 * time.Second)
-			}
-			log.Info("Chaos Revert Completed")
-
-			// updating the chaosresult after stopped
-			failStep := "Chaos injection stopped!"
-			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep)
-			result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
-
-			// generating summary event in chaosengine
-			msg := experimentsDetails.ExperimentName + " experiment has been aborted"
-			types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Warning", chaosDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
-
-			// generating summary event in chaosresult
-			types.SetResultEventAttributes(eventsDetails, types.StoppedVerdict, msg, "Warning", resultDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosResult")
-			os.Exit(1)
+// undoRecord returns the undo record of the drained node
+func undoRecord(experimentsDetails *experimentTypes.ExperimentDetails) ledger.Record {
+	return ledger.Record{
+		Action: ledger.UncordonNode,
+		Target: experimentsDetails.TargetNode,
+	}
+}
+
+// removeUndoRecord removes the undo record of the drained node from the ledger, once the node is uncordoned
+func removeUndoRecord(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	if err := ledger.Remove(clients, chaosDetails, undoRecord(experimentsDetails)); err != nil {
+		log.Warnf("The node is uncordoned, but the undo record is not removed, err: %v", err)
+	}
+}
+
+// revertChaos reverts the chaos, once the abort signal is received
+// the node is marked as reverted once it is uncordoned, otherwise as failed
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	log.Info("[Chaos]: Killing process started because of terminated signal received")
+	log.Info("Chaos Revert Started")
+	// retry thrice for the chaos revert
+	targetStatus := types.FailedStatus
+	for retry := 3; retry > 0; retry-- {
+		if err := UncordonNode(experimentsDetails, clients); err != nil {
+			log.Errorf("Unable to uncordon the node, err: %v", err)
+			time.Sleep(1 * time.Second)
+			continue
 		}
+		removeUndoRecord(experimentsDetails, clients, chaosDetails)
+		targetStatus = types.RevertedStatus
+		break
 	}
+	common.SetTargets(chaosDetails, targetStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+	log.Info("Chaos Revert Completed")
+	return common.ErrChaosStopped
 }
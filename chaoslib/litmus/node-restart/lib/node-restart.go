@@ -1,6 +1,7 @@
 package lib
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"strconv"
@@ -9,7 +10,9 @@ import (
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-restart/types"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -37,7 +40,7 @@ const (
 )
 
 // PrepareNodeRestart contains preparation steps before chaos injection
-func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareNodeRestart(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Select the node
 	if experimentsDetails.TargetNode == "" {
@@ -51,6 +54,12 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 		experimentsDetails.TargetNodeIP = targetNode.Status.HostIP
 	}
 
+	// verify the target node against the blast radius guardrails, before injecting the chaos
+	if err := guardrails.CheckNodes(clients, chaosDetails.Guardrails, []string{experimentsDetails.TargetNode}); err != nil {
+		return err
+	}
+	common.SetTargets(chaosDetails, types.TargetedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+
 	log.InfoWithValues("[Info]: Details of application under chaos injection", logrus.Fields{
 		"Target Node":    experimentsDetails.TargetNode,
 		"Target Node IP": experimentsDetails.TargetNodeIP,
@@ -58,7 +67,7 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 
 	// Checking the status of target node
 	log.Info("[Status]: Getting the status of target node")
-	err = status.CheckNodeStatus(experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckNodeStatus(ctx, experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		return errors.Errorf("Target node is not in ready state, err: %v", err)
 	}
@@ -69,7 +78,9 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", strconv.Itoa(experimentsDetails.RampTime))
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	if experimentsDetails.EngineName != "" {
@@ -95,6 +106,13 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 			return errors.Errorf("Unable to get imagePullSecrets, err: %v", err)
 		}
 	}
+	// record the restart arguments, which are used by the helper pod
+	common.SetParameters(chaosDetails, map[string]string{
+		"targetNodeIP":  experimentsDetails.TargetNodeIP,
+		"sshUser":       experimentsDetails.SSHUser,
+		"rebootCommand": experimentsDetails.RebootCommand,
+	})
+
 	// Creating the helper pod to perform node restart
 	err = CreateHelperPod(experimentsDetails, clients)
 	if err != nil {
@@ -103,15 +121,17 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 
 	//Checking the status of helper pod
 	log.Info("[Status]: Checking the status of the helper pod")
-	err = CheckApplicationStatus(experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = CheckApplicationStatus(ctx, experimentsDetails.ChaosNamespace, appLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		common.DeleteHelperPodBasedOnJobCleanupPolicy(experimentsDetails.ExperimentName+"-"+experimentsDetails.RunID, appLabel, chaosDetails, clients)
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return errors.Errorf("helper pod is not in running state, err: %v", err)
 	}
+	common.SetTargets(chaosDetails, types.InjectedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			common.DeleteAllHelperPodBasedOnJobCleanupPolicy(appLabel, chaosDetails, clients)
 			return err
 		}
@@ -120,18 +140,26 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 	// Wait till the completion of helper pod
 	log.Infof("[Wait]: Waiting for %vs till the completion of the helper pod", strconv.Itoa(experimentsDetails.ChaosDuration+30))
 
-	podStatus, err := status.WaitForCompletion(experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+30, experimentsDetails.ExperimentName)
+	podStatus, err := status.WaitForCompletion(ctx, experimentsDetails.ChaosNamespace, appLabel, clients, experimentsDetails.ChaosDuration+30, experimentsDetails.ExperimentName)
+	if ctx.Err() != nil {
+		// the restart can't be reverted by deleting the helper pod, so the node keeps the injected status
+		return common.StopHelperPods(appLabel, chaosDetails, clients)
+	}
 	if err != nil || podStatus == "Failed" {
 		common.DeleteHelperPodBasedOnJobCleanupPolicy(experimentsDetails.ExperimentName+"-"+experimentsDetails.RunID, appLabel, chaosDetails, clients)
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return errors.Errorf("helper pod failed due to, err: %v", err)
 	}
 
 	// Checking the status of application node
 	log.Info("[Status]: Getting the status of application node")
-	err = status.CheckNodeStatus(experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckNodeStatus(ctx, experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		common.DeleteHelperPodBasedOnJobCleanupPolicy(experimentsDetails.ExperimentName+"-"+experimentsDetails.RunID, appLabel, chaosDetails, clients)
 		log.Warnf("Application node is not in the ready state, you may need to manually recover the node, err: %v", err)
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+	} else {
+		common.SetTargets(chaosDetails, types.RevertedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 	}
 
 	//Deleting the helper pod
@@ -144,7 +172,9 @@ func PrepareNodeRestart(experimentsDetails *experimentTypes.ExperimentDetails, c
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", strconv.Itoa(experimentsDetails.RampTime))
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -229,6 +259,7 @@ func CreateHelperPod(experimentsDetails *experimentTypes.ExperimentDetails, clie
 	}
 
 	_, err := clients.KubeClient.CoreV1().Pods(experimentsDetails.ChaosNamespace).Create(helperPod)
+	metrics.HelperPodCreated(err)
 	return err
 }
 
@@ -247,7 +278,7 @@ func GetNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clie
 }
 
 // CheckApplicationStatus checks the status of the AUT
-func CheckApplicationStatus(appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
+func CheckApplicationStatus(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
 
 	// Checking whether application containers are not terminated
 	log.Info("[Status]: Checking whether application containers are not in terminated state")
@@ -256,7 +287,7 @@ func CheckApplicationStatus(appNs, appLabel string, timeout, delay int, clients
 	}
 	// Checking whether application pods are in running or completed state
 	log.Info("[Status]: Checking whether application pods are in running or completed state")
-	if err := status.CheckPodStatusPhase(appNs, appLabel, timeout, delay, clients, "Running", "Completed", "Succeeded"); err != nil {
+	if err := status.CheckPodStatusPhase(ctx, appNs, appLabel, timeout, delay, clients, "Running", "Completed", "Succeeded"); err != nil {
 		return err
 	}
 	return nil
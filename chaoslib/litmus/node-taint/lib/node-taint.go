@@ -1,19 +1,18 @@
 package lib
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"os/signal"
 	"strings"
-	"syscall"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-taint/types"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
-	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/common"
@@ -23,25 +22,16 @@ import (
 )
 
 var err error
-var inject, abort chan os.Signal
 
 //PrepareNodeTaint contains the prepration steps before chaos injection
-func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
-
-	// inject channel is used to transmit signal notifications.
-	inject = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to inject channel.
-	signal.Notify(inject, os.Interrupt, syscall.SIGTERM)
-
-	// abort channel is used to transmit signal notifications.
-	abort = make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM)
+func PrepareNodeTaint(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	if experimentsDetails.TargetNode == "" {
@@ -52,6 +42,13 @@ func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, cli
 		}
 	}
 
+	// verify the target node against the blast radius guardrails, before injecting the chaos
+	if err := guardrails.CheckNodes(clients, chaosDetails.Guardrails, []string{experimentsDetails.TargetNode}); err != nil {
+		return err
+	}
+	common.SetTargets(chaosDetails, types.TargetedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+	common.SetParameters(chaosDetails, map[string]string{"taints": experimentsDetails.Taints})
+
 	if experimentsDetails.EngineName != "" {
 		msg := "Injecting " + experimentsDetails.ExperimentName + " chaos on " + experimentsDetails.TargetNode + " node"
 		types.SetEngineEventAttributes(eventsDetails, types.ChaosInject, msg, "Normal", chaosDetails)
@@ -60,23 +57,23 @@ func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, cli
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
 
-	// watching for the abort signal and revert the chaos
-	go abortWatcher(experimentsDetails, clients, resultDetails, chaosDetails, eventsDetails)
-
 	// taint the application node
-	err := TaintNode(experimentsDetails, clients)
-	if err != nil {
+	if err := TaintNode(ctx, experimentsDetails, clients, chaosDetails); err != nil {
+		if ctx.Err() == nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+		}
 		return err
 	}
+	common.SetTargets(chaosDetails, types.InjectedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 
 	// Verify the status of AUT after reschedule
 	log.Info("[Status]: Verify the status of AUT after reschedule")
-	err = status.CheckApplicationStatus(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckApplicationStatus(ctx, experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		return errors.Errorf("Application status check failed, err: %v", err)
 	}
@@ -84,7 +81,7 @@ func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	// Verify the status of Auxiliary Applications after reschedule
 	if experimentsDetails.AuxiliaryAppInfo != "" {
 		log.Info("[Status]: Verify that the Auxiliary Applications are running")
-		err = status.CheckAuxiliaryApplicationStatus(experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+		err = status.CheckAuxiliaryApplicationStatus(ctx, experimentsDetails.AuxiliaryAppInfo, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 		if err != nil {
 			return errors.Errorf("Auxiliary Applications status check failed, err: %v", err)
 		}
@@ -92,18 +89,23 @@ func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, cli
 
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	if err := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration); err != nil {
+		return revertChaos(experimentsDetails, clients, chaosDetails)
+	}
 
 	log.Info("[Chaos]: Stopping the experiment")
 
 	// remove taint from the application node
 	if err := RemoveTaintFromNode(experimentsDetails, clients); err != nil {
+		common.SetTargets(chaosDetails, types.FailedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 		return err
 	}
+	removeUndoRecord(experimentsDetails, clients, chaosDetails)
+	common.SetTargets(chaosDetails, types.RevertedStatus, common.Targets("node", experimentsDetails.TargetNode)...)
 
 	// Checking the status of target nodes
 	log.Info("[Status]: Getting the status of target nodes")
-	err = status.CheckNodeStatus(experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckNodeStatus(ctx, experimentsDetails.TargetNode, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		log.Warnf("Target nodes are not in the ready state, you may need to manually recover the node, err: %v", err)
 	}
@@ -111,13 +113,15 @@ func PrepareNodeTaint(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // TaintNode taint the application node
-func TaintNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
+func TaintNode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	// get the taint labels & effect
 	TaintKey, TaintValue, TaintEffect := GetTaintDetails(experimentsDetails)
@@ -130,44 +134,54 @@ func TaintNode(experimentsDetails *experimentTypes.ExperimentDetails, clients cl
 		return errors.Errorf("failed to get %v node, err: %v", experimentsDetails.TargetNode, err)
 	}
 
-	// check if the taint already exists
+	// check if the taint already exists, the taints are unique by the key & effect
 	tainted := false
 	for _, taint := range node.Spec.Taints {
-		if taint.Key == TaintKey {
+		if taint.Key == TaintKey && string(taint.Effect) == TaintEffect {
 			tainted = true
 			break
 		}
 	}
 
-	select {
-	case <-inject:
-		// stopping the chaos execution, if abort signal recieved
-		os.Exit(0)
-	default:
-		if !tainted {
-			node.Spec.Taints = append(node.Spec.Taints, apiv1.Taint{
-				Key:    TaintKey,
-				Value:  TaintValue,
-				Effect: apiv1.TaintEffect(TaintEffect),
-			})
-
-			updatedNodeWithTaint, err := clients.KubeClient.CoreV1().Nodes().Update(node)
-			if err != nil || updatedNodeWithTaint == nil {
-				return fmt.Errorf("failed to update %v node after adding taints, err: %v", experimentsDetails.TargetNode, err)
-			}
+	// stopping the chaos execution, if abort signal recieved
+	if err := common.CheckAbort(ctx); err != nil {
+		return err
+	}
+
+	if !tainted {
+		// record the taint in the ledger, so that it is removed even if the experiment dies in between
+		if err := ledger.Add(clients, chaosDetails, undoRecord(experimentsDetails)); err != nil {
+			return err
 		}
 
-		log.Infof("Successfully added taint in %v node", experimentsDetails.TargetNode)
+		node.Spec.Taints = append(node.Spec.Taints, apiv1.Taint{
+			Key:    TaintKey,
+			Value:  TaintValue,
+			Effect: apiv1.TaintEffect(TaintEffect),
+		})
+
+		updatedNodeWithTaint, err := clients.KubeClient.CoreV1().Nodes().Update(node)
+		if err != nil || updatedNodeWithTaint == nil {
+			return fmt.Errorf("failed to update %v node after adding taints, err: %v", experimentsDetails.TargetNode, err)
+		}
+		experimentsDetails.TaintAdded = true
+	} else {
+		log.Infof("[Skip]: The %v node already has the taint, it is not removed after the chaos", experimentsDetails.TargetNode)
 	}
+
+	log.Infof("Successfully added taint in %v node", experimentsDetails.TargetNode)
 	return nil
 }
 
-// RemoveTaintFromNode remove the taint from the application node
+// RemoveTaintFromNode remove the taint, added by the experiment, from the application node
+// the other taints with the same key (e.g. added by the user before the chaos) are kept as it is
 func RemoveTaintFromNode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) error {
 
-	// Get the taint key
-	TaintLabel := strings.Split(experimentsDetails.Taints, ":")
-	TaintKey := strings.Split(TaintLabel[0], "=")[0]
+	if !experimentsDetails.TaintAdded {
+		return nil
+	}
+	// get the taint labels & effect
+	TaintKey, TaintValue, TaintEffect := GetTaintDetails(experimentsDetails)
 
 	// get the node details
 	node, err := clients.KubeClient.CoreV1().Nodes().Get(experimentsDetails.TargetNode, v1.GetOptions{})
@@ -175,23 +189,15 @@ func RemoveTaintFromNode(experimentsDetails *experimentTypes.ExperimentDetails,
 		return errors.Errorf("failed to get %v node, err: %v", experimentsDetails.TargetNode, err)
 	}
 
-	// check if the taint already exists
-	tainted := false
+	// remove only the taint with matching key, value & effect
+	var Newtaints []apiv1.Taint
 	for _, taint := range node.Spec.Taints {
-		if taint.Key == TaintKey {
-			tainted = true
-			break
+		if taint.Key != TaintKey || taint.Value != TaintValue || string(taint.Effect) != TaintEffect {
+			Newtaints = append(Newtaints, taint)
 		}
 	}
 
-	if tainted {
-		var Newtaints []apiv1.Taint
-		// remove all the taints with matching key
-		for _, taint := range node.Spec.Taints {
-			if taint.Key != TaintKey {
-				Newtaints = append(Newtaints, taint)
-			}
-		}
+	if len(Newtaints) != len(node.Spec.Taints) {
 		node.Spec.Taints = Newtaints
 		updatedNodeWithTaint, err := clients.KubeClient.CoreV1().Nodes().Update(node)
 		if err != nil || updatedNodeWithTaint == nil {
@@ -226,39 +232,42 @@ func GetTaintDetails(experimentsDetails *experimentTypes.ExperimentDetails) (str
 	return TaintKey, TaintValue, TaintEffect
 }
 
-// abortWatcher continuosly watch for the abort signals
-func abortWatcher(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
-
-	for {
-		select {
-		case <-abort:
-			log.Info("[Chaos]: Killing process started because of terminated signal received")
-			log.Info("Chaos Revert Started")
-			// retry thrice for the chaos revert
-			retry := 3
-			for retry > 0 {
-				if err := RemoveTaintFromNode(experimentsDetails, clients); err != nil {
-					log.Errorf("Unable to untaint node, err: %v", err)
-				}
-				retry--
-				time.Sleep(1 * time.Second)
-			}
-			log.Info("Chaos Revert Completed")
-
-			// updating the chaosresult after stopped
-			failStep := "Chaos injection stopped!"
-			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep)
-			result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
-
-			// generating summary event in chaosengine
-			msg := experimentsDetails.ExperimentName + " experiment has been aborted"
-			types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Warning", chaosDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
-
-			// generating summary event in chaosresult
-			types.SetResultEventAttributes(eventsDetails, types.StoppedVerdict, msg, "Warning", resultDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosResult")
-			os.Exit(1)
+// undoRecord returns the undo record of the taint, added by the experiment
+func undoRecord(experimentsDetails *experimentTypes.ExperimentDetails) ledger.Record {
+	TaintKey, TaintValue, TaintEffect := GetTaintDetails(experimentsDetails)
+	return ledger.Record{
+		Action: ledger.RemoveTaint,
+		Target: experimentsDetails.TargetNode,
+		Values: map[string]string{"key": TaintKey, "value": TaintValue, "effect": TaintEffect},
+	}
+}
+
+// removeUndoRecord removes the undo record of the taint from the ledger, once the taint is removed
+func removeUndoRecord(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+	if err := ledger.Remove(clients, chaosDetails, undoRecord(experimentsDetails)); err != nil {
+		log.Warnf("The taint is removed, but the undo record is not, err: %v", err)
+	}
+}
+
+// revertChaos reverts the chaos, once the abort signal is received
+// the node is marked as reverted once the taint is removed, otherwise as failed
+func revertChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	log.Info("[Chaos]: Killing process started because of terminated signal received")
+	log.Info("Chaos Revert Started")
+	// retry thrice for the chaos revert
+	targetStatus := types.FailedStatus
+	for retry := 3; retry > 0; retry-- {
+		if err := RemoveTaintFromNode(experimentsDetails, clients); err != nil {
+			log.Errorf("Unable to untaint node, err: %v", err)
+			time.Sleep(1 * time.Second)
+			continue
 		}
+		removeUndoRecord(experimentsDetails, clients, chaosDetails)
+		targetStatus = types.RevertedStatus
+		break
 	}
+	common.SetTargets(chaosDetails, targetStatus, common.Targets("node", experimentsDetails.TargetNode)...)
+	log.Info("Chaos Revert Completed")
+	return common.ErrChaosStopped
 }
@@ -0,0 +1,86 @@
+package lib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/node-taint/types"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// tainted returns true, if the node has the taint with the given key
+func tainted(t *testing.T, clients clients.ClientSets, nodeName, key string) bool {
+	node, err := clients.KubeClient.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unable to get the %v node, err: %v", nodeName, err)
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPrepareNodeTaintAborted(t *testing.T) {
+	clients := clients.NewFakeClientSets([]runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+	}, nil, nil)
+	experimentsDetails := &experimentTypes.ExperimentDetails{
+		ExperimentName: "node-taint",
+		ChaosDuration:  60,
+		TargetNode:     "worker-1",
+		Taints:         "app=node-taint:NoSchedule",
+		Timeout:        2,
+		Delay:          1,
+	}
+	chaosDetails := &types.ChaosDetails{ExperimentName: "node-taint", ChaosNamespace: "litmus", ChaosUID: "chaos-uid"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- PrepareNodeTaint(ctx, experimentsDetails, clients, &types.ResultDetails{}, &types.EventDetails{}, chaosDetails)
+	}()
+
+	// the abort signal is received, once the node is tainted
+	deadline := time.After(5 * time.Second)
+	for !tainted(t, clients, "worker-1", "app") {
+		select {
+		case err := <-done:
+			t.Fatalf("expected the chaos to be injected, returned %v", err)
+		case <-deadline:
+			t.Fatal("expected the node to be tainted")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != common.ErrChaosStopped {
+			t.Fatalf("expected the chaos stopped error, found %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the chaos to be stopped on the abort signal")
+	}
+
+	// the taint & its undo record are removed, before it returns
+	if tainted(t, clients, "worker-1", "app") {
+		t.Fatal("expected the taint to be removed")
+	}
+	records, err := ledger.Pending(clients, "litmus", "chaos-uid")
+	if err != nil || len(records) != 0 {
+		t.Fatalf("expected no pending undo records, found %+v, err: %v", records, err)
+	}
+	if targets := common.GetTargets(chaosDetails); len(targets) != 1 || targets[0].ChaosStatus != types.RevertedStatus {
+		t.Fatalf("expected the node to be reverted, found %+v", targets)
+	}
+}
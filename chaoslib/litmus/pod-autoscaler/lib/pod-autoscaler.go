@@ -1,15 +1,15 @@
 package lib
 
 import (
+	"context"
 	"math"
-	"os"
-	"os/signal"
+	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/pod-autoscaler/types"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
 	"github.com/litmuschaos/litmus-go/pkg/types"
@@ -30,14 +30,18 @@ var (
 )
 
 //PreparePodAutoscaler contains the prepration steps before chaos injection
-func PreparePodAutoscaler(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PreparePodAutoscaler(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
+	common.SetParameters(chaosDetails, map[string]string{"replicas": strconv.Itoa(experimentsDetails.Replicas)})
+
 	// initialise the resource clients
 	appsv1DeploymentClient = clients.KubeClient.AppsV1().Deployments(experimentsDetails.AppNS)
 	appsv1StatefulsetClient = clients.KubeClient.AppsV1().StatefulSets(experimentsDetails.AppNS)
@@ -58,19 +62,24 @@ func PreparePodAutoscaler(experimentsDetails *experimentTypes.ExperimentDetails,
 			"No. Of Deployments": len(deploymentList),
 			"Target Deployments": deploymentList,
 		})
+		common.SetTargets(chaosDetails, types.TargetedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 
-		//calling go routine which will continuously watch for the abort signal
-		go AbortPodAutoScalerChaos(appsUnderTest, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
-
-		err = PodAutoscalerChaosInDeployment(experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails)
+		err = PodAutoscalerChaosInDeployment(ctx, experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails)
+		// rollback the autoscaling, if the abort signal is received in between
+		if ctx.Err() != nil {
+			return AbortPodAutoScalerChaos(appsUnderTest, experimentsDetails, clients, chaosDetails)
+		}
 		if err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 			return errors.Errorf("Unable to perform autoscaling, err: %v", err)
 		}
 
-		err = AutoscalerRecoveryInDeployment(experimentsDetails, clients, appsUnderTest)
+		err = AutoscalerRecoveryInDeployment(experimentsDetails, clients, appsUnderTest, chaosDetails)
 		if err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 			return errors.Errorf("Unable to rollback the autoscaling, err: %v", err)
 		}
+		common.SetTargets(chaosDetails, types.RevertedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 
 	case "statefulset", "statefulsets":
 
@@ -87,17 +96,23 @@ func PreparePodAutoscaler(experimentsDetails *experimentTypes.ExperimentDetails,
 			"No. Of Statefulsets": len(stsList),
 			"Target Statefulsets": stsList,
 		})
+		common.SetTargets(chaosDetails, types.TargetedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 
-		//calling go routine which will continuously watch for the abort signal
-		go AbortPodAutoScalerChaos(appsUnderTest, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
-
-		if err = PodAutoscalerChaosInStatefulset(experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails); err != nil {
+		err = PodAutoscalerChaosInStatefulset(ctx, experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails)
+		// rollback the autoscaling, if the abort signal is received in between
+		if ctx.Err() != nil {
+			return AbortPodAutoScalerChaos(appsUnderTest, experimentsDetails, clients, chaosDetails)
+		}
+		if err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 			return errors.Errorf("Unable to perform autoscaling, err: %v", err)
 		}
 
-		if err = AutoscalerRecoveryInStatefulset(experimentsDetails, clients, appsUnderTest); err != nil {
+		if err = AutoscalerRecoveryInStatefulset(experimentsDetails, clients, appsUnderTest, chaosDetails); err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 			return errors.Errorf("Unable to rollback the autoscaling, err: %v", err)
 		}
+		common.SetTargets(chaosDetails, types.RevertedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 
 	default:
 		return errors.Errorf("application type '%s' is not supported for the chaos", experimentsDetails.AppKind)
@@ -106,7 +121,9 @@ func PreparePodAutoscaler(experimentsDetails *experimentTypes.ExperimentDetails,
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -155,7 +172,12 @@ func GetStatefulsetDetails(experimentsDetails *experimentTypes.ExperimentDetails
 }
 
 //PodAutoscalerChaosInDeployment scales up the replicas of deployment and verify the status
-func PodAutoscalerChaosInDeployment(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PodAutoscalerChaosInDeployment(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+
+	// record the initial replicas in the ledger, so that they are restored even if the experiment dies in between
+	if err := addUndoRecords(ledger.ScaleDeployment, experimentsDetails, clients, appsUnderTest, chaosDetails); err != nil {
+		return err
+	}
 
 	// Scale Application
 	retryErr := retries.RetryOnConflict(retries.DefaultRetry, func() error {
@@ -179,9 +201,10 @@ func PodAutoscalerChaosInDeployment(experimentsDetails *experimentTypes.Experime
 	if retryErr != nil {
 		return errors.Errorf("Unable to scale the deployment, err: %v", retryErr)
 	}
+	common.SetTargets(chaosDetails, types.InjectedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 	log.Info("Application Started Scaling")
 
-	err = DeploymentStatusCheck(experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails)
+	err = DeploymentStatusCheck(ctx, experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails)
 	if err != nil {
 		return errors.Errorf("Status Check failed, err: %v", err)
 	}
@@ -190,7 +213,12 @@ func PodAutoscalerChaosInDeployment(experimentsDetails *experimentTypes.Experime
 }
 
 //PodAutoscalerChaosInStatefulset scales up the replicas of statefulset and verify the status
-func PodAutoscalerChaosInStatefulset(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PodAutoscalerChaosInStatefulset(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+
+	// record the initial replicas in the ledger, so that they are restored even if the experiment dies in between
+	if err := addUndoRecords(ledger.ScaleStatefulSet, experimentsDetails, clients, appsUnderTest, chaosDetails); err != nil {
+		return err
+	}
 
 	// Scale Application
 	retryErr := retries.RetryOnConflict(retries.DefaultRetry, func() error {
@@ -213,9 +241,10 @@ func PodAutoscalerChaosInStatefulset(experimentsDetails *experimentTypes.Experim
 	if retryErr != nil {
 		return errors.Errorf("Unable to scale the statefulset, err: %v", retryErr)
 	}
+	common.SetTargets(chaosDetails, types.InjectedStatus, appTargets(experimentsDetails, appsUnderTest)...)
 	log.Info("Application Started Scaling")
 
-	err = StatefulsetStatusCheck(experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails)
+	err = StatefulsetStatusCheck(ctx, experimentsDetails, clients, appsUnderTest, resultDetails, eventsDetails, chaosDetails)
 	if err != nil {
 		return errors.Errorf("Status Check failed, err: %v", err)
 	}
@@ -224,7 +253,7 @@ func PodAutoscalerChaosInStatefulset(experimentsDetails *experimentTypes.Experim
 }
 
 // DeploymentStatusCheck check the status of deployment and verify the available replicas
-func DeploymentStatusCheck(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func DeploymentStatusCheck(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Record start timestamp
 	ChaosStartTimeStamp := time.Now().Unix()
@@ -233,6 +262,7 @@ func DeploymentStatusCheck(experimentsDetails *experimentTypes.ExperimentDetails
 	err = retry.
 		Times(uint(experimentsDetails.ChaosDuration / experimentsDetails.Delay)).
 		Wait(time.Duration(experimentsDetails.Delay) * time.Second).
+		Context(ctx).
 		Try(func(attempt uint) error {
 			for _, app := range appsUnderTest {
 				deployment, err := appsv1DeploymentClient.Get(app.AppName, metav1.GetOptions{})
@@ -250,7 +280,7 @@ func DeploymentStatusCheck(experimentsDetails *experimentTypes.ExperimentDetails
 		})
 
 	if isFailed {
-		err = AutoscalerRecoveryInDeployment(experimentsDetails, clients, appsUnderTest)
+		err = AutoscalerRecoveryInDeployment(experimentsDetails, clients, appsUnderTest, chaosDetails)
 		if err != nil {
 			return errors.Errorf("Unable to perform autoscaling, err: %v", err)
 		}
@@ -262,7 +292,7 @@ func DeploymentStatusCheck(experimentsDetails *experimentTypes.ExperimentDetails
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
@@ -270,14 +300,16 @@ func DeploymentStatusCheck(experimentsDetails *experimentTypes.ExperimentDetails
 	ChaosCurrentTimeStamp := time.Now().Unix()
 	if int(ChaosCurrentTimeStamp-ChaosStartTimeStamp) <= experimentsDetails.ChaosDuration {
 		log.Info("[Wait]: Waiting for completion of chaos duration")
-		time.Sleep(time.Duration(experimentsDetails.ChaosDuration-int(ChaosCurrentTimeStamp-ChaosStartTimeStamp)) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration-int(ChaosCurrentTimeStamp-ChaosStartTimeStamp)); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 // StatefulsetStatusCheck check the status of statefulset and verify the available replicas
-func StatefulsetStatusCheck(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func StatefulsetStatusCheck(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Record start timestamp
 	ChaosStartTimeStamp := time.Now().Unix()
@@ -286,6 +318,7 @@ func StatefulsetStatusCheck(experimentsDetails *experimentTypes.ExperimentDetail
 	err = retry.
 		Times(uint(experimentsDetails.ChaosDuration / experimentsDetails.Delay)).
 		Wait(time.Duration(experimentsDetails.Delay) * time.Second).
+		Context(ctx).
 		Try(func(attempt uint) error {
 			for _, app := range appsUnderTest {
 				statefulset, err := appsv1StatefulsetClient.Get(app.AppName, metav1.GetOptions{})
@@ -303,7 +336,7 @@ func StatefulsetStatusCheck(experimentsDetails *experimentTypes.ExperimentDetail
 		})
 
 	if isFailed {
-		err = AutoscalerRecoveryInStatefulset(experimentsDetails, clients, appsUnderTest)
+		err = AutoscalerRecoveryInStatefulset(experimentsDetails, clients, appsUnderTest, chaosDetails)
 		if err != nil {
 			return errors.Errorf("Unable to perform autoscaling, err: %v", err)
 		}
@@ -315,7 +348,7 @@ func StatefulsetStatusCheck(experimentsDetails *experimentTypes.ExperimentDetail
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err = probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err = probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
@@ -324,14 +357,16 @@ func StatefulsetStatusCheck(experimentsDetails *experimentTypes.ExperimentDetail
 	ChaosCurrentTimeStamp := time.Now().Unix()
 	if int(ChaosCurrentTimeStamp-ChaosStartTimeStamp) <= experimentsDetails.ChaosDuration {
 		log.Info("[Wait]: Waiting for completion of chaos duration")
-		time.Sleep(time.Duration(experimentsDetails.ChaosDuration-int(ChaosCurrentTimeStamp-ChaosStartTimeStamp)) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration-int(ChaosCurrentTimeStamp-ChaosStartTimeStamp)); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 //AutoscalerRecoveryInDeployment rollback the replicas to initial values in deployment
-func AutoscalerRecoveryInDeployment(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest) error {
+func AutoscalerRecoveryInDeployment(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, chaosDetails *types.ChaosDetails) error {
 
 	// Scale back to initial number of replicas
 	retryErr := retries.RetryOnConflict(retries.DefaultRetry, func() error {
@@ -354,6 +389,7 @@ func AutoscalerRecoveryInDeployment(experimentsDetails *experimentTypes.Experime
 	if retryErr != nil {
 		return errors.Errorf("Unable to rollback the deployment, err: %v", retryErr)
 	}
+	removeUndoRecords(ledger.ScaleDeployment, experimentsDetails, clients, appsUnderTest, chaosDetails)
 	log.Info("[Info]: Application pod started rolling back")
 
 	err = retry.
@@ -382,7 +418,7 @@ func AutoscalerRecoveryInDeployment(experimentsDetails *experimentTypes.Experime
 }
 
 //AutoscalerRecoveryInStatefulset rollback the replicas to initial values in deployment
-func AutoscalerRecoveryInStatefulset(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest) error {
+func AutoscalerRecoveryInStatefulset(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, chaosDetails *types.ChaosDetails) error {
 
 	// Scale back to initial number of replicas
 	retryErr := retries.RetryOnConflict(retries.DefaultRetry, func() error {
@@ -405,6 +441,7 @@ func AutoscalerRecoveryInStatefulset(experimentsDetails *experimentTypes.Experim
 	if retryErr != nil {
 		return errors.Errorf("Unable to rollback the statefulset, err: %v", retryErr)
 	}
+	removeUndoRecords(ledger.ScaleStatefulSet, experimentsDetails, clients, appsUnderTest, chaosDetails)
 	log.Info("[Info]: Application pod started rolling back")
 
 	err = retry.
@@ -435,43 +472,65 @@ func AutoscalerRecoveryInStatefulset(experimentsDetails *experimentTypes.Experim
 
 func int32Ptr(i int32) *int32 { return &i }
 
-//AbortPodAutoScalerChaos go routine will continuously watch for the abort signal for the entire chaos duration and generate the required events and result
-func AbortPodAutoScalerChaos(appsUnderTest []experimentTypes.ApplicationUnderTest, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) {
-
-	// signChan channel is used to transmit signal notifications.
-	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
-
-	for {
-		select {
-		case <-signChan:
-			log.Info("[Chaos]: Revert Started")
-			// Note that we are attempting recovery (in this case scaling down to original replica count) after ..
-			// .. the tasks to patch results & generate events. This is so because the func AutoscalerRecovery..
-			// ..takes more time to complete - it involves a status check post the downscale. We have a period of ..
-			// .. few seconds before the pod deletion/removal occurs from the time the TERM is caught and thereby..
-			// ..run the risk of not updating the status of the objects/create events. With the current approach..
-			// ..tests indicate we succeed with the downscale/patch call, even if the status checks take longer
-			// As such, this is a workaround, and other solutions such as usage of pre-stop hooks etc., need to be explored
-			// Other experiments have simpler "recoveries" that are more or less guaranteed to work.
-			switch strings.ToLower(experimentsDetails.AppKind) {
-			case "deployment", "deployments":
-				if err := AutoscalerRecoveryInDeployment(experimentsDetails, clients, appsUnderTest); err != nil {
-					log.Errorf("the recovery after abortion failed err: %v", err)
-				}
-			case "statefulset", "statefulsets":
+// appTargets returns the target details of the applications under chaos
+func appTargets(experimentsDetails *experimentTypes.ExperimentDetails, appsUnderTest []experimentTypes.ApplicationUnderTest) []types.TargetDetails {
+	kind := strings.TrimSuffix(strings.ToLower(experimentsDetails.AppKind), "s")
+	var targets []types.TargetDetails
+	for _, app := range appsUnderTest {
+		targets = append(targets, types.TargetDetails{Kind: kind, Name: app.AppName, Namespace: experimentsDetails.AppNS})
+	}
+	return targets
+}
 
-				if err := AutoscalerRecoveryInStatefulset(experimentsDetails, clients, appsUnderTest); err != nil {
-					log.Errorf("the recovery after abortion failed err: %v", err)
-				}
+// undoRecord returns the undo record of the given application, with its initial replicas
+func undoRecord(action string, experimentsDetails *experimentTypes.ExperimentDetails, app experimentTypes.ApplicationUnderTest) ledger.Record {
+	return ledger.Record{
+		Action:    action,
+		Target:    app.AppName,
+		Namespace: experimentsDetails.AppNS,
+		Values:    map[string]string{"replicas": strconv.Itoa(app.ReplicaCount)},
+	}
+}
 
-			default:
-				log.Errorf("application type '%s' is not supported for the chaos", experimentsDetails.AppKind)
-			}
-			log.Info("[Chaos]: Revert Completed")
+// addUndoRecords writes the undo records of all the applications under chaos in the ledger
+func addUndoRecords(action string, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, chaosDetails *types.ChaosDetails) error {
+	for _, app := range appsUnderTest {
+		if err := ledger.Add(clients, chaosDetails, undoRecord(action, experimentsDetails, app)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			os.Exit(1)
+// removeUndoRecords removes the undo records of all the applications under chaos from the ledger, once they are scaled back
+func removeUndoRecords(action string, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, appsUnderTest []experimentTypes.ApplicationUnderTest, chaosDetails *types.ChaosDetails) {
+	for _, app := range appsUnderTest {
+		if err := ledger.Remove(clients, chaosDetails, undoRecord(action, experimentsDetails, app)); err != nil {
+			log.Warnf("The %v application is scaled back, but the undo record is not removed, err: %v", app.AppName, err)
 		}
 	}
 }
+
+//AbortPodAutoScalerChaos rollback the replicas to initial values, once the abort signal is received
+func AbortPodAutoScalerChaos(appsUnderTest []experimentTypes.ApplicationUnderTest, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	log.Info("[Chaos]: Revert Started")
+	var err error
+	switch strings.ToLower(experimentsDetails.AppKind) {
+	case "deployment", "deployments":
+		err = AutoscalerRecoveryInDeployment(experimentsDetails, clients, appsUnderTest, chaosDetails)
+	case "statefulset", "statefulsets":
+		err = AutoscalerRecoveryInStatefulset(experimentsDetails, clients, appsUnderTest, chaosDetails)
+	default:
+		err = errors.Errorf("application type '%s' is not supported for the chaos", experimentsDetails.AppKind)
+	}
+	if err != nil {
+		log.Errorf("the recovery after abortion failed err: %v", err)
+		common.SetTargets(chaosDetails, types.FailedStatus, appTargets(experimentsDetails, appsUnderTest)...)
+	} else {
+		common.SetTargets(chaosDetails, types.RevertedStatus, appTargets(experimentsDetails, appsUnderTest)...)
+	}
+	log.Info("[Chaos]: Revert Completed")
+
+	return common.ErrChaosStopped
+}
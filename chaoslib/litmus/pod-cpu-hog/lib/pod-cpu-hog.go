@@ -1,9 +1,8 @@
 package lib
 
 import (
-	"os"
-	"os/signal"
-	"syscall"
+	"context"
+	"strconv"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
@@ -37,7 +36,7 @@ func StressCPU(experimentsDetails *experimentTypes.ExperimentDetails, podName st
 }
 
 //ExperimentCPU function orchestrates the experiment by calling the StressCPU function for every core, of every container, of every pod that is targeted
-func ExperimentCPU(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func ExperimentCPU(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	// Get the target pod details for the chaos execution
 	// if the target pod is not defined it will derive the random target pod list using pod affected percentage
@@ -63,12 +62,20 @@ func ExperimentCPU(experimentsDetails *experimentTypes.ExperimentDetails, client
 		}
 	}
 
+	// record the cpu stress arguments, which are injected into the target containers
+	common.SetParameters(chaosDetails, map[string]string{
+		"targetContainer": experimentsDetails.TargetContainer,
+		"cpuCores":        strconv.Itoa(experimentsDetails.CPUcores),
+		"chaosInjectCmd":  experimentsDetails.ChaosInjectCmd,
+		"sequence":        experimentsDetails.Sequence,
+	})
+
 	if experimentsDetails.Sequence == "serial" {
-		if err = InjectChaosInSerialMode(experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = InjectChaosInSerialMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return err
 		}
 	} else {
-		if err = InjectChaosInParallelMode(experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
+		if err = InjectChaosInParallelMode(ctx, experimentsDetails, targetPodList, clients, resultDetails, eventsDetails, chaosDetails); err != nil {
 			return err
 		}
 	}
@@ -77,11 +84,11 @@ func ExperimentCPU(experimentsDetails *experimentTypes.ExperimentDetails, client
 }
 
 // InjectChaosInSerialMode stressed the cpu of all target application serially (one by one)
-func InjectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
@@ -106,25 +113,25 @@ func InjectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 		for i := 0; i < experimentsDetails.CPUcores; i++ {
 			go StressCPU(experimentsDetails, pod.Name, clients)
 		}
+		common.SetTargets(chaosDetails, types.InjectedStatus, common.PodTargets(pod)...)
 
 		log.Infof("[Chaos]:Waiting for: %vs", experimentsDetails.ChaosDuration)
 
-		// signChan channel is used to transmit signal notifications.
-		signChan := make(chan os.Signal, 1)
-		// Catch and relay certain signal(s) to signChan channel.
-		signal.Notify(signChan, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
 	loop:
 		for {
 			endTime = time.After(timeDelay)
 			select {
-			case <-signChan:
+			case <-ctx.Done():
 				log.Info("[Chaos]: Revert Started")
 				err := KillStressCPUSerial(experimentsDetails, pod.Name, clients)
 				if err != nil {
 					log.Errorf("Error in Kill stress after abortion, err: %v", err)
+					common.SetTargets(chaosDetails, types.FailedStatus, common.PodTargets(pod)...)
+				} else {
+					common.SetTargets(chaosDetails, types.RevertedStatus, common.PodTargets(pod)...)
 				}
 				log.Info("[Chaos]: Revert Completed")
-				os.Exit(1)
+				return common.ErrChaosStopped
 			case <-endTime:
 				log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 				endTime = nil
@@ -132,18 +139,20 @@ func InjectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetai
 			}
 		}
 		if err := KillStressCPUSerial(experimentsDetails, pod.Name, clients); err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, common.PodTargets(pod)...)
 			return err
 		}
+		common.SetTargets(chaosDetails, types.RevertedStatus, common.PodTargets(pod)...)
 	}
 	return nil
 }
 
 // InjectChaosInParallelMode stressed the cpu of all target application in parallel mode (all at once)
-func InjectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
@@ -167,32 +176,29 @@ func InjectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDet
 		for i := 0; i < experimentsDetails.CPUcores; i++ {
 			go StressCPU(experimentsDetails, pod.Name, clients)
 		}
+		common.SetTargets(chaosDetails, types.InjectedStatus, common.PodTargets(pod)...)
 	}
 
 	log.Infof("[Chaos]:Waiting for: %vs", experimentsDetails.ChaosDuration)
 
-	// signChan channel is used to transmit signal notifications.
-	signChan := make(chan os.Signal, 1)
-	// Catch and relay certain signal(s) to signChan channel.
-	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
 loop:
 	for {
 		endTime = time.After(timeDelay)
 		select {
-		case <-signChan:
+		case <-ctx.Done():
 			log.Info("[Chaos]: Revert Started")
-			if err := KillStressCPUParallel(experimentsDetails, targetPodList, clients); err != nil {
+			if err := KillStressCPUParallel(experimentsDetails, targetPodList, clients, chaosDetails); err != nil {
 				log.Errorf("Error in Kill stress after abortion, err: %v", err)
 			}
 			log.Info("[Chaos]: Revert Completed")
-			os.Exit(1)
+			return common.ErrChaosStopped
 		case <-endTime:
 			log.Infof("[Chaos]: Time is up for experiment: %v", experimentsDetails.ExperimentName)
 			endTime = nil
 			break loop
 		}
 	}
-	if err := KillStressCPUParallel(experimentsDetails, targetPodList, clients); err != nil {
+	if err := KillStressCPUParallel(experimentsDetails, targetPodList, clients, chaosDetails); err != nil {
 		return err
 	}
 
@@ -200,22 +206,26 @@ loop:
 }
 
 //PrepareCPUstress contains the steps for prepration before chaos
-func PrepareCPUstress(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareCPUstress(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	//Starting the CPU stress experiment
-	err := ExperimentCPU(experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
+	err := ExperimentCPU(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails)
 	if err != nil {
 		return err
 	}
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -250,13 +260,16 @@ func KillStressCPUSerial(experimentsDetails *experimentTypes.ExperimentDetails,
 
 // KillStressCPUParallel function to kill all the stress process running inside target container
 // Triggered by either timeout of chaos duration or termination of the experiment
-func KillStressCPUParallel(experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets) error {
+// the target pods are marked as reverted once the stress process is killed, otherwise as failed
+func KillStressCPUParallel(experimentsDetails *experimentTypes.ExperimentDetails, targetPodList corev1.PodList, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	for _, pod := range targetPodList.Items {
 
 		if err := KillStressCPUSerial(experimentsDetails, pod.Name, clients); err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, common.PodTargets(pod)...)
 			return err
 		}
+		common.SetTargets(chaosDetails, types.RevertedStatus, common.PodTargets(pod)...)
 	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package lib
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -20,20 +21,29 @@ import (
 var err error
 
 //PreparePodDelete contains the prepration steps before chaos injection
-func PreparePodDelete(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PreparePodDelete(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
+	// record the parameters of the pod deletion
+	common.SetParameters(chaosDetails, map[string]string{
+		"force":         strconv.FormatBool(experimentsDetails.Force),
+		"chaosInterval": experimentsDetails.ChaosInterval,
+		"sequence":      experimentsDetails.Sequence,
+	})
+
 	if experimentsDetails.Sequence == "serial" {
-		if err = InjectChaosInSerialMode(experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
+		if err = InjectChaosInSerialMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
 			return err
 		}
 	} else {
-		if err = InjectChaosInParallelMode(experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
+		if err = InjectChaosInParallelMode(ctx, experimentsDetails, clients, chaosDetails, eventsDetails, resultDetails); err != nil {
 			return err
 		}
 	}
@@ -41,17 +51,19 @@ func PreparePodDelete(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // InjectChaosInSerialMode delete the target application pods serial mode(one by one)
-func InjectChaosInSerialMode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
+func InjectChaosInSerialMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
@@ -96,12 +108,14 @@ loop:
 				err = clients.KubeClient.CoreV1().Pods(experimentsDetails.AppNS).Delete(pod.Name, &v1.DeleteOptions{})
 			}
 			if err != nil {
+				common.SetTargets(chaosDetails, types.FailedStatus, common.PodTargets(pod)...)
 				return err
 			}
+			common.SetTargets(chaosDetails, types.InjectedStatus, common.PodTargets(pod)...)
 
 			switch chaosDetails.Randomness {
 			case true:
-				if err := common.RandomInterval(experimentsDetails.ChaosInterval); err != nil {
+				if err := common.RandomInterval(ctx, experimentsDetails.ChaosInterval); err != nil {
 					return err
 				}
 			default:
@@ -109,15 +123,20 @@ loop:
 				if experimentsDetails.ChaosInterval != "" {
 					log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaosInterval)
 					waitTime, _ := strconv.Atoi(experimentsDetails.ChaosInterval)
-					common.WaitForDuration(waitTime)
+					if err := common.WaitForDurationWithContext(ctx, waitTime); err != nil {
+						return err
+					}
 				}
 			}
 
 			//Verify the status of pod after the chaos injection
 			log.Info("[Status]: Verification for the recreation of application pod")
-			if err = status.CheckApplicationStatus(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+			if err = status.CheckApplicationStatus(ctx, experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+				common.SetTargets(chaosDetails, types.FailedStatus, common.PodTargets(pod)...)
 				return err
 			}
+			// the deleted pod is recreated, it is marked as reverted
+			common.SetTargets(chaosDetails, types.RevertedStatus, common.PodTargets(pod)...)
 
 			//ChaosCurrentTimeStamp contains the current timestamp
 			ChaosCurrentTimeStamp := time.Now().Unix()
@@ -140,11 +159,11 @@ loop:
 }
 
 // InjectChaosInParallelMode delete the target application pods in parallel mode (all at once)
-func InjectChaosInParallelMode(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
+func InjectChaosInParallelMode(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails, resultDetails *types.ResultDetails) error {
 
 	// run the probes during chaos
 	if len(resultDetails.ProbeDetails) != 0 {
-		if err := probe.RunProbes(chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, "DuringChaos", eventsDetails); err != nil {
 			return err
 		}
 	}
@@ -189,13 +208,15 @@ loop:
 				err = clients.KubeClient.CoreV1().Pods(experimentsDetails.AppNS).Delete(pod.Name, &v1.DeleteOptions{})
 			}
 			if err != nil {
+				common.SetTargets(chaosDetails, types.FailedStatus, common.PodTargets(pod)...)
 				return err
 			}
+			common.SetTargets(chaosDetails, types.InjectedStatus, common.PodTargets(pod)...)
 		}
 
 		switch chaosDetails.Randomness {
 		case true:
-			if err := common.RandomInterval(experimentsDetails.ChaosInterval); err != nil {
+			if err := common.RandomInterval(ctx, experimentsDetails.ChaosInterval); err != nil {
 				return err
 			}
 		default:
@@ -203,15 +224,20 @@ loop:
 			if experimentsDetails.ChaosInterval != "" {
 				log.Infof("[Wait]: Wait for the chaos interval %vs", experimentsDetails.ChaosInterval)
 				waitTime, _ := strconv.Atoi(experimentsDetails.ChaosInterval)
-				common.WaitForDuration(waitTime)
+				if err := common.WaitForDurationWithContext(ctx, waitTime); err != nil {
+					return err
+				}
 			}
 		}
 
 		//Verify the status of pod after the chaos injection
 		log.Info("[Status]: Verification for the recreation of application pod")
-		if err = status.CheckApplicationStatus(experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+		if err = status.CheckApplicationStatus(ctx, experimentsDetails.AppNS, experimentsDetails.AppLabel, experimentsDetails.Timeout, experimentsDetails.Delay, clients); err != nil {
+			common.SetTargets(chaosDetails, types.FailedStatus, common.PodTargets(targetPodList.Items...)...)
 			return err
 		}
+		// the deleted pods are recreated, they are marked as reverted
+		common.SetTargets(chaosDetails, types.RevertedStatus, common.PodTargets(targetPodList.Items...)...)
 
 		//ChaosCurrentTimeStamp contains the current timestamp
 		ChaosCurrentTimeStamp := time.Now().Unix()
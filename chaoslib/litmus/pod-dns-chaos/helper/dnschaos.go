@@ -1,14 +1,13 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
@@ -18,13 +17,12 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/result"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientTypes "k8s.io/apimachinery/pkg/types"
 )
 
-var abort, injectAbort chan os.Signal
-
 func main() {
 
 	experimentsDetails := experimentTypes.ExperimentDetails{}
@@ -33,13 +31,9 @@ func main() {
 	chaosDetails := types.ChaosDetails{}
 	resultDetails := types.ResultDetails{}
 
-	// abort channel is used to transmit signal notifications.
-	abort = make(chan os.Signal, 1)
-	// abort channel is used to transmit signal notifications.
-	injectAbort = make(chan os.Signal, 1)
-
-	// Catch and relay certain signal(s) to abort channel.
-	signal.Notify(abort, os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	// ctx is cancelled, once the abort signal is received
+	ctx, cancel := common.AbortContext(context.Background())
+	defer cancel()
 
 	//Getting kubeConfig and Generate ClientSets
 	if err := client.GenerateClientSetFromKubeConfig(); err != nil {
@@ -59,7 +53,7 @@ func main() {
 	// Set the chaos result uid
 	result.SetResultUID(&resultDetails, client, &chaosDetails)
 
-	err := PreparePodDNSChaos(&experimentsDetails, client, &eventsDetails, &chaosDetails, &resultDetails)
+	err := PreparePodDNSChaos(ctx, &experimentsDetails, client, &eventsDetails, &chaosDetails, &resultDetails)
 	if err != nil {
 		log.Fatalf("helper pod failed, err: %v", err)
 	}
@@ -67,7 +61,7 @@ func main() {
 }
 
 //PreparePodDNSChaos contains the preparation steps before chaos injection
-func PreparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+func PreparePodDNSChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
 
 	containerID, err := GetContainerID(experimentsDetails, clients)
 	if err != nil {
@@ -95,25 +89,23 @@ func PreparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 
 	// injecting dns chaos inside target container
 	go func() {
-		select {
-		case <-injectAbort:
+		if err := common.CheckAbort(ctx); err != nil {
 			log.Info("[Chaos]: Abort received, skipping chaos injection")
-		default:
-			err = cmd.Run()
-			if err != nil {
-				log.Fatalf("dns interceptor failed : %v", err)
-			}
+			return
+		}
+		err = cmd.Run()
+		if err != nil {
+			log.Fatalf("dns interceptor failed : %v", err)
 		}
 	}()
 
-	timeChan := time.Tick(time.Duration(experimentsDetails.ChaosDuration) * time.Second)
 	log.Infof("[Chaos]: Waiting for %vs", experimentsDetails.ChaosDuration)
 
 	// either wait for abort signal or chaos duration
-	select {
-	case <-abort:
+	abortErr := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration)
+	if abortErr != nil {
 		log.Info("[Chaos]: Killing process started because of terminated signal received")
-	case <-timeChan:
+	} else {
 		log.Info("[Chaos]: Stopping the experiment, chaos duration over")
 	}
 
@@ -140,7 +132,7 @@ func PreparePodDNSChaos(experimentsDetails *experimentTypes.ExperimentDetails, c
 		time.Sleep(1 * time.Second)
 	}
 	log.Info("Chaos Revert Completed")
-	return nil
+	return abortErr
 }
 
 //GetContainerID extract out the container id of the target container
@@ -1,6 +1,7 @@
 package lib
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -19,12 +20,14 @@ import (
 )
 
 //PreparePodDelete contains the prepration steps before chaos injection
-func PreparePodDelete(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PreparePodDelete(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	//Waiting for the ramp time before chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time before injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 
 	if experimentsDetails.ChaosServiceAccount == "" {
@@ -35,6 +38,12 @@ func PreparePodDelete(experimentsDetails *experimentTypes.ExperimentDetails, cli
 		}
 	}
 
+	// record the parameters of the pod deletion, the target pods are picked by powerfulseal itself
+	common.SetParameters(chaosDetails, map[string]string{
+		"force":         strconv.FormatBool(experimentsDetails.Force),
+		"chaosInterval": experimentsDetails.ChaosInterval,
+	})
+
 	// generating a unique string which can be appended with the powerfulseal deployment name & labels for the uniquely identification
 	runID := common.GetRunID()
 
@@ -59,14 +68,15 @@ func PreparePodDelete(experimentsDetails *experimentTypes.ExperimentDetails, cli
 
 	//checking the status of the powerfulseal pod, wait till the powerfulseal pod comes to running state else fail the experiment
 	log.Info("[Status]: Checking the status of the helper pod")
-	err = status.CheckApplicationStatus(experimentsDetails.ChaosNamespace, "name=powerfulseal-"+runID, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
+	err = status.CheckApplicationStatus(ctx, experimentsDetails.ChaosNamespace, "name=powerfulseal-"+runID, experimentsDetails.Timeout, experimentsDetails.Delay, clients)
 	if err != nil {
 		return errors.Errorf("powerfulseal pod is not in running state, err: %v", err)
 	}
 
 	// Wait for Chaos Duration
 	log.Infof("[Wait]: Waiting for the %vs chaos duration", experimentsDetails.ChaosDuration)
-	common.WaitForDuration(experimentsDetails.ChaosDuration)
+	// the powerfulseal deployment is deleted before returning, if the abort signal is received in between
+	abortErr := common.WaitForDurationWithContext(ctx, experimentsDetails.ChaosDuration)
 
 	//Deleting the powerfulseal deployment
 	log.Info("[Cleanup]: Deleting the powerfulseal deployment")
@@ -81,11 +91,16 @@ func PreparePodDelete(experimentsDetails *experimentTypes.ExperimentDetails, cli
 	if err != nil {
 		return errors.Errorf("Unable to delete the  powerfulseal configmap, err: %v", err)
 	}
+	if abortErr != nil {
+		return abortErr
+	}
 
 	//Waiting for the ramp time after chaos injection
 	if experimentsDetails.RampTime != 0 {
 		log.Infof("[Ramp]: Waiting for the %vs ramp time after injecting chaos", experimentsDetails.RampTime)
-		common.WaitForDuration(experimentsDetails.RampTime)
+		if err := common.WaitForDurationWithContext(ctx, experimentsDetails.RampTime); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -114,7 +129,10 @@ func CreateConfigMap(experimentsDetails *experimentTypes.ExperimentDetails, clie
 			Name:      "policy-" + runID,
 			Namespace: experimentsDetails.ChaosNamespace,
 			Labels: map[string]string{
-				"name": "policy-" + runID,
+				"app":                       "powerfulseal",
+				"name":                      "policy-" + runID,
+				"chaosUID":                  string(experimentsDetails.ChaosUID),
+				"app.kubernetes.io/part-of": "litmus",
 			},
 		},
 		Data: data,
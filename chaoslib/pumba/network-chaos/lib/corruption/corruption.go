@@ -1,6 +1,7 @@
 package corruption
 
 import (
+	"context"
 	"strconv"
 
 	network_chaos "github.com/litmuschaos/litmus-go/chaoslib/pumba/network-chaos/lib"
@@ -12,13 +13,13 @@ import (
 var err error
 
 //PodNetworkCorruptionChaos contains the steps to prepare and inject chaos
-func PodNetworkCorruptionChaos(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PodNetworkCorruptionChaos(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 
 	args, err := GetContainerArguments(experimentsDetails)
 	if err != nil {
 		return err
 	}
-	return network_chaos.PrepareAndInjectChaos(experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, args)
+	return network_chaos.PrepareAndInjectChaos(ctx, experimentsDetails, clients, resultDetails, eventsDetails, chaosDetails, args)
 }
 
 // GetContainerArguments derives the args for the pumba pod
@@ -0,0 +1,115 @@
+package experiments
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
+)
+
+// Experiment is implemented by every chaos experiment, which can be invoked by the go-runner
+type Experiment interface {
+	// Run executes the experiment business logic
+	// the experiment is stopped and the chaos is reverted, once the context is cancelled
+	Run(ctx context.Context, clients clients.ClientSets)
+}
+
+// ExperimentFunc is an adapter, which allows the use of ordinary functions as experiments
+type ExperimentFunc func(ctx context.Context, clients clients.ClientSets)
+
+// Run calls f(ctx, clients)
+func (f ExperimentFunc) Run(ctx context.Context, clients clients.ClientSets) {
+	f(ctx, clients)
+}
+
+// Factory returns a new instance of the experiment
+type Factory func() Experiment
+
+// TunablesFunc returns the env variables, which can be tuned for the experiment
+type TunablesFunc func() ([]config.Tunable, error)
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Factory{}
+	tunables = map[string]TunablesFunc{}
+)
+
+// Register makes an experiment available to the go-runner under the given name
+// It is intended to be called from the init function of the experiment package
+// It panics, if the name is empty, the factory is nil or the name is already registered
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name == "" {
+		panic("experiments: Register called with an empty experiment name")
+	}
+	if factory == nil {
+		panic("experiments: Register factory is nil for " + name)
+	}
+	if _, dup := registry[name]; dup {
+		panic("experiments: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// RegisterFunc registers an ordinary function as an experiment under the given name
+func RegisterFunc(name string, fn ExperimentFunc) {
+	Register(name, func() Experiment { return fn })
+}
+
+// RegisterTunables registers the tunables of the experiment, used to generate the documentation of the experiment env
+// It panics, if the name is empty or the tunables are already registered for the name
+func RegisterTunables(name string, fn TunablesFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name == "" {
+		panic("experiments: RegisterTunables called with an empty experiment name")
+	}
+	if _, dup := tunables[name]; dup {
+		panic("experiments: RegisterTunables called twice for " + name)
+	}
+	tunables[name] = fn
+}
+
+// Tunables returns the tunables of the experiment registered under the given name
+// It returns nil, if the experiment doesn't register its tunables
+func Tunables(name string) ([]config.Tunable, error) {
+	mu.RLock()
+	fn, ok := tunables[name]
+	mu.RUnlock()
+
+	if !ok || fn == nil {
+		return nil, nil
+	}
+	return fn()
+}
+
+// Get returns a new instance of the experiment registered under the given name
+func Get(name string) (Experiment, error) {
+	mu.RLock()
+	factory, ok := registry[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, errors.Errorf("unsupported experiment %v, registered experiments are %v", name, List())
+	}
+	return factory(), nil
+}
+
+// List returns the sorted names of all the registered experiments
+func List() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
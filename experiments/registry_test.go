@@ -0,0 +1,133 @@
+package experiments
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+)
+
+// fakeExperiment records the runs of the experiment
+type fakeExperiment struct {
+	runs int
+}
+
+func (e *fakeExperiment) Run(ctx context.Context, clients clients.ClientSets) {
+	e.runs++
+}
+
+// reset removes all the registered experiments & tunables, it returns a func which restores them
+func reset() func() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	oldRegistry, oldTunables := registry, tunables
+	registry, tunables = map[string]Factory{}, map[string]TunablesFunc{}
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		registry, tunables = oldRegistry, oldTunables
+	}
+}
+
+// panics returns true, if the given func panics
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return false
+}
+
+func TestRegister(t *testing.T) {
+	defer reset()()
+
+	Register("pod-delete", func() Experiment { return &fakeExperiment{} })
+	ran := false
+	RegisterFunc("container-kill", func(ctx context.Context, clients clients.ClientSets) { ran = true })
+
+	if names := List(); strings.Join(names, ",") != "container-kill,pod-delete" {
+		t.Fatalf("expected the sorted experiment names, found %v", names)
+	}
+
+	// every lookup returns a new instance of the experiment
+	first, err := Get("pod-delete")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Run(context.Background(), clients.ClientSets{})
+	second, err := Get("pod-delete")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first == second || second.(*fakeExperiment).runs != 0 {
+		t.Fatalf("expected a new instance of the experiment")
+	}
+
+	fn, err := Get("container-kill")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fn.Run(context.Background(), clients.ClientSets{})
+	if !ran {
+		t.Fatalf("expected the registered func to run")
+	}
+
+	// the unregistered experiment is rejected, along with the registered names
+	if _, err := Get("pod-cpu-hog"); err == nil || !strings.Contains(err.Error(), "container-kill pod-delete") {
+		t.Fatalf("expected the unsupported experiment error, found %v", err)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	defer reset()()
+
+	factory := func() Experiment { return &fakeExperiment{} }
+	Register("pod-delete", factory)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{name: "duplicate name", fn: func() { Register("pod-delete", factory) }},
+		{name: "empty name", fn: func() { Register("", factory) }},
+		{name: "nil factory", fn: func() { Register("pod-cpu-hog", nil) }},
+		{name: "duplicate tunables", fn: func() {
+			RegisterTunables("pod-memory-hog", nil)
+			RegisterTunables("pod-memory-hog", nil)
+		}},
+		{name: "empty tunables name", fn: func() { RegisterTunables("", nil) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !panics(tt.fn) {
+				t.Fatalf("expected the registration to panic")
+			}
+		})
+	}
+
+	// the registered experiment is retained, after the duplicate registration
+	if names := List(); len(names) != 1 || names[0] != "pod-delete" {
+		t.Fatalf("expected only the pod-delete experiment, found %v", names)
+	}
+}
+
+func TestTunables(t *testing.T) {
+	defer reset()()
+
+	RegisterTunables("pod-delete", func() ([]config.Tunable, error) {
+		return []config.Tunable{{Name: "TOTAL_CHAOS_DURATION", Type: "int", Default: "15"}}, nil
+	})
+
+	list, err := Tunables("pod-delete")
+	if err != nil || len(list) != 1 || list[0].Name != "TOTAL_CHAOS_DURATION" {
+		t.Fatalf("unexpected tunables %v, err: %v", list, err)
+	}
+	// the experiments without the registered tunables don't return any tunable
+	if list, err := Tunables("container-kill"); err != nil || list != nil {
+		t.Fatalf("expected no tunables, found %v, err: %v", list, err)
+	}
+}
@@ -1,6 +1,7 @@
 package cassandra
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"strconv"
@@ -22,7 +23,7 @@ import (
 )
 
 // LivenessCheck will create an external liveness pod which will continuously check for the liveness of cassandra statefulset
-func LivenessCheck(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
+func LivenessCheck(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
 	var err error
 
 	// Generate the run_id for the liveness pod
@@ -43,7 +44,7 @@ func LivenessCheck(experimentsDetails *experimentTypes.ExperimentDetails, client
 	// Checking the status of liveness deployment pod
 	log.Info("[Status]: Checking the status of the cassandra liveness pod")
 	// Record cassandra liveness pod resource version
-	if err = status.CheckApplicationStatus(experimentsDetails.ChaoslibDetail.AppNS, "name=cassandra-liveness-deploy-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
+	if err = status.CheckApplicationStatus(ctx, experimentsDetails.ChaoslibDetail.AppNS, "name=cassandra-liveness-deploy-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
 		return "", errors.Errorf("Liveness pod is not in running state, err: %v", err)
 	}
 
@@ -210,7 +211,10 @@ func CreateLivenessPod(experimentsDetails *experimentTypes.ExperimentDetails, cl
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cassandra-liveness-deploy-" + experimentsDetails.RunID,
 			Labels: map[string]string{
-				"name": "cassandra-liveness-deploy-" + experimentsDetails.RunID,
+				"app":                       "cassandra-liveness",
+				"name":                      "cassandra-liveness-deploy-" + experimentsDetails.RunID,
+				"chaosUID":                  string(experimentsDetails.ChaoslibDetail.ChaosUID),
+				"app.kubernetes.io/part-of": "litmus",
 			},
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -344,7 +348,10 @@ func CreateLivenessService(experimentsDetails *experimentTypes.ExperimentDetails
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cassandra-liveness-service-" + experimentsDetails.RunID,
 			Labels: map[string]string{
-				"name": "cassandra-liveness-service-" + experimentsDetails.RunID,
+				"app":                       "cassandra-liveness",
+				"name":                      "cassandra-liveness-service-" + experimentsDetails.RunID,
+				"chaosUID":                  string(experimentsDetails.ChaoslibDetail.ChaosUID),
+				"app.kubernetes.io/part-of": "litmus",
 			},
 		},
 		Spec: corev1.ServiceSpec{
@@ -1,63 +1,32 @@
 package environment
 
 import (
-	"os"
-	"strconv"
-
 	cassandraTypes "github.com/litmuschaos/litmus-go/pkg/cassandra/pod-delete/types"
-	exp "github.com/litmuschaos/litmus-go/pkg/generic/pod-delete/types"
 	"github.com/litmuschaos/litmus-go/pkg/types"
-	clientTypes "k8s.io/apimachinery/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
 )
 
-//GetENV fetches all the env variables from the runner pod
-func GetENV(cassandraDetails *cassandraTypes.ExperimentDetails) {
-
-	var ChaoslibDetail exp.ExperimentDetails
+// defaults overrides the defaults of the pod-delete chaoslib details for the cassandra-pod-delete experiment
+var defaults = config.Defaults{
+	"EXPERIMENT_NAME": "cassandra-pod-delete",
+}
 
-	ChaoslibDetail.ExperimentName = Getenv("EXPERIMENT_NAME", "cassandra-pod-delete")
-	ChaoslibDetail.ChaosNamespace = Getenv("CHAOS_NAMESPACE", "litmus")
-	ChaoslibDetail.EngineName = Getenv("CHAOSENGINE", "")
-	ChaoslibDetail.ChaosDuration, _ = strconv.Atoi(Getenv("TOTAL_CHAOS_DURATION", "30"))
-	ChaoslibDetail.ChaosInterval = Getenv("CHAOS_INTERVAL", "10")
-	ChaoslibDetail.RampTime, _ = strconv.Atoi(Getenv("RAMP_TIME", "0"))
-	ChaoslibDetail.ChaosLib = Getenv("LIB", "litmus")
-	ChaoslibDetail.ChaosServiceAccount = Getenv("CHAOS_SERVICE_ACCOUNT", "")
-	ChaoslibDetail.AppNS = Getenv("APP_NAMESPACE", "")
-	ChaoslibDetail.AppLabel = Getenv("APP_LABEL", "")
-	ChaoslibDetail.AppKind = Getenv("APP_KIND", "")
-	ChaoslibDetail.ChaosUID = clientTypes.UID(Getenv("CHAOS_UID", ""))
-	ChaoslibDetail.InstanceID = Getenv("INSTANCE_ID", "")
-	ChaoslibDetail.ChaosPodName = Getenv("POD_NAME", "")
-	ChaoslibDetail.TargetContainer = Getenv("TARGET_CONTAINER", "")
-	ChaoslibDetail.Force, _ = strconv.ParseBool(Getenv("FORCE", "false"))
-	ChaoslibDetail.Delay, _ = strconv.Atoi(Getenv("STATUS_CHECK_DELAY", "2"))
-	ChaoslibDetail.Timeout, _ = strconv.Atoi(Getenv("STATUS_CHECK_TIMEOUT", "180"))
-	ChaoslibDetail.PodsAffectedPerc, _ = strconv.Atoi(Getenv("PODS_AFFECTED_PERC", "0"))
-	cassandraDetails.ChaoslibDetail = &ChaoslibDetail
-	cassandraDetails.CassandraServiceName = Getenv("CASSANDRA_SVC_NAME", "")
-	cassandraDetails.KeySpaceReplicaFactor = Getenv("KEYSPACE_REPLICATION_FACTOR", "")
-	cassandraDetails.CassandraPort, _ = strconv.Atoi(Getenv("CASSANDRA_PORT", "9042"))
-	cassandraDetails.LivenessServicePort, _ = strconv.Atoi(Getenv("LIVENESS_SVC_PORT", "8088"))
-	cassandraDetails.CassandraLivenessImage = Getenv("CASSANDRA_LIVENESS_IMAGE", "litmuschaos/cassandra-client:latest")
-	cassandraDetails.CassandraLivenessCheck = Getenv("CASSANDRA_LIVENESS_CHECK", "")
-	cassandraDetails.RunID = Getenv("RunID", "")
+//GetENV fetches all the env variables from the runner pod
+//it returns the validation errors of all the invalid env variables together
+func GetENV(cassandraDetails *cassandraTypes.ExperimentDetails) error {
+	return config.Load(cassandraDetails, defaults)
 }
 
-// Getenv fetch the env and set the default value, if any
-func Getenv(key string, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		value = defaultValue
-	}
-	return value
+//Tunables returns the env variables, which can be tuned for the experiment
+func Tunables() ([]config.Tunable, error) {
+	return config.Describe(&cassandraTypes.ExperimentDetails{}, defaults)
 }
 
 //InitialiseChaosVariables initialise all the global variables
 func InitialiseChaosVariables(chaosDetails *types.ChaosDetails, cassandraDetails *cassandraTypes.ExperimentDetails) {
 	appDetails := types.AppDetails{}
-	appDetails.AnnotationCheck, _ = strconv.ParseBool(Getenv("ANNOTATION_CHECK", "false"))
-	appDetails.AnnotationKey = Getenv("ANNOTATION_KEY", "litmuschaos.io/chaos")
+	appDetails.AnnotationCheck = cassandraDetails.ChaoslibDetail.AnnotationCheck
+	appDetails.AnnotationKey = cassandraDetails.ChaoslibDetail.AnnotationKey
 	appDetails.AnnotationValue = "true"
 	appDetails.Kind = cassandraDetails.ChaoslibDetail.AppKind
 	appDetails.Label = cassandraDetails.ChaoslibDetail.AppLabel
@@ -73,5 +42,5 @@ func InitialiseChaosVariables(chaosDetails *types.ChaosDetails, cassandraDetails
 	chaosDetails.Delay = cassandraDetails.ChaoslibDetail.Delay
 	chaosDetails.AppDetail = appDetails
 	chaosDetails.ProbeImagePullPolicy = cassandraDetails.ChaoslibDetail.LIBImagePullPolicy
-	chaosDetails.Randomness, _ = strconv.ParseBool(Getenv("RANDOMNESS", "false"))
+	chaosDetails.Randomness = cassandraDetails.ChaoslibDetail.Randomness
 }
@@ -0,0 +1,201 @@
+package cleanup
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the kinds of the chaos artifacts
+const (
+	HelperPod                   = "HelperPod"
+	ProbePod                    = "ProbePod"
+	PowerfulsealDeployment      = "PowerfulsealDeployment"
+	PowerfulsealConfigMap       = "PowerfulsealConfigMap"
+	KafkaLivenessPod            = "KafkaLivenessPod"
+	CassandraLivenessDeployment = "CassandraLivenessDeployment"
+	CassandraLivenessService    = "CassandraLivenessService"
+)
+
+// Artifact is a resource, created by an experiment for the chaos injection, the probes or the liveness checks
+// which is left behind if the experiment dies in between or the job cleanup policy is retain
+type Artifact struct {
+	Kind      string
+	Namespace string
+	Name      string
+	ChaosUID  string
+}
+
+// Find returns all the chaos artifacts in the given namespace
+// it returns the artifacts of all the namespaces, if the namespace is empty
+// only the artifacts of the given chaosUID are returned, if it is not empty
+func Find(clients clients.ClientSets, namespace, chaosUID string) ([]Artifact, error) {
+
+	var artifacts []Artifact
+	add := func(kind string, meta metav1.ObjectMeta, uid string) {
+		if chaosUID == "" || uid == chaosUID {
+			artifacts = append(artifacts, Artifact{Kind: kind, Namespace: meta.Namespace, Name: meta.Name, ChaosUID: uid})
+		}
+	}
+
+	pods, err := clients.KubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the pods, err: %v", err)
+	}
+	for _, pod := range pods.Items {
+		if kind := podKind(pod.ObjectMeta); kind != "" {
+			add(kind, pod.ObjectMeta, pod.Labels["chaosUID"])
+		}
+	}
+
+	deployments, err := clients.KubeClient.AppsV1().Deployments(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the deployments, err: %v", err)
+	}
+	// chaosUIDs of the powerfulseal deployments, keyed by the namespace & runID
+	powerfulseal := map[string]string{}
+	for _, deployment := range deployments.Items {
+		switch {
+		case isNamed(deployment.ObjectMeta, "powerfulseal-"):
+			powerfulseal[deployment.Namespace+"/"+strings.TrimPrefix(deployment.Name, "powerfulseal-")] = deployment.Labels["chaosUID"]
+			add(PowerfulsealDeployment, deployment.ObjectMeta, deployment.Labels["chaosUID"])
+		case isNamed(deployment.ObjectMeta, "cassandra-liveness-deploy-"):
+			add(CassandraLivenessDeployment, deployment.ObjectMeta, deployment.Labels["chaosUID"])
+		}
+	}
+
+	configMaps, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the configmaps, err: %v", err)
+	}
+	for _, configMap := range configMaps.Items {
+		if isNamed(configMap.ObjectMeta, "policy-") {
+			// the older policy configmaps are not labelled with the chaosUID, it is derived from the powerfulseal deployment
+			uid := configMap.Labels["chaosUID"]
+			if uid == "" {
+				uid = powerfulseal[configMap.Namespace+"/"+strings.TrimPrefix(configMap.Name, "policy-")]
+			}
+			add(PowerfulsealConfigMap, configMap.ObjectMeta, uid)
+		}
+	}
+
+	services, err := clients.KubeClient.CoreV1().Services(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the services, err: %v", err)
+	}
+	for _, service := range services.Items {
+		if isNamed(service.ObjectMeta, "cassandra-liveness-service-") {
+			add(CassandraLivenessService, service.ObjectMeta, service.Labels["chaosUID"])
+		}
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].Namespace != artifacts[j].Namespace {
+			return artifacts[i].Namespace < artifacts[j].Namespace
+		}
+		if artifacts[i].Kind != artifacts[j].Kind {
+			return artifacts[i].Kind < artifacts[j].Kind
+		}
+		return artifacts[i].Name < artifacts[j].Name
+	})
+	return artifacts, nil
+}
+
+// Active returns the chaosUIDs of the chaosengines in the given namespace, whose experiments are still running
+// it returns the chaosUIDs of all the namespaces, if the namespace is empty
+func Active(clients clients.ClientSets, namespace string) (map[string]bool, error) {
+	engines, err := clients.LitmusClient.ChaosEngines(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the chaosengines, err: %v", err)
+	}
+	active := map[string]bool{}
+	for _, engine := range engines.Items {
+		if engine.Spec.EngineState == v1alpha1.EngineStateActive && engine.Status.EngineStatus == v1alpha1.EngineStatusInitialized {
+			active[string(engine.UID)] = true
+		}
+	}
+	return active, nil
+}
+
+// SkipActive removes the artifacts of the given active chaosUIDs
+// the artifacts without chaosUID can't be matched with their chaosengine, so they are also removed while any chaosengine is active
+func SkipActive(artifacts []Artifact, active map[string]bool) []Artifact {
+	if len(active) == 0 {
+		return artifacts
+	}
+	var inactive []Artifact
+	for _, artifact := range artifacts {
+		if artifact.ChaosUID == "" || active[artifact.ChaosUID] {
+			log.Infof("[Cleanup]: Skipping the %v %v/%v, as its chaosengine may be active", artifact.Kind, artifact.Namespace, artifact.Name)
+			continue
+		}
+		inactive = append(inactive, artifact)
+	}
+	return inactive
+}
+
+// Delete deletes all the given artifacts, the artifacts which are already deleted are skipped
+// the helper pods are deleted gracefully, so that they can revert the chaos before exiting
+func Delete(clients clients.ClientSets, artifacts []Artifact) error {
+
+	var failed []string
+	for _, artifact := range artifacts {
+		if err := deleteArtifact(clients, artifact); err != nil && !k8serrors.IsNotFound(err) {
+			log.Errorf("Unable to delete the %v %v/%v, err: %v", artifact.Kind, artifact.Namespace, artifact.Name, err)
+			failed = append(failed, artifact.Namespace+"/"+artifact.Name)
+			continue
+		}
+		log.Infof("[Cleanup]: Deleted the %v %v/%v", artifact.Kind, artifact.Namespace, artifact.Name)
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("unable to delete the %v artifacts", strings.Join(failed, ","))
+	}
+	return nil
+}
+
+// deleteArtifact deletes the artifact based on its kind
+func deleteArtifact(clients clients.ClientSets, artifact Artifact) error {
+	propagation := metav1.DeletePropagationBackground
+	options := &metav1.DeleteOptions{PropagationPolicy: &propagation}
+
+	switch artifact.Kind {
+	case HelperPod, ProbePod, KafkaLivenessPod:
+		return clients.KubeClient.CoreV1().Pods(artifact.Namespace).Delete(artifact.Name, options)
+	case PowerfulsealDeployment, CassandraLivenessDeployment:
+		return clients.KubeClient.AppsV1().Deployments(artifact.Namespace).Delete(artifact.Name, options)
+	case PowerfulsealConfigMap:
+		return clients.KubeClient.CoreV1().ConfigMaps(artifact.Namespace).Delete(artifact.Name, options)
+	case CassandraLivenessService:
+		return clients.KubeClient.CoreV1().Services(artifact.Namespace).Delete(artifact.Name, options)
+	default:
+		return errors.Errorf("unsupported artifact kind '%v'", artifact.Kind)
+	}
+}
+
+// podKind returns the kind of the chaos pod, it returns empty string for the other pods
+// the experiment (runner) pods also contains the chaosUID label, so the helper & probe pods are identified by their app & name labels
+func podKind(meta metav1.ObjectMeta) string {
+	app := meta.Labels["app"]
+	switch {
+	case meta.Labels["chaosUID"] != "" && (strings.HasSuffix(app, "-helper") || strings.Contains(app, "-helper-")):
+		return HelperPod
+	case meta.Labels["chaosUID"] != "" && isNamed(meta, "") && strings.Contains(meta.Name, "-probe-"):
+		return ProbePod
+	case app == "kafka-liveness" && isNamed(meta, "kafka-liveness-"):
+		return KafkaLivenessPod
+	default:
+		return ""
+	}
+}
+
+// isNamed returns true, if the resource name has the given prefix and matches with its name label
+// all the chaos artifacts are labelled with their names, which avoids matching the user resources with the same prefix
+func isNamed(meta metav1.ObjectMeta, prefix string) bool {
+	return strings.HasPrefix(meta.Name, prefix) && meta.Labels["name"] == meta.Name
+}
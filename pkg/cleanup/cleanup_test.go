@@ -0,0 +1,157 @@
+package cleanup
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func objectMeta(namespace, name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}
+}
+
+func newObjects() []runtime.Object {
+	return []runtime.Object{
+		// chaos artifacts
+		&corev1.Pod{ObjectMeta: objectMeta("litmus", "pod-cpu-hog-abcd", map[string]string{"app": "pod-cpu-hog-helper-wxyz", "name": "pod-cpu-hog-abcd", "chaosUID": "uid-1"})},
+		&corev1.Pod{ObjectMeta: objectMeta("litmus", "node-restart-abcd", map[string]string{"app": "node-restart-helper", "name": "node-restart-abcd", "chaosUID": "uid-2"})},
+		&corev1.Pod{ObjectMeta: objectMeta("litmus", "pod-delete-probe-abcd", map[string]string{"name": "pod-delete-probe-abcd", "chaosUID": "uid-1"})},
+		&corev1.Pod{ObjectMeta: objectMeta("kafka", "kafka-liveness-abcd", map[string]string{"app": "kafka-liveness", "name": "kafka-liveness-abcd"})},
+		&appsv1.Deployment{ObjectMeta: objectMeta("litmus", "powerfulseal-abcd", map[string]string{"app": "powerfulseal", "name": "powerfulseal-abcd", "chaosUID": "uid-1"})},
+		&corev1.ConfigMap{ObjectMeta: objectMeta("litmus", "policy-abcd", map[string]string{"name": "policy-abcd"})},
+		&appsv1.Deployment{ObjectMeta: objectMeta("cassandra", "cassandra-liveness-deploy-abcd", map[string]string{"name": "cassandra-liveness-deploy-abcd"})},
+		&corev1.Service{ObjectMeta: objectMeta("cassandra", "cassandra-liveness-service-abcd", map[string]string{"name": "cassandra-liveness-service-abcd"})},
+		// the experiment pod and the user resources are not the chaos artifacts
+		&corev1.Pod{ObjectMeta: objectMeta("litmus", "pod-delete-runner", map[string]string{"app": "engine", "chaosUID": "uid-1"})},
+		&corev1.Pod{ObjectMeta: objectMeta("default", "nginx-probe-1", map[string]string{"app": "nginx"})},
+		&corev1.ConfigMap{ObjectMeta: objectMeta("default", "policy-config", nil)},
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		chaosUID  string
+		want      map[string]string
+	}{
+		{
+			name: "all the artifacts",
+			want: map[string]string{
+				"pod-cpu-hog-abcd":                HelperPod,
+				"node-restart-abcd":               HelperPod,
+				"pod-delete-probe-abcd":           ProbePod,
+				"kafka-liveness-abcd":             KafkaLivenessPod,
+				"powerfulseal-abcd":               PowerfulsealDeployment,
+				"policy-abcd":                     PowerfulsealConfigMap,
+				"cassandra-liveness-deploy-abcd":  CassandraLivenessDeployment,
+				"cassandra-liveness-service-abcd": CassandraLivenessService,
+			},
+		},
+		{
+			name:     "artifacts of the chaosUID",
+			chaosUID: "uid-1",
+			want: map[string]string{
+				"pod-cpu-hog-abcd":      HelperPod,
+				"pod-delete-probe-abcd": ProbePod,
+				"powerfulseal-abcd":     PowerfulsealDeployment,
+				"policy-abcd":           PowerfulsealConfigMap,
+			},
+		},
+		{
+			name:      "artifacts of the namespace",
+			namespace: "cassandra",
+			want: map[string]string{
+				"cassandra-liveness-deploy-abcd":  CassandraLivenessDeployment,
+				"cassandra-liveness-service-abcd": CassandraLivenessService,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := clients.NewFakeClientSets(newObjects(), nil, nil)
+			artifacts, err := Find(clients, tt.namespace, tt.chaosUID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(artifacts) != len(tt.want) {
+				t.Fatalf("expected %v artifacts, found %v", len(tt.want), artifacts)
+			}
+			for _, artifact := range artifacts {
+				if tt.want[artifact.Name] != artifact.Kind {
+					t.Errorf("unexpected %v artifact of %v kind", artifact.Name, artifact.Kind)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	clients := clients.NewFakeClientSets(newObjects(), nil, nil)
+	artifacts, err := Find(clients, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the artifacts, which are already deleted, are skipped
+	artifacts = append(artifacts, Artifact{Kind: HelperPod, Namespace: "litmus", Name: "deleted"})
+	if err := Delete(clients, artifacts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining, err := Find(clients, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("expected all the artifacts to be deleted, found %v", remaining)
+	}
+	if _, err := clients.KubeClient.CoreV1().Pods("litmus").Get("pod-delete-runner", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected the experiment pod to be retained, err: %v", err)
+	}
+}
+
+func newEngine(name, uid string, state v1alpha1.EngineState, status v1alpha1.EngineStatus) *v1alpha1.ChaosEngine {
+	return &v1alpha1.ChaosEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "litmus", UID: types.UID(uid)},
+		Spec:       v1alpha1.ChaosEngineSpec{EngineState: state},
+		Status:     v1alpha1.ChaosEngineStatus{EngineStatus: status},
+	}
+}
+
+func TestSkipActive(t *testing.T) {
+	clients := clients.NewFakeClientSets(newObjects(), []runtime.Object{
+		newEngine("cpu-chaos", "uid-1", v1alpha1.EngineStateActive, v1alpha1.EngineStatusInitialized),
+		newEngine("node-chaos", "uid-2", v1alpha1.EngineStateActive, v1alpha1.EngineStatusCompleted),
+	}, nil)
+
+	active, err := Active(clients, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(active) != 1 || !active["uid-1"] {
+		t.Fatalf("expected only the running chaosengine to be active, found %v", active)
+	}
+
+	artifacts, err := Find(clients, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the artifacts of the active chaosengine and the ones without chaosUID are skipped
+	artifacts = SkipActive(artifacts, active)
+	if len(artifacts) != 1 || artifacts[0].Name != "node-restart-abcd" {
+		t.Fatalf("expected only the artifacts of the completed chaosengine, found %v", artifacts)
+	}
+
+	// all the artifacts are retained, if none of the chaosengines is active
+	artifacts, _ = Find(clients, "", "")
+	if skipped := SkipActive(artifacts, nil); len(skipped) != len(artifacts) {
+		t.Fatalf("expected all the artifacts to be retained, found %v", skipped)
+	}
+}
@@ -11,10 +11,14 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// kubeconfig contains the absolute path to the kubeconfig file
+var kubeconfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
+
 // ClientSets is a collection of clientSets and kubeConfig needed
+// the clientSets are interfaces, so that they can be replaced by the fake clientSets in the unit tests
 type ClientSets struct {
-	KubeClient    *kubernetes.Clientset
-	LitmusClient  *chaosClient.LitmuschaosV1alpha1Client
+	KubeClient    kubernetes.Interface
+	LitmusClient  chaosClient.LitmuschaosV1alpha1Interface
 	KubeConfig    *rest.Config
 	DynamicClient dynamic.Interface
 }
@@ -47,8 +51,9 @@ func (clientSets *ClientSets) GenerateClientSetFromKubeConfig() error {
 
 // getKubeConfig setup the config for access cluster resource
 func getKubeConfig() (*rest.Config, error) {
-	kubeconfig := flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
-	flag.Parse()
+	if !flag.Parsed() {
+		flag.Parse()
+	}
 	// It uses in-cluster config, if kubeconfig path is not specified
 	config, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
 	return config, err
@@ -0,0 +1,23 @@
+package clients
+
+import (
+	litmusFake "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// NewFakeClientSets returns the ClientSets backed by the fake clientSets, which are used in the unit tests
+// kubeObjects & litmusObjects are the initial kubernetes & litmus objects, served by the corresponding fake clientSet
+// dynamicObjects are the initial objects served by the fake dynamic client, they should be registered with the kubernetes scheme
+// or should be of the unstructured type
+func NewFakeClientSets(kubeObjects, litmusObjects, dynamicObjects []runtime.Object) ClientSets {
+	return ClientSets{
+		KubeClient:    k8sFake.NewSimpleClientset(kubeObjects...),
+		LitmusClient:  litmusFake.NewSimpleClientset(litmusObjects...).LitmuschaosV1alpha1(),
+		KubeConfig:    &rest.Config{},
+		DynamicClient: dynamicFake.NewSimpleDynamicClient(scheme.Scheme, dynamicObjects...),
+	}
+}
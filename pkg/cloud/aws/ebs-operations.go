@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// EBSVolumeAttach will attach the ebs volume to the ec2 instance with the given device name
+func EBSVolumeAttach(volumeID, instanceID, deviceName, region string) error {
+
+	// Load session from shared config
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            aws.Config{Region: aws.String(region)},
+	}))
+
+	// Create new EC2 client
+	ec2Svc := ec2.New(sess)
+
+	input := &ec2.AttachVolumeInput{
+		Device:     aws.String(deviceName),
+		InstanceId: aws.String(instanceID),
+		VolumeId:   aws.String(volumeID),
+	}
+
+	result, err := ec2Svc.AttachVolume(input)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			default:
+				return errors.Errorf(aerr.Error())
+			}
+		} else {
+			return errors.Errorf(err.Error())
+		}
+	}
+
+	log.InfoWithValues("Attaching ebs having:", logrus.Fields{
+		"VolumeId":   *result.VolumeId,
+		"State":      *result.State,
+		"Device":     *result.Device,
+		"InstanceId": *result.InstanceId,
+	})
+	return nil
+}
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -48,14 +49,14 @@ func GetEC2InstanceStatus(instanceID, region string) (string, error) {
 }
 
 // PreChaosNodeStatusCheck fetch the target node name from instance id and checks its status also fetch the total active nodes in the cluster
-func PreChaosNodeStatusCheck(timeout, delay int, clients clients.ClientSets) (int, error) {
+func PreChaosNodeStatusCheck(ctx context.Context, timeout, delay int, clients clients.ClientSets) (int, error) {
 
 	nodeList, err := clients.KubeClient.CoreV1().Nodes().List(metav1.ListOptions{})
 	if err != nil {
 		return 0, errors.Errorf("fail to get the nodes, err: %v", err)
 	}
 	for _, node := range nodeList.Items {
-		if err = status.CheckNodeStatus(node.Name, timeout, delay, clients); err != nil {
+		if err = status.CheckNodeStatus(ctx, node.Name, timeout, delay, clients); err != nil {
 			log.Infof("[Info]: The cluster is unhealthy this might not work, due to %v", err)
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/standalone"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -43,6 +44,11 @@ func CreateEvents(eventsDetails *types.EventDetails, clients clients.ClientSets,
 // else it will create a new event
 func GenerateEvents(eventsDetails *types.EventDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, kind string) error {
 
+	// skipping the events in the standalone mode, as the involved chaosengine & chaosresult don't exist
+	if standalone.Enabled() {
+		return nil
+	}
+
 	switch kind {
 	case "ChaosResult":
 		eventName := eventsDetails.Reason + chaosDetails.ChaosPodName
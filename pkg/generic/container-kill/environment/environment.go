@@ -1,58 +1,27 @@
 package environment
 
 import (
-	"os"
-	"strconv"
-
-	clientTypes "k8s.io/apimachinery/pkg/types"
-
 	experimentTypes "github.com/litmuschaos/litmus-go/pkg/generic/container-kill/types"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
 )
 
 //GetENV fetches all the env variables from the runner pod
-func GetENV(experimentDetails *experimentTypes.ExperimentDetails) {
-	experimentDetails.ExperimentName = Getenv("EXPERIMENT_NAME", "container-kill")
-	experimentDetails.ChaosNamespace = Getenv("CHAOS_NAMESPACE", "")
-	experimentDetails.EngineName = Getenv("CHAOSENGINE", "")
-	experimentDetails.ChaosDuration, _ = strconv.Atoi(Getenv("TOTAL_CHAOS_DURATION", "20"))
-	experimentDetails.ChaosInterval, _ = strconv.Atoi(Getenv("CHAOS_INTERVAL", "10"))
-	experimentDetails.RampTime, _ = strconv.Atoi(Getenv("RAMP_TIME", "0"))
-	experimentDetails.ChaosLib = Getenv("LIB", "litmus")
-	experimentDetails.AppNS = Getenv("APP_NAMESPACE", "")
-	experimentDetails.AppLabel = Getenv("APP_LABEL", "")
-	experimentDetails.AppKind = Getenv("APP_KIND", "")
-	experimentDetails.ChaosUID = clientTypes.UID(Getenv("CHAOS_UID", ""))
-	experimentDetails.InstanceID = Getenv("INSTANCE_ID", "")
-	experimentDetails.ChaosPodName = Getenv("POD_NAME", "")
-	experimentDetails.ChaosServiceAccount = Getenv("CHAOS_SERVICE_ACCOUNT", "")
-	experimentDetails.LIBImage = Getenv("LIB_IMAGE", "litmuschaos/go-runner:latest")
-	experimentDetails.LIBImagePullPolicy = Getenv("LIB_IMAGE_PULL_POLICY", "Always")
-	experimentDetails.TargetContainer = Getenv("TARGET_CONTAINER", "")
-	experimentDetails.SocketPath = Getenv("SOCKET_PATH", "/var/run/docker.sock")
-	experimentDetails.Delay, _ = strconv.Atoi(Getenv("STATUS_CHECK_DELAY", "2"))
-	experimentDetails.Timeout, _ = strconv.Atoi(Getenv("STATUS_CHECK_TIMEOUT", "180"))
-	experimentDetails.TargetPods = Getenv("TARGET_PODS", "")
-	experimentDetails.ContainerRuntime = Getenv("CONTAINER_RUNTIME", "docker")
-	experimentDetails.PodsAffectedPerc, _ = strconv.Atoi(Getenv("PODS_AFFECTED_PERC", "0"))
-	experimentDetails.Sequence = Getenv("SEQUENCE", "parallel")
-	experimentDetails.Signal = Getenv("SIGNAL", "SIGKILL")
+//it returns the validation errors of all the invalid env variables together
+func GetENV(experimentDetails *experimentTypes.ExperimentDetails) error {
+	return config.Load(experimentDetails, nil)
 }
 
-// Getenv fetch the env and set the default value, if any
-func Getenv(key string, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		value = defaultValue
-	}
-	return value
+//Tunables returns the env variables, which can be tuned for the experiment
+func Tunables() ([]config.Tunable, error) {
+	return config.Describe(&experimentTypes.ExperimentDetails{}, nil)
 }
 
 //InitialiseChaosVariables initialise all the global variables
 func InitialiseChaosVariables(chaosDetails *types.ChaosDetails, experimentDetails *experimentTypes.ExperimentDetails) {
 	appDetails := types.AppDetails{}
-	appDetails.AnnotationCheck, _ = strconv.ParseBool(Getenv("ANNOTATION_CHECK", "false"))
-	appDetails.AnnotationKey = Getenv("ANNOTATION_KEY", "litmuschaos.io/chaos")
+	appDetails.AnnotationCheck = experimentDetails.AnnotationCheck
+	appDetails.AnnotationKey = experimentDetails.AnnotationKey
 	appDetails.AnnotationValue = "true"
 	appDetails.Kind = experimentDetails.AppKind
 	appDetails.Label = experimentDetails.AppLabel
@@ -67,6 +36,6 @@ func InitialiseChaosVariables(chaosDetails *types.ChaosDetails, experimentDetail
 	chaosDetails.Timeout = experimentDetails.Timeout
 	chaosDetails.Delay = experimentDetails.Delay
 	chaosDetails.AppDetail = appDetails
-	chaosDetails.JobCleanupPolicy = Getenv("JOB_CLEANUP_POLICY", "retain")
+	chaosDetails.JobCleanupPolicy = experimentDetails.JobCleanupPolicy
 	chaosDetails.ProbeImagePullPolicy = experimentDetails.LIBImagePullPolicy
 }
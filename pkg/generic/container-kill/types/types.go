@@ -7,34 +7,37 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName      string
-	EngineName          string
-	ChaosDuration       int
-	ChaosInterval       int
-	RampTime            int
-	ChaosLib            string
-	AppNS               string
-	AppLabel            string
-	AppKind             string
-	ChaosUID            clientTypes.UID
-	InstanceID          string
-	ChaosNamespace      string
-	ChaosPodName        string
-	LIBImage            string
-	LIBImagePullPolicy  string
-	TargetContainer     string
-	SocketPath          string
+	ExperimentName      string          `env:"EXPERIMENT_NAME" default:"container-kill"`
+	EngineName          string          `env:"CHAOSENGINE"`
+	ChaosDuration       int             `env:"TOTAL_CHAOS_DURATION" default:"20" min:"1"`
+	ChaosInterval       int             `env:"CHAOS_INTERVAL" default:"10" min:"0"`
+	RampTime            int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib            string          `env:"LIB" default:"litmus"`
+	AppNS               string          `env:"APP_NAMESPACE"`
+	AppLabel            string          `env:"APP_LABEL"`
+	AppKind             string          `env:"APP_KIND"`
+	ChaosUID            clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID          string          `env:"INSTANCE_ID"`
+	ChaosNamespace      string          `env:"CHAOS_NAMESPACE"`
+	ChaosPodName        string          `env:"POD_NAME"`
+	LIBImage            string          `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy  string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
+	TargetContainer     string          `env:"TARGET_CONTAINER"`
+	SocketPath          string          `env:"SOCKET_PATH" default:"/var/run/docker.sock"`
 	Iterations          int
-	ChaosServiceAccount string
+	ChaosServiceAccount string `env:"CHAOS_SERVICE_ACCOUNT"`
 	RunID               string
-	Timeout             int
-	Delay               int
-	TargetPods          string
-	ContainerRuntime    string
-	PodsAffectedPerc    int
+	Timeout             int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay               int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	TargetPods          string `env:"TARGET_PODS"`
+	ContainerRuntime    string `env:"CONTAINER_RUNTIME" default:"docker" enum:"docker|containerd|crio"`
+	PodsAffectedPerc    int    `env:"PODS_AFFECTED_PERC" default:"0" min:"0" max:"100"`
 	Annotations         map[string]string
-	Sequence            string
+	Sequence            string `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
 	Resources           corev1.ResourceRequirements
-	Signal              string
+	Signal              string `env:"SIGNAL" default:"SIGKILL"`
 	ImagePullSecrets    []corev1.LocalObjectReference
+	AnnotationCheck     bool   `env:"ANNOTATION_CHECK" default:"false"`
+	AnnotationKey       string `env:"ANNOTATION_KEY" default:"litmuschaos.io/chaos"`
+	JobCleanupPolicy    string `env:"JOB_CLEANUP_POLICY" default:"retain" enum:"retain|delete"`
 }
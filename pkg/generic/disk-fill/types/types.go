@@ -7,35 +7,38 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                string
-	EngineName                    string
-	ChaosDuration                 int
-	RampTime                      int
-	ChaosLib                      string
-	AppNS                         string
-	AppLabel                      string
-	AppKind                       string
-	ChaosUID                      clientTypes.UID
-	InstanceID                    string
-	ChaosNamespace                string
-	ChaosPodName                  string
-	TargetContainer               string
-	AuxiliaryAppInfo              string
-	FillPercentage                int
-	ContainerPath                 string
+	ExperimentName                string          `env:"EXPERIMENT_NAME" default:"disk-fill"`
+	EngineName                    string          `env:"CHAOSENGINE"`
+	ChaosDuration                 int             `env:"TOTAL_CHAOS_DURATION" default:"60" min:"1"`
+	RampTime                      int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib                      string          `env:"LIB" default:"litmus"`
+	AppNS                         string          `env:"APP_NAMESPACE"`
+	AppLabel                      string          `env:"APP_LABEL"`
+	AppKind                       string          `env:"APP_KIND"`
+	ChaosUID                      clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                    string          `env:"INSTANCE_ID"`
+	ChaosNamespace                string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                  string          `env:"POD_NAME"`
+	TargetContainer               string          `env:"TARGET_CONTAINER"`
+	AuxiliaryAppInfo              string          `env:"AUXILIARY_APPINFO"`
+	FillPercentage                int             `env:"FILL_PERCENTAGE" default:"80" min:"0"`
+	ContainerPath                 string          `env:"CONTAINER_PATH" default:"/var/lib/docker/containers"`
 	RunID                         string
-	Timeout                       int
-	Delay                         int
-	LIBImage                      string
-	LIBImagePullPolicy            string
-	TargetPods                    string
-	PodsAffectedPerc              int
+	Timeout                       int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay                         int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	LIBImage                      string `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy            string `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
+	TargetPods                    string `env:"TARGET_PODS"`
+	PodsAffectedPerc              int    `env:"PODS_AFFECTED_PERC" default:"0" min:"0" max:"100"`
 	Annotations                   map[string]string
-	Sequence                      string
+	Sequence                      string `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
 	Resources                     corev1.ResourceRequirements
 	ChaosServiceAccount           string
 	ImagePullSecrets              []corev1.LocalObjectReference
-	EphemeralStorageMebibytes     int
-	TerminationGracePeriodSeconds int
-	DataBlockSize                 int
+	EphemeralStorageMebibytes     int    `env:"EPHEMERAL_STORAGE_MEBIBYTES" min:"0"`
+	TerminationGracePeriodSeconds int    `env:"TERMINATION_GRACE_PERIOD_SECONDS" min:"0"`
+	DataBlockSize                 int    `env:"DATA_BLOCK_SIZE" default:"256" min:"0"`
+	AnnotationCheck               bool   `env:"ANNOTATION_CHECK" default:"false"`
+	AnnotationKey                 string `env:"ANNOTATION_KEY" default:"litmuschaos.io/chaos"`
+	JobCleanupPolicy              string `env:"JOB_CLEANUP_POLICY" default:"retain" enum:"retain|delete"`
 }
@@ -7,27 +7,28 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	AuxiliaryAppInfo   string
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"kubelet-service-kill"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"90" min:"1"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
 	RunID              string
-	TargetNode         string
-	Timeout            int
-	Delay              int
+	TargetNode         string `env:"TARGET_NODE"`
+	Timeout            int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay              int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
 	Annotations        map[string]string
-	LIBImage           string
-	LIBImagePullPolicy string
+	LIBImage           string `env:"LIB_IMAGE" default:"ubuntu:16.04"`
+	LIBImagePullPolicy string `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
 	Resources          corev1.ResourceRequirements
 	ImagePullSecrets   []corev1.LocalObjectReference
-	TargetContainer    string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	JobCleanupPolicy   string `env:"JOB_CLEANUP_POLICY" default:"retain" enum:"retain|delete"`
 }
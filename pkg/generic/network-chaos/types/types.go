@@ -7,40 +7,43 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                     string
-	EngineName                         string
-	ChaosDuration                      int
-	LIBImage                           string
-	LIBImagePullPolicy                 string
-	RampTime                           int
-	ChaosLib                           string
-	AppNS                              string
-	AppLabel                           string
-	AppKind                            string
-	ChaosUID                           clientTypes.UID
-	InstanceID                         string
-	ChaosNamespace                     string
-	ChaosPodName                       string
+	ExperimentName                     string          `env:"EXPERIMENT_NAME"`
+	EngineName                         string          `env:"CHAOSENGINE"`
+	ChaosDuration                      int             `env:"TOTAL_CHAOS_DURATION" default:"60" min:"1"`
+	LIBImage                           string          `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy                 string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
+	RampTime                           int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib                           string          `env:"LIB" default:"litmus"`
+	AppNS                              string          `env:"APP_NAMESPACE"`
+	AppLabel                           string          `env:"APP_LABEL"`
+	AppKind                            string          `env:"APP_KIND"`
+	ChaosUID                           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                         string          `env:"INSTANCE_ID"`
+	ChaosNamespace                     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                       string          `env:"POD_NAME"`
 	RunID                              string
-	NetworkPacketDuplicationPercentage int
-	NetworkInterface                   string
-	TargetContainer                    string
-	NetworkLatency                     int
-	NetworkPacketLossPercentage        int
-	NetworkPacketCorruptionPercentage  int
-	TCImage                            string
-	Timeout                            int
-	Delay                              int
-	TargetPods                         string
-	PodsAffectedPerc                   int
-	DestinationIPs                     string
+	NetworkPacketDuplicationPercentage int    `env:"NETWORK_PACKET_DUPLICATION_PERCENTAGE" default:"100" min:"0" max:"100"`
+	NetworkInterface                   string `env:"NETWORK_INTERFACE" default:"eth0"`
+	TargetContainer                    string `env:"TARGET_CONTAINER"`
+	NetworkLatency                     int    `env:"NETWORK_LATENCY" default:"60000" min:"0"`
+	NetworkPacketLossPercentage        int    `env:"NETWORK_PACKET_LOSS_PERCENTAGE" default:"100" min:"0" max:"100"`
+	NetworkPacketCorruptionPercentage  int    `env:"NETWORK_PACKET_CORRUPTION_PERCENTAGE" default:"100" min:"0" max:"100"`
+	TCImage                            string `env:"TC_IMAGE" default:"gaiadocker/iproute2"`
+	Timeout                            int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay                              int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	TargetPods                         string `env:"TARGET_PODS"`
+	PodsAffectedPerc                   int    `env:"PODS_AFFECTED_PERC" default:"0" min:"0" max:"100"`
+	DestinationIPs                     string `env:"DESTINATION_IPS"`
 	Annotations                        map[string]string
-	DestinationHosts                   string
-	ContainerRuntime                   string
-	ChaosServiceAccount                string
-	SocketPath                         string
-	Sequence                           string
+	DestinationHosts                   string `env:"DESTINATION_HOSTS"`
+	ContainerRuntime                   string `env:"CONTAINER_RUNTIME" default:"docker" enum:"docker|containerd|crio"`
+	ChaosServiceAccount                string `env:"CHAOS_SERVICE_ACCOUNT"`
+	SocketPath                         string `env:"SOCKET_PATH" default:"/var/run/docker.sock"`
+	Sequence                           string `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
 	Resources                          corev1.ResourceRequirements
 	ImagePullSecrets                   []corev1.LocalObjectReference
-	TerminationGracePeriodSeconds      int
+	TerminationGracePeriodSeconds      int    `env:"TERMINATION_GRACE_PERIOD_SECONDS" min:"0"`
+	AnnotationCheck                    bool   `env:"ANNOTATION_CHECK" default:"false"`
+	AnnotationKey                      string `env:"ANNOTATION_KEY" default:"litmuschaos.io/chaos"`
+	JobCleanupPolicy                   string `env:"JOB_CLEANUP_POLICY" default:"retain" enum:"retain|delete"`
 }
@@ -7,30 +7,31 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	NodeCPUcores       int
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"node-cpu-hog"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" min:"1"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	NodeCPUcores       int             `env:"NODE_CPU_CORE" default:"0" min:"0"`
 	RunID              string
-	LIBImage           string
-	LIBImagePullPolicy string
-	AuxiliaryAppInfo   string
-	Timeout            int
-	Delay              int
+	LIBImage           string `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy string `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
+	AuxiliaryAppInfo   string `env:"AUXILIARY_APPINFO"`
+	Timeout            int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay              int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
 	Annotations        map[string]string
-	TargetNodes        string
-	NodesAffectedPerc  int
-	Sequence           string
+	TargetNodes        string `env:"TARGET_NODES"`
+	NodesAffectedPerc  int    `env:"NODES_AFFECTED_PERC" default:"0" min:"0" max:"100"`
+	Sequence           string `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
 	Resources          corev1.ResourceRequirements
 	ImagePullSecrets   []corev1.LocalObjectReference
-	TargetContainer    string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	JobCleanupPolicy   string `env:"JOB_CLEANUP_POLICY" default:"retain" enum:"retain|delete"`
 }
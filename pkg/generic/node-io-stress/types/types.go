@@ -7,34 +7,35 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                  string
-	EngineName                      string
-	ChaosDuration                   int
-	RampTime                        int
-	ChaosLib                        string
-	AppNS                           string
-	AppLabel                        string
-	AppKind                         string
-	ChaosUID                        clientTypes.UID
-	InstanceID                      string
-	ChaosNamespace                  string
-	ChaosPodName                    string
+	ExperimentName                  string          `env:"EXPERIMENT_NAME" default:"node-io-stress"`
+	EngineName                      string          `env:"CHAOSENGINE"`
+	ChaosDuration                   int             `env:"TOTAL_CHAOS_DURATION" default:"120" min:"1"`
+	RampTime                        int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib                        string          `env:"LIB" default:"litmus"`
+	AppNS                           string          `env:"APP_NAMESPACE"`
+	AppLabel                        string          `env:"APP_LABEL"`
+	AppKind                         string          `env:"APP_KIND"`
+	ChaosUID                        clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                      string          `env:"INSTANCE_ID"`
+	ChaosNamespace                  string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                    string          `env:"POD_NAME"`
 	RunID                           string
-	LIBImage                        string
-	LIBImagePullPolicy              string
-	AuxiliaryAppInfo                string
-	Timeout                         int
-	Delay                           int
+	LIBImage                        string `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy              string `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
+	AuxiliaryAppInfo                string `env:"AUXILIARY_APPINFO"`
+	Timeout                         int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay                           int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
 	Annotations                     map[string]string
-	TargetNodes                     string
-	FilesystemUtilizationPercentage int
-	FilesystemUtilizationBytes      int
-	CPU                             int
-	NumberOfWorkers                 int
-	VMWorkers                       int
-	NodesAffectedPerc               int
-	Sequence                        string
+	TargetNodes                     string `env:"TARGET_NODES"`
+	FilesystemUtilizationPercentage int    `env:"FILESYSTEM_UTILIZATION_PERCENTAGE" min:"0" max:"100"`
+	FilesystemUtilizationBytes      int    `env:"FILESYSTEM_UTILIZATION_BYTES" min:"0"`
+	CPU                             int    `env:"CPU" default:"1" min:"0"`
+	NumberOfWorkers                 int    `env:"NUMBER_OF_WORKERS" default:"4" min:"0"`
+	VMWorkers                       int    `env:"VM_WORKERS" default:"1" min:"0"`
+	NodesAffectedPerc               int    `env:"NODES_AFFECTED_PERC" default:"0" min:"0" max:"100"`
+	Sequence                        string `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
 	Resources                       corev1.ResourceRequirements
 	ImagePullSecrets                []corev1.LocalObjectReference
-	TargetContainer                 string
+	TargetContainer                 string `env:"TARGET_CONTAINER"`
+	JobCleanupPolicy                string `env:"JOB_CLEANUP_POLICY" default:"retain" enum:"retain|delete"`
 }
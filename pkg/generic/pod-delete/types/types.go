@@ -6,26 +6,29 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName      string
-	EngineName          string
-	ChaosDuration       int
-	ChaosInterval       string
-	RampTime            int
-	Force               bool
-	ChaosLib            string
-	ChaosServiceAccount string
-	AppNS               string
-	AppLabel            string
-	AppKind             string
-	ChaosUID            clientTypes.UID
-	InstanceID          string
-	ChaosNamespace      string
-	ChaosPodName        string
-	Timeout             int
-	Delay               int
-	TargetPods          string
-	PodsAffectedPerc    int
-	Sequence            string
+	ExperimentName      string          `env:"EXPERIMENT_NAME" default:"pod-delete"`
+	EngineName          string          `env:"CHAOSENGINE"`
+	ChaosDuration       int             `env:"TOTAL_CHAOS_DURATION" default:"30" min:"1"`
+	ChaosInterval       string          `env:"CHAOS_INTERVAL" default:"10"`
+	RampTime            int             `env:"RAMP_TIME" default:"0" min:"0"`
+	Force               bool            `env:"FORCE" default:"false"`
+	ChaosLib            string          `env:"LIB" default:"litmus"`
+	ChaosServiceAccount string          `env:"CHAOS_SERVICE_ACCOUNT"`
+	AppNS               string          `env:"APP_NAMESPACE"`
+	AppLabel            string          `env:"APP_LABEL"`
+	AppKind             string          `env:"APP_KIND"`
+	ChaosUID            clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID          string          `env:"INSTANCE_ID"`
+	ChaosNamespace      string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName        string          `env:"POD_NAME"`
+	Timeout             int             `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay               int             `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	TargetPods          string          `env:"TARGET_PODS"`
+	PodsAffectedPerc    int             `env:"PODS_AFFECTED_PERC" default:"0" min:"0" max:"100"`
+	Sequence            string          `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
 	LIBImagePullPolicy  string
-	TargetContainer     string
+	TargetContainer     string `env:"TARGET_CONTAINER"`
+	AnnotationCheck     bool   `env:"ANNOTATION_CHECK" default:"false"`
+	AnnotationKey       string `env:"ANNOTATION_KEY" default:"litmuschaos.io/chaos"`
+	Randomness          bool   `env:"RANDOMNESS" default:"false"`
 }
@@ -7,35 +7,38 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName                string
-	EngineName                    string
-	ChaosDuration                 int
-	LIBImage                      string
-	LIBImagePullPolicy            string
-	RampTime                      int
-	ChaosLib                      string
-	AppNS                         string
-	AppLabel                      string
-	AppKind                       string
-	ChaosUID                      clientTypes.UID
-	InstanceID                    string
-	ChaosNamespace                string
-	ChaosPodName                  string
+	ExperimentName                string          `env:"EXPERIMENT_NAME" default:"pod-dns-chaos"`
+	EngineName                    string          `env:"CHAOSENGINE"`
+	ChaosDuration                 int             `env:"TOTAL_CHAOS_DURATION" default:"60" min:"1"`
+	LIBImage                      string          `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy            string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
+	RampTime                      int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib                      string          `env:"LIB" default:"litmus"`
+	AppNS                         string          `env:"APP_NAMESPACE"`
+	AppLabel                      string          `env:"APP_LABEL"`
+	AppKind                       string          `env:"APP_KIND"`
+	ChaosUID                      clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID                    string          `env:"INSTANCE_ID"`
+	ChaosNamespace                string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName                  string          `env:"POD_NAME"`
 	RunID                         string
-	Timeout                       int
-	Delay                         int
-	TargetContainer               string
-	TargetPods                    string
-	PodsAffectedPerc              int
+	Timeout                       int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay                         int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	TargetContainer               string `env:"TARGET_CONTAINER"`
+	TargetPods                    string `env:"TARGET_PODS"`
+	PodsAffectedPerc              int    `env:"PODS_AFFECTED_PERC" default:"0" min:"0" max:"100"`
 	Annotations                   map[string]string
-	TargetHostNames               string
-	MatchScheme                   string
-	ChaosType                     string
-	ContainerRuntime              string
-	ChaosServiceAccount           string
-	Sequence                      string
-	SocketPath                    string
+	TargetHostNames               string `env:"TARGET_HOSTNAMES"`
+	MatchScheme                   string `env:"MATCH_SCHEME" default:"exact" enum:"exact|substring"`
+	ChaosType                     string `env:"CHAOS_TYPE" default:"error" enum:"error|spoof"`
+	ContainerRuntime              string `env:"CONTAINER_RUNTIME" default:"docker" enum:"docker|containerd|crio"`
+	ChaosServiceAccount           string `env:"CHAOS_SERVICE_ACCOUNT"`
+	Sequence                      string `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
+	SocketPath                    string `env:"SOCKET_PATH" default:"/var/run/docker.sock"`
 	Resources                     corev1.ResourceRequirements
 	ImagePullSecrets              []corev1.LocalObjectReference
-	TerminationGracePeriodSeconds int
+	TerminationGracePeriodSeconds int    `env:"TERMINATION_GRACE_PERIOD_SECONDS" min:"0"`
+	AnnotationCheck               bool   `env:"ANNOTATION_CHECK" default:"false"`
+	AnnotationKey                 string `env:"ANNOTATION_KEY" default:"litmuschaos.io/chaos"`
+	JobCleanupPolicy              string `env:"JOB_CLEANUP_POLICY" default:"retain" enum:"retain|delete"`
 }
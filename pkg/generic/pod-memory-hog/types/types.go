@@ -7,32 +7,34 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	ChaosInterval      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	PodsAffectedPerc   int
-	MemoryConsumption  int
-	Timeout            int
-	Delay              int
-	TargetPods         string
-	ChaosKillCmd       string
-	LIBImage           string
-	LIBImagePullPolicy string
-	StressImage        string
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"pod-memory-hog"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" min:"1"`
+	ChaosInterval      int             `env:"CHAOS_INTERVAL" default:"10" min:"0"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	PodsAffectedPerc   int             `env:"PODS_AFFECTED_PERC" default:"0" min:"0" max:"100"`
+	MemoryConsumption  int             `env:"MEMORY_CONSUMPTION" default:"500" min:"0"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	TargetPods         string          `env:"TARGET_PODS"`
+	ChaosKillCmd       string          `env:"CHAOS_KILL_COMMAND" default:"kill $(find /proc -name exe -lname '*/dd' 2>&1 | grep -v 'Permission denied' | awk -F/ '{print $(NF-1)}' |  head -n 1)"`
+	LIBImage           string          `env:"LIB_IMAGE" default:"litmuschaos/go-runner:latest"`
+	LIBImagePullPolicy string          `env:"LIB_IMAGE_PULL_POLICY" default:"Always" enum:"Always|IfNotPresent|Never"`
+	StressImage        string          `env:"STRESS_IMAGE" default:"alexeiled/stress-ng:latest-ubuntu"`
 	Annotations        map[string]string
-	TargetContainer    string
-	Sequence           string
-	SocketPath         string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
+	Sequence           string `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
+	SocketPath         string `env:"SOCKET_PATH" default:"/var/run/docker.sock"`
 	Resources          corev1.ResourceRequirements
 	ImagePullSecrets   []corev1.LocalObjectReference
+	AnnotationCheck    bool   `env:"ANNOTATION_CHECK" default:"false"`
+	AnnotationKey      string `env:"ANNOTATION_KEY" default:"litmuschaos.io/chaos"`
 }
@@ -0,0 +1,298 @@
+package guardrails
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// the guardrails limits the blast radius of the experiments, they are evaluated after the target selection
+// and the experiment fails before injecting any chaos, if the selected targets violates any of the policies
+
+// the guardrail policies
+const (
+	// ProtectedNamespaces rejects the targets inside the protected namespaces
+	ProtectedNamespaces string = "protected-namespaces"
+	// ProtectedLabels rejects the targets with any of the protected labels
+	ProtectedLabels string = "protected-labels"
+	// MaxTargets limits the absolute number of the targets
+	MaxTargets string = "max-targets"
+	// MaxTargetsPercentage limits the percentage of the targets, out of all the candidates
+	MaxTargetsPercentage string = "max-targets-percentage"
+	// AllReplicas rejects the targets, which contains all the replicas of a workload
+	AllReplicas string = "all-replicas"
+	// PodDisruptionBudget rejects the targets, which exceeds the allowed disruptions of a pod disruption budget
+	PodDisruptionBudget string = "pod-disruption-budget"
+)
+
+// Violation is returned, if the selected targets violates any of the guardrail policies
+type Violation struct {
+	Policy  string
+	Message string
+}
+
+// Error returns the violated policy along with the reason
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%v guardrail violated, %v", v.Policy, v.Message)
+}
+
+// FailStep returns the fail step of the experiment, which failed because of the violation
+func (v *Violation) FailStep() string {
+	return "Verifying the blast radius guardrails, " + v.Error()
+}
+
+// AsViolation returns the guardrail violation, if the given error is caused by it
+func AsViolation(err error) (*Violation, bool) {
+	var violation *Violation
+	if errors.As(err, &violation) {
+		return violation, true
+	}
+	return nil, false
+}
+
+// Load populates the guardrail policies from the env variables
+func Load(policy *types.Guardrails) error {
+	return config.Load(policy, nil)
+}
+
+// Tunables returns the env variables of the guardrail policies, which are shared by all the experiments
+func Tunables() ([]config.Tunable, error) {
+	return config.Describe(&types.Guardrails{}, nil)
+}
+
+// CheckNamespace verifies that the given namespace is not protected
+func CheckNamespace(policy types.Guardrails, namespace string) error {
+	for _, ns := range split(policy.ProtectedNamespaces) {
+		if ns == namespace {
+			return &Violation{Policy: ProtectedNamespaces, Message: fmt.Sprintf("%v namespace is protected", namespace)}
+		}
+	}
+	return nil
+}
+
+// CheckPods verifies the selected target pods against the guardrail policies
+// candidates is the number of the pods, out of which the targets are selected
+func CheckPods(clients clients.ClientSets, policy types.Guardrails, candidates int, targets []corev1.Pod) error {
+
+	for _, pod := range targets {
+		if err := CheckNamespace(policy, pod.Namespace); err != nil {
+			return err
+		}
+		if label, ok := protectedLabel(policy, pod.Labels); ok {
+			return &Violation{Policy: ProtectedLabels, Message: fmt.Sprintf("%v pod has the protected %v label", pod.Name, label)}
+		}
+	}
+
+	if err := checkLimits(policy, "pods", len(targets), candidates); err != nil {
+		return err
+	}
+
+	if !policy.AllowAllReplicas {
+		if err := checkReplicas(clients, targets); err != nil {
+			return err
+		}
+	}
+
+	if policy.RespectPDB {
+		if err := checkDisruptionBudgets(clients, targets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckNodes verifies the selected target nodes against the guardrail policies
+// all the nodes of the cluster are considered as the candidates
+func CheckNodes(clients clients.ClientSets, policy types.Guardrails, targets []string) error {
+
+	nodes, err := clients.KubeClient.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Errorf("unable to list the nodes, err: %v", err)
+	}
+	nodeLabels := map[string]map[string]string{}
+	for _, node := range nodes.Items {
+		nodeLabels[node.Name] = node.Labels
+	}
+
+	for _, name := range targets {
+		if label, ok := protectedLabel(policy, nodeLabels[name]); ok {
+			return &Violation{Policy: ProtectedLabels, Message: fmt.Sprintf("%v node has the protected %v label", name, label)}
+		}
+	}
+	return checkLimits(policy, "nodes", len(targets), len(nodes.Items))
+}
+
+// UnprotectedNodes returns the nodes without any of the protected labels, out of which the targets can be selected
+// it returns a violation, if all the nodes have the protected labels
+func UnprotectedNodes(policy types.Guardrails, nodes []corev1.Node) ([]corev1.Node, error) {
+	var unprotected []corev1.Node
+	for _, node := range nodes {
+		if _, ok := protectedLabel(policy, node.Labels); !ok {
+			unprotected = append(unprotected, node)
+		}
+	}
+	if len(unprotected) == 0 && len(nodes) != 0 {
+		return nil, &Violation{Policy: ProtectedLabels, Message: fmt.Sprintf("all the %v nodes have the protected labels", len(nodes))}
+	}
+	return unprotected, nil
+}
+
+// CheckNodeLimits verifies the number of the selected target nodes against the max targets policies
+// candidates is the number of the nodes of the cluster
+func CheckNodeLimits(policy types.Guardrails, candidates int, targets []string) error {
+	return checkLimits(policy, "nodes", len(targets), candidates)
+}
+
+// CheckInstances verifies the selected target instances against the max targets policies
+// candidates is the number of the instances, out of which the targets are selected
+// the max targets percentage is skipped, if the candidates are not known (zero), e.g. for the explicit instance ids
+func CheckInstances(policy types.Guardrails, candidates int, targets []string) error {
+	return checkLimits(policy, "instances", len(targets), candidates)
+}
+
+// CheckInstanceNodes verifies that the cluster nodes of the given ec2 instances don't have the protected labels
+// the node of an instance is matched via its provider id, the instances which aren't the cluster nodes are skipped
+func CheckInstanceNodes(clients clients.ClientSets, policy types.Guardrails, instanceIDs []string) error {
+	nodes, err := clients.KubeClient.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Errorf("unable to list the nodes, err: %v", err)
+	}
+	for _, id := range instanceIDs {
+		for _, node := range nodes.Items {
+			if !strings.HasSuffix(node.Spec.ProviderID, "/"+id) {
+				continue
+			}
+			if label, ok := protectedLabel(policy, node.Labels); ok {
+				return &Violation{Policy: ProtectedLabels, Message: fmt.Sprintf("%v instance of %v node has the protected %v label", id, node.Name, label)}
+			}
+		}
+	}
+	return nil
+}
+
+// checkLimits verifies the number of the targets against the max targets & max targets percentage
+func checkLimits(policy types.Guardrails, kind string, targets, candidates int) error {
+	if policy.MaxTargets > 0 && targets > policy.MaxTargets {
+		return &Violation{Policy: MaxTargets, Message: fmt.Sprintf("%v %v targeted, at most %v are allowed", targets, kind, policy.MaxTargets)}
+	}
+	if policy.MaxTargetsPercentage > 0 && candidates > 0 && targets*100 > policy.MaxTargetsPercentage*candidates {
+		return &Violation{Policy: MaxTargetsPercentage, Message: fmt.Sprintf("%v out of %v %v targeted, at most %v%% are allowed", targets, candidates, kind, policy.MaxTargetsPercentage)}
+	}
+	return nil
+}
+
+// checkReplicas verifies that the targets doesn't contain all the replicas of any workload
+// the replicas are the pods, controlled by the same owner (replicaset, statefulset, daemonset, etc)
+func checkReplicas(clients clients.ClientSets, targets []corev1.Pod) error {
+
+	targeted := map[string]int{}
+	owners := map[string]metav1.OwnerReference{}
+	for i := range targets {
+		if owner := metav1.GetControllerOf(&targets[i]); owner != nil {
+			key := targets[i].Namespace + "/" + string(owner.UID)
+			targeted[key]++
+			owners[key] = *owner
+		}
+	}
+	if len(targeted) == 0 {
+		return nil
+	}
+
+	replicas := map[string]int{}
+	for _, namespace := range namespaces(targets) {
+		pods, err := clients.KubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return errors.Errorf("unable to list the pods in %v namespace, err: %v", namespace, err)
+		}
+		for i := range pods.Items {
+			if owner := metav1.GetControllerOf(&pods.Items[i]); owner != nil {
+				replicas[namespace+"/"+string(owner.UID)]++
+			}
+		}
+	}
+
+	for key, count := range targeted {
+		if count >= replicas[key] {
+			owner := owners[key]
+			return &Violation{Policy: AllReplicas, Message: fmt.Sprintf("all the %v replicas of %v %v targeted", replicas[key], owner.Kind, owner.Name)}
+		}
+	}
+	return nil
+}
+
+// checkDisruptionBudgets verifies that the targets doesn't exceed the allowed disruptions of the pod disruption budgets
+func checkDisruptionBudgets(clients clients.ClientSets, targets []corev1.Pod) error {
+
+	for _, namespace := range namespaces(targets) {
+		pdbs, err := clients.KubeClient.PolicyV1beta1().PodDisruptionBudgets(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return errors.Errorf("unable to list the pod disruption budgets in %v namespace, err: %v", namespace, err)
+		}
+		for _, pdb := range pdbs.Items {
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil {
+				return errors.Errorf("invalid selector of %v pod disruption budget, err: %v", pdb.Name, err)
+			}
+			// an empty selector selects nothing in the pod disruption budgets
+			if selector.Empty() {
+				continue
+			}
+			count := 0
+			for _, pod := range targets {
+				if pod.Namespace == namespace && selector.Matches(labels.Set(pod.Labels)) {
+					count++
+				}
+			}
+			if count > int(pdb.Status.PodDisruptionsAllowed) {
+				return &Violation{Policy: PodDisruptionBudget, Message: fmt.Sprintf("%v pods targeted, but %v pod disruption budget allows only %v disruptions", count, pdb.Name, pdb.Status.PodDisruptionsAllowed)}
+			}
+		}
+	}
+	return nil
+}
+
+// protectedLabel returns the protected label, which matches with the given labels
+func protectedLabel(policy types.Guardrails, podLabels map[string]string) (string, bool) {
+	for _, label := range split(policy.ProtectedLabels) {
+		parts := strings.SplitN(label, "=", 2)
+		value, ok := podLabels[parts[0]]
+		if ok && (len(parts) == 1 || parts[1] == value) {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// namespaces returns the distinct namespaces of the given pods
+func namespaces(pods []corev1.Pod) []string {
+	var list []string
+	seen := map[string]bool{}
+	for _, pod := range pods {
+		if !seen[pod.Namespace] {
+			seen[pod.Namespace] = true
+			list = append(list, pod.Namespace)
+		}
+	}
+	return list
+}
+
+// split returns the comma separated values, "none" signifies an empty list
+func split(value string) []string {
+	var list []string
+	if strings.TrimSpace(value) == "none" {
+		return nil
+	}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,234 @@
+package guardrails
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+)
+
+func newPod(namespace, name, owner string, labels map[string]string) corev1.Pod {
+	isController := true
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    labels,
+		OwnerReferences: []metav1.OwnerReference{
+			{Kind: "ReplicaSet", Name: owner, UID: clientTypes.UID(owner), Controller: &isController},
+		},
+	}}
+}
+
+func newObjects() []runtime.Object {
+	nginx := map[string]string{"app": "nginx"}
+	pods := []corev1.Pod{
+		newPod("default", "nginx-1", "nginx", nginx),
+		newPod("default", "nginx-2", "nginx", nginx),
+		newPod("default", "redis-1", "redis", map[string]string{"app": "redis", "tier": "critical"}),
+	}
+	var objects []runtime.Object
+	for i := range pods {
+		objects = append(objects, &pods[i])
+	}
+	return append(objects,
+		&policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx-pdb", Namespace: "default"},
+			Spec:       policyv1beta1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: nginx}},
+			Status:     policyv1beta1.PodDisruptionBudgetStatus{PodDisruptionsAllowed: 1},
+		},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "master", Labels: map[string]string{"node-role.kubernetes.io/master": ""}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+	)
+}
+
+func defaultPolicy() types.Guardrails {
+	return types.Guardrails{
+		ProtectedNamespaces: "kube-system",
+		ProtectedLabels:     "node-role.kubernetes.io/master,node-role.kubernetes.io/control-plane",
+		RespectPDB:          true,
+	}
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	for _, name := range []string{"GUARDRAIL_PROTECTED_NAMESPACES", "GUARDRAIL_PROTECTED_LABELS", "GUARDRAIL_MAX_TARGETS",
+		"GUARDRAIL_MAX_TARGETS_PERC", "GUARDRAIL_ALLOW_ALL_REPLICAS", "GUARDRAIL_RESPECT_PDB"} {
+		os.Unsetenv(name)
+	}
+	policy := types.Guardrails{}
+	if err := Load(&policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(policy, defaultPolicy()) {
+		t.Fatalf("expected the default policy %+v, found %+v", defaultPolicy(), policy)
+	}
+
+	// the only replica of the redis deployment can't be targeted with the default policy
+	clients := clients.NewFakeClientSets(newObjects(), nil, nil)
+	redis, err := clients.KubeClient.CoreV1().Pods("default").Get("redis-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unable to get the redis-1 pod: %v", err)
+	}
+	checkViolation(t, CheckPods(clients, policy, 1, []corev1.Pod{*redis}), AllReplicas)
+}
+
+func TestCheckPods(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  func(*types.Guardrails)
+		targets []string
+		want    string
+	}{
+		{
+			name:    "default policy",
+			targets: []string{"nginx-1"},
+		},
+		{
+			name:    "all the targets allowed",
+			policy:  func(p *types.Guardrails) { p.AllowAllReplicas, p.RespectPDB = true, false },
+			targets: []string{"nginx-1", "nginx-2", "redis-1"},
+		},
+		{
+			name:    "protected label",
+			policy:  func(p *types.Guardrails) { p.ProtectedLabels = "tier=critical" },
+			targets: []string{"redis-1"},
+			want:    ProtectedLabels,
+		},
+		{
+			name:    "max targets",
+			policy:  func(p *types.Guardrails) { p.MaxTargets = 1 },
+			targets: []string{"nginx-1", "redis-1"},
+			want:    MaxTargets,
+		},
+		{
+			name:    "max targets percentage",
+			policy:  func(p *types.Guardrails) { p.MaxTargetsPercentage = 50 },
+			targets: []string{"nginx-1", "nginx-2"},
+			want:    MaxTargetsPercentage,
+		},
+		{
+			name:    "all the replicas",
+			targets: []string{"nginx-2", "redis-1"},
+			want:    AllReplicas,
+		},
+		{
+			name:    "pod disruption budget",
+			policy:  func(p *types.Guardrails) { p.AllowAllReplicas = true },
+			targets: []string{"nginx-1", "nginx-2"},
+			want:    PodDisruptionBudget,
+		},
+		{
+			name:    "allowed disruptions",
+			policy:  func(p *types.Guardrails) { p.AllowAllReplicas = true },
+			targets: []string{"nginx-1", "redis-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := clients.NewFakeClientSets(newObjects(), nil, nil)
+			policy := defaultPolicy()
+			if tt.policy != nil {
+				tt.policy(&policy)
+			}
+			var targets []corev1.Pod
+			for _, name := range tt.targets {
+				pod, err := clients.KubeClient.CoreV1().Pods("default").Get(name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("unable to get the %v pod: %v", name, err)
+				}
+				targets = append(targets, *pod)
+			}
+			checkViolation(t, CheckPods(clients, policy, 3, targets), tt.want)
+		})
+	}
+}
+
+func TestCheckNodes(t *testing.T) {
+	clients := clients.NewFakeClientSets(newObjects(), nil, nil)
+	policy := defaultPolicy()
+
+	checkViolation(t, CheckNodes(clients, policy, []string{"worker-1", "worker-2"}), "")
+	checkViolation(t, CheckNodes(clients, policy, []string{"worker-1", "master"}), ProtectedLabels)
+
+	policy.MaxTargetsPercentage = 50
+	checkViolation(t, CheckNodes(clients, policy, []string{"worker-1", "worker-2"}), MaxTargetsPercentage)
+
+	// the default protected labels are disabled with none
+	policy = defaultPolicy()
+	policy.ProtectedLabels = "none"
+	checkViolation(t, CheckNodes(clients, policy, []string{"master"}), "")
+}
+
+func TestUnprotectedNodes(t *testing.T) {
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "master", Labels: map[string]string{"node-role.kubernetes.io/master": ""}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+	}
+	policy := defaultPolicy()
+
+	unprotected, err := UnprotectedNodes(policy, nodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unprotected) != 1 || unprotected[0].Name != "worker-1" {
+		t.Errorf("expected the worker-1 node, got %v", unprotected)
+	}
+
+	_, err = UnprotectedNodes(policy, nodes[:1])
+	checkViolation(t, err, ProtectedLabels)
+}
+
+func TestCheckInstanceNodes(t *testing.T) {
+	clients := clients.NewFakeClientSets([]runtime.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "master", Labels: map[string]string{"node-role.kubernetes.io/master": ""}},
+			Spec:       corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0master"},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Spec:       corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0worker"},
+		},
+	}, nil, nil)
+	policy := defaultPolicy()
+
+	checkViolation(t, CheckInstanceNodes(clients, policy, []string{"i-0worker", "i-0standalone"}), "")
+	checkViolation(t, CheckInstanceNodes(clients, policy, []string{"i-0worker", "i-0master"}), ProtectedLabels)
+
+	// the max targets percentage is skipped for the unknown candidates
+	policy.MaxTargets = 1
+	policy.MaxTargetsPercentage = 50
+	checkViolation(t, CheckInstances(policy, 0, []string{"i-0worker"}), "")
+	checkViolation(t, CheckInstances(policy, 0, []string{"i-0worker", "i-0standalone"}), MaxTargets)
+}
+
+func TestCheckNamespace(t *testing.T) {
+	policy := defaultPolicy()
+	checkViolation(t, CheckNamespace(policy, "kube-system"), ProtectedNamespaces)
+	checkViolation(t, CheckNamespace(policy, "default"), "")
+}
+
+// checkViolation verifies that the error is a violation of the given policy, or nil if the policy is empty
+func checkViolation(t *testing.T, err error, policy string) {
+	t.Helper()
+	if policy == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	violation, ok := AsViolation(err)
+	if !ok {
+		t.Fatalf("expected the %v violation, found %v", policy, err)
+	}
+	if violation.Policy != policy {
+		t.Fatalf("expected the %v violation, found %v", policy, violation)
+	}
+}
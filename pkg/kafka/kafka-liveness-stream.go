@@ -1,6 +1,7 @@
 package kafka
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -17,7 +18,7 @@ import (
 
 // LivenessStream generates kafka liveness pod, which continuously validate the liveness of kafka brokers
 // and derive the kafka topic leader(candidate for the deletion)
-func LivenessStream(experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
+func LivenessStream(ctx context.Context, experimentsDetails *experimentTypes.ExperimentDetails, clients clients.ClientSets) (string, error) {
 	var ordinality string
 
 	// Generate a random string as suffix to topic name
@@ -32,7 +33,7 @@ func LivenessStream(experimentsDetails *experimentTypes.ExperimentDetails, clien
 	}
 
 	log.Info("[Liveness]: Confirm that the kafka liveness pod is running")
-	if err := status.CheckApplicationStatus(experimentsDetails.KafkaNamespace, "name=kafka-liveness-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
+	if err := status.CheckApplicationStatus(ctx, experimentsDetails.KafkaNamespace, "name=kafka-liveness-"+experimentsDetails.RunID, experimentsDetails.ChaoslibDetail.Timeout, experimentsDetails.ChaoslibDetail.Delay, clients); err != nil {
 		return "", errors.Errorf("Liveness pod status check failed, err: %v", err)
 	}
 
@@ -86,6 +87,7 @@ func CreateLivenessPod(experimentsDetails *experimentTypes.ExperimentDetails, Ka
 			Labels: map[string]string{
 				"app":                       "kafka-liveness",
 				"name":                      "kafka-liveness-" + experimentsDetails.RunID,
+				"chaosUID":                  string(experimentsDetails.ChaoslibDetail.ChaosUID),
 				"app.kubernetes.io/part-of": "litmus",
 			},
 		},
@@ -6,23 +6,23 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ChaoslibDetail        *exp.ExperimentDetails
+	ChaoslibDetail        *exp.ExperimentDetails `env:",inline"`
 	ExperimentName        string
-	KafkaKind             string
-	KafkaLivenessStream   string
-	KafkaLivenessImage    string
-	KafkaConsumerTimeout  int
-	KafkaInstanceName     string
-	KafkaNamespace        string
-	KafkaLabel            string
-	KafkaBroker           string
-	KafkaRepliationFactor string
-	KafkaService          string
-	KafkaPort             string
-	ZookeeperNamespace    string
-	ZookeeperLabel        string
-	ZookeeperService      string
-	ZookeeperPort         string
-	Lib                   string
-	RunID                 string
+	KafkaKind             string `env:"KAFKA_KIND" default:"statefulset"`
+	KafkaLivenessStream   string `env:"KAFKA_LIVENESS_STREAM" default:"enabled" enum:"enabled|disabled"`
+	KafkaLivenessImage    string `env:"KAFKA_LIVENESS_IMAGE" default:"litmuschaos/kafka-client:ci"`
+	KafkaConsumerTimeout  int    `env:"KAFKA_CONSUMER_TIMEOUT" default:"60000" min:"0"`
+	KafkaInstanceName     string `env:"KAFKA_INSTANCE_NAME" default:"kafka"`
+	KafkaNamespace        string `env:"KAFKA_NAMESPACE" default:"default"`
+	KafkaLabel            string `env:"KAFKA_LABEL"`
+	KafkaBroker           string `env:"KAFKA_BROKER"`
+	KafkaRepliationFactor string `env:"KAFKA_REPLICATION_FACTOR"`
+	KafkaService          string `env:"KAFKA_SERVICE"`
+	KafkaPort             string `env:"KAFKA_PORT" default:"9092"`
+	ZookeeperNamespace    string `env:"ZOOKEEPER_NAMESPACE"`
+	ZookeeperLabel        string `env:"ZOOKEEPER_LABEL"`
+	ZookeeperService      string `env:"ZOOKEEPER_SERVICE"`
+	ZookeeperPort         string `env:"ZOOKEEPER_PORT"`
+	Lib                   string `env:"LIB" default:"litmus"`
+	RunID                 string `env:"RunID"`
 }
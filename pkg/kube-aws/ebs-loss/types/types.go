@@ -6,26 +6,26 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	ChaosDuration      int
-	RampTime           int
-	ChaosLib           string
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	AuxiliaryAppInfo   string
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"ebs-loss"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"60" min:"1"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" min:"0"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
 	RunID              string
-	Timeout            int
-	Delay              int
-	Ec2InstanceID      string
-	EBSVolumeID        string
-	DeviceName         string
-	Region             string
+	Timeout            int    `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay              int    `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	Ec2InstanceID      string `env:"EC2_INSTANCE_ID" required:"true"`
+	EBSVolumeID        string `env:"EBS_VOL_ID" required:"true"`
+	DeviceName         string `env:"DEVICE_NAME" required:"true"`
+	Region             string `env:"REGION" required:"true"`
 	LIBImagePullPolicy string
-	TargetContainer    string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
 }
@@ -6,27 +6,27 @@ import (
 
 // ExperimentDetails is for collecting all the experiment-related details
 type ExperimentDetails struct {
-	ExperimentName     string
-	EngineName         string
-	RampTime           int
-	AppNS              string
-	AppLabel           string
-	AppKind            string
-	AuxiliaryAppInfo   string
-	ChaosLib           string
-	ChaosDuration      int
-	ChaosInterval      int
-	ChaosUID           clientTypes.UID
-	InstanceID         string
-	ChaosNamespace     string
-	ChaosPodName       string
-	Timeout            int
-	Delay              int
-	Ec2InstanceID      string
-	Region             string
-	ManagedNodegroup   string
-	Sequence           string
+	ExperimentName     string          `env:"EXPERIMENT_NAME" default:"ec2-terminate-by-id"`
+	EngineName         string          `env:"CHAOSENGINE"`
+	RampTime           int             `env:"RAMP_TIME" default:"0" min:"0"`
+	AppNS              string          `env:"APP_NAMESPACE"`
+	AppLabel           string          `env:"APP_LABEL"`
+	AppKind            string          `env:"APP_KIND"`
+	AuxiliaryAppInfo   string          `env:"AUXILIARY_APPINFO"`
+	ChaosLib           string          `env:"LIB" default:"litmus"`
+	ChaosDuration      int             `env:"TOTAL_CHAOS_DURATION" default:"30" min:"1"`
+	ChaosInterval      int             `env:"CHAOS_INTERVAL" default:"30" min:"0"`
+	ChaosUID           clientTypes.UID `env:"CHAOS_UID"`
+	InstanceID         string          `env:"INSTANCE_ID"`
+	ChaosNamespace     string          `env:"CHAOS_NAMESPACE" default:"litmus"`
+	ChaosPodName       string          `env:"POD_NAME"`
+	Timeout            int             `env:"STATUS_CHECK_TIMEOUT" default:"180" min:"1"`
+	Delay              int             `env:"STATUS_CHECK_DELAY" default:"2" min:"1"`
+	Ec2InstanceID      string          `env:"EC2_INSTANCE_ID" required:"true"`
+	Region             string          `env:"REGION" required:"true"`
+	ManagedNodegroup   string          `env:"MANAGED_NODEGROUP" default:"disable" enum:"enable|disable"`
+	Sequence           string          `env:"SEQUENCE" default:"parallel" enum:"serial|parallel"`
 	ActiveNodes        int
 	LIBImagePullPolicy string
-	TargetContainer    string
+	TargetContainer    string `env:"TARGET_CONTAINER"`
 }
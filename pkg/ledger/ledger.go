@@ -0,0 +1,265 @@
+package ledger
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	retries "k8s.io/client-go/util/retry"
+)
+
+// the ledger keeps the undo records of the injected faults in a configmap, inside the chaos namespace
+// a record is added before injecting the fault and removed once the fault is reverted
+// the outstanding records are replayed on the next start of the same experiment (or on demand)
+// which reverts the faults, left behind by a runner which died in between
+
+// the actions, which can be recorded in the ledger
+const (
+	// RemoveTaint removes the taint with the recorded key, value & effect from the target node
+	RemoveTaint string = "remove-taint"
+	// UncordonNode marks the target node as schedulable
+	UncordonNode string = "uncordon-node"
+	// ScaleDeployment scales the target deployment back to the recorded replicas
+	ScaleDeployment string = "scale-deployment"
+	// ScaleStatefulSet scales the target statefulset back to the recorded replicas
+	ScaleStatefulSet string = "scale-statefulset"
+	// StartEC2Instance starts the target ec2 instance
+	StartEC2Instance string = "start-ec2-instance"
+	// AttachEBSVolume attaches the target ebs volume back to the recorded ec2 instance
+	AttachEBSVolume string = "attach-ebs-volume"
+)
+
+// appLabel is the value of the app label of all the ledgers, used to find them across the experiments
+const appLabel = "chaos-ledger"
+
+// Record is the undo record of an injected fault
+// it contains the action and the original values, needed to revert the fault
+type Record struct {
+	Action    string            `json:"action"`
+	Target    string            `json:"target"`
+	Namespace string            `json:"namespace,omitempty"`
+	Values    map[string]string `json:"values,omitempty"`
+	ChaosUID  string            `json:"chaosUID,omitempty"`
+	Timestamp string            `json:"timestamp,omitempty"`
+}
+
+// Key returns the key of the record inside the ledger configmap
+func (record Record) Key() string {
+	parts := []string{record.Action}
+	if record.Namespace != "" {
+		parts = append(parts, record.Namespace)
+	}
+	return strings.Join(append(parts, record.Target), ".")
+}
+
+// Name returns the name of the ledger configmap for the given experiment
+// it is derived in the same way as the chaosresult name, so that every run of the experiment shares the ledger
+func Name(chaosDetails *types.ChaosDetails) string {
+	name := chaosDetails.ExperimentName
+	if chaosDetails.EngineName != "" {
+		name = chaosDetails.EngineName + "-" + name
+	}
+	if chaosDetails.InstanceID != "" {
+		name = name + "-" + chaosDetails.InstanceID
+	}
+	return name + "-ledger"
+}
+
+// Add writes the undo record in the ledger, it should be called before injecting the fault
+func Add(clients clients.ClientSets, chaosDetails *types.ChaosDetails, record Record) error {
+
+	record.ChaosUID = string(chaosDetails.ChaosUID)
+	record.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	value, err := json.Marshal(record)
+	if err != nil {
+		return errors.Errorf("unable to marshal the undo record, err: %v", err)
+	}
+
+	err = retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		ledger, err := clients.KubeClient.CoreV1().ConfigMaps(chaosDetails.ChaosNamespace).Get(Name(chaosDetails), metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			ledger := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      Name(chaosDetails),
+					Namespace: chaosDetails.ChaosNamespace,
+					Labels: map[string]string{
+						"app":                       appLabel,
+						"app.kubernetes.io/part-of": "litmus",
+						"name":                      Name(chaosDetails),
+					},
+				},
+				Data: map[string]string{record.Key(): string(value)},
+			}
+			_, err = clients.KubeClient.CoreV1().ConfigMaps(chaosDetails.ChaosNamespace).Create(ledger)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if ledger.Data == nil {
+			ledger.Data = map[string]string{}
+		}
+		ledger.Data[record.Key()] = string(value)
+		_, err = clients.KubeClient.CoreV1().ConfigMaps(chaosDetails.ChaosNamespace).Update(ledger)
+		return err
+	})
+	if err != nil {
+		return errors.Errorf("unable to write the undo record of %v in the %v ledger, err: %v", record.Target, Name(chaosDetails), err)
+	}
+	return nil
+}
+
+// Remove removes the undo record from the ledger, it should be called once the fault is reverted
+// the ledger is deleted, once it doesn't contain any record
+func Remove(clients clients.ClientSets, chaosDetails *types.ChaosDetails, record Record) error {
+	return removeKey(clients, chaosDetails.ChaosNamespace, Name(chaosDetails), record.Key())
+}
+
+// Recover replays all the outstanding undo records of the experiment
+// the records are removed from the ledger, once they are reverted successfully
+func Recover(clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+
+	ledger, err := clients.KubeClient.CoreV1().ConfigMaps(chaosDetails.ChaosNamespace).Get(Name(chaosDetails), metav1.GetOptions{})
+	// the experiments, which don't have access to the configmaps, can't record anything in the ledger
+	if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Errorf("unable to get the %v ledger, err: %v", Name(chaosDetails), err)
+	}
+	return replay(clients, ledger, "")
+}
+
+// RecoverAll replays the outstanding undo records of all the ledgers in the given namespace
+// it replays the records of all the namespaces, if the namespace is empty
+// only the records of the given chaosUID are replayed, if it is not empty
+func RecoverAll(clients clients.ClientSets, namespace, chaosUID string) error {
+
+	ledgers, err := list(clients, namespace)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for i := range ledgers {
+		if err := replay(clients, &ledgers[i], chaosUID); err != nil {
+			log.Errorf("Unable to recover the %v/%v ledger, err: %v", ledgers[i].Namespace, ledgers[i].Name, err)
+			failed = append(failed, ledgers[i].Namespace+"/"+ledgers[i].Name)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("unable to recover the %v ledgers", strings.Join(failed, ","))
+	}
+	return nil
+}
+
+// Pending returns the outstanding undo records of all the ledgers in the given namespace
+// it returns the records of all the namespaces, if the namespace is empty
+// only the records of the given chaosUID are returned, if it is not empty
+func Pending(clients clients.ClientSets, namespace, chaosUID string) ([]Record, error) {
+
+	ledgers, err := list(clients, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for _, ledger := range ledgers {
+		for key, value := range ledger.Data {
+			record := Record{}
+			if err := json.Unmarshal([]byte(value), &record); err != nil {
+				return nil, errors.Errorf("unable to parse the %v undo record of the %v/%v ledger, err: %v", key, ledger.Namespace, ledger.Name, err)
+			}
+			if chaosUID == "" || record.ChaosUID == chaosUID {
+				records = append(records, record)
+			}
+		}
+	}
+	return records, nil
+}
+
+// list returns all the ledgers in the given namespace
+func list(clients clients.ClientSets, namespace string) ([]corev1.ConfigMap, error) {
+	ledgers, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).List(metav1.ListOptions{LabelSelector: "app=" + appLabel})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the ledgers, err: %v", err)
+	}
+	return ledgers.Items, nil
+}
+
+// replay reverts the undo records of the given ledger and removes them from the ledger, once they are reverted
+// only the records of the given chaosUID are replayed, if it is not empty
+func replay(clients clients.ClientSets, ledger *corev1.ConfigMap, chaosUID string) error {
+
+	var failed []string
+	for key, value := range ledger.Data {
+		record := Record{}
+		if err := json.Unmarshal([]byte(value), &record); err != nil {
+			log.Errorf("Unable to parse the %v undo record, err: %v", key, err)
+			failed = append(failed, key)
+			continue
+		}
+		if chaosUID != "" && record.ChaosUID != chaosUID {
+			continue
+		}
+
+		log.InfoWithValues("[Recovery]: Reverting the outstanding chaos", logrus.Fields{
+			"Action":   record.Action,
+			"Target":   record.Target,
+			"ChaosUID": record.ChaosUID,
+			"Since":    record.Timestamp,
+		})
+		if err := Revert(clients, record); err != nil {
+			log.Errorf("Unable to revert the %v undo record, err: %v", key, err)
+			failed = append(failed, key)
+			continue
+		}
+		if err := removeKey(clients, ledger.Namespace, ledger.Name, key); err != nil {
+			return err
+		}
+	}
+
+	if len(failed) != 0 {
+		return errors.Errorf("unable to revert the %v undo records of the %v ledger", strings.Join(failed, ","), ledger.Name)
+	}
+	return nil
+}
+
+// removeKey removes the given key from the ledger and deletes the ledger, once it doesn't contain any record
+func removeKey(clients clients.ClientSets, namespace, name, key string) error {
+
+	err := retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		ledger, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := ledger.Data[key]; !ok {
+			return nil
+		}
+		delete(ledger.Data, key)
+		if len(ledger.Data) == 0 {
+			err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Delete(ledger.Name, &metav1.DeleteOptions{})
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		_, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Update(ledger)
+		return err
+	})
+	if err != nil {
+		return errors.Errorf("unable to remove the %v undo record from the %v ledger, err: %v", key, name, err)
+	}
+	return nil
+}
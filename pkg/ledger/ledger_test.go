@@ -0,0 +1,121 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testNamespace = "litmus"
+
+func newChaosDetails() *types.ChaosDetails {
+	return &types.ChaosDetails{
+		ChaosUID:       "chaos-uid",
+		ChaosNamespace: testNamespace,
+		EngineName:     "engine",
+		ExperimentName: "node-taint",
+	}
+}
+
+func TestAddAndRemove(t *testing.T) {
+	clients := clients.NewFakeClientSets(nil, nil, nil)
+	chaosDetails := newChaosDetails()
+	records := []Record{
+		{Action: RemoveTaint, Target: "node-1", Values: map[string]string{"key": "chaos"}},
+		{Action: ScaleDeployment, Target: "nginx", Namespace: "app-ns", Values: map[string]string{"replicas": "2"}},
+	}
+
+	for _, record := range records {
+		if err := Add(clients, chaosDetails, record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	ledger, err := clients.KubeClient.CoreV1().ConfigMaps(testNamespace).Get("engine-node-taint-ledger", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unable to get the ledger: %v", err)
+	}
+	if len(ledger.Data) != 2 || ledger.Data["scale-deployment.app-ns.nginx"] == "" {
+		t.Fatalf("expected the undo records in the ledger, found %v", ledger.Data)
+	}
+
+	for _, record := range records {
+		if err := Remove(clients, chaosDetails, record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// the ledger is deleted, once all the records are removed
+	if _, err := clients.KubeClient.CoreV1().ConfigMaps(testNamespace).Get("engine-node-taint-ledger", metav1.GetOptions{}); err == nil {
+		t.Fatalf("expected the empty ledger to be deleted")
+	}
+	// removing an unknown record is a no-op
+	if err := Remove(clients, chaosDetails, records[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	replicas := int32(5)
+	objects := []runtime.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "tainted"},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: "chaos", Value: "node-taint", Effect: corev1.TaintEffectNoExecute},
+				{Key: "chaos", Value: "node-taint", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule},
+			}},
+		},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cordoned"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "app-ns"}, Spec: appsv1.DeploymentSpec{Replicas: &replicas}},
+	}
+	clients := clients.NewFakeClientSets(objects, nil, nil)
+	chaosDetails := newChaosDetails()
+
+	records := []Record{
+		{Action: RemoveTaint, Target: "tainted", Values: map[string]string{"key": "chaos", "value": "node-taint", "effect": "NoExecute"}},
+		{Action: UncordonNode, Target: "cordoned"},
+		{Action: ScaleDeployment, Target: "nginx", Namespace: "app-ns", Values: map[string]string{"replicas": "2"}},
+	}
+	for _, record := range records {
+		if err := Add(clients, chaosDetails, record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := Recover(clients, chaosDetails); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tainted, _ := clients.KubeClient.CoreV1().Nodes().Get("tainted", metav1.GetOptions{})
+	// the taint of the user with the same key but another effect is kept
+	if len(tainted.Spec.Taints) != 2 || tainted.Spec.Taints[0].Effect != corev1.TaintEffectNoSchedule || tainted.Spec.Taints[1].Key != "dedicated" {
+		t.Errorf("expected only the chaos taint to be removed, found %v", tainted.Spec.Taints)
+	}
+	cordoned, _ := clients.KubeClient.CoreV1().Nodes().Get("cordoned", metav1.GetOptions{})
+	if cordoned.Spec.Unschedulable {
+		t.Errorf("expected the node to be uncordoned")
+	}
+	deployment, _ := clients.KubeClient.AppsV1().Deployments("app-ns").Get("nginx", metav1.GetOptions{})
+	if *deployment.Spec.Replicas != 2 {
+		t.Errorf("expected the deployment to be scaled back to 2 replicas, found %v", *deployment.Spec.Replicas)
+	}
+	if _, err := clients.KubeClient.CoreV1().ConfigMaps(testNamespace).Get("engine-node-taint-ledger", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected the ledger to be deleted, once all the records are reverted")
+	}
+
+	// the records are kept in the ledger, if they can't be reverted
+	if err := Add(clients, chaosDetails, Record{Action: UncordonNode, Target: "unknown"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Recover(clients, chaosDetails); err == nil {
+		t.Fatalf("expected an error for the unknown node")
+	}
+	ledger, err := clients.KubeClient.CoreV1().ConfigMaps(testNamespace).Get("engine-node-taint-ledger", metav1.GetOptions{})
+	if err != nil || len(ledger.Data) != 1 {
+		t.Fatalf("expected the failed record to be kept in the ledger, found %v, err: %v", ledger, err)
+	}
+}
@@ -0,0 +1,124 @@
+package ledger
+
+import (
+	"strconv"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	awslib "github.com/litmuschaos/litmus-go/pkg/cloud/aws"
+	ebsTypes "github.com/litmuschaos/litmus-go/pkg/kube-aws/ebs-loss/types"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	retries "k8s.io/client-go/util/retry"
+)
+
+// Revert reverts the fault, recorded in the given undo record
+// the revert is idempotent, it is a no-op if the fault is already reverted
+func Revert(clients clients.ClientSets, record Record) error {
+	switch record.Action {
+	case RemoveTaint:
+		taint := corev1.Taint{Key: record.Values["key"], Value: record.Values["value"], Effect: corev1.TaintEffect(record.Values["effect"])}
+		return removeTaint(clients, record.Target, taint)
+	case UncordonNode:
+		return uncordonNode(clients, record.Target)
+	case ScaleDeployment, ScaleStatefulSet:
+		replicas, err := strconv.Atoi(record.Values["replicas"])
+		if err != nil {
+			return errors.Errorf("invalid replicas in the undo record of %v, err: %v", record.Target, err)
+		}
+		return scale(clients, record.Action, record.Namespace, record.Target, int32(replicas))
+	case StartEC2Instance:
+		return startEC2Instance(record.Target, record.Values["region"])
+	case AttachEBSVolume:
+		return attachEBSVolume(record.Target, record.Values["instanceID"], record.Values["deviceName"], record.Values["region"])
+	default:
+		return errors.Errorf("unsupported action '%v' in the undo record of %v", record.Action, record.Target)
+	}
+}
+
+// removeTaint removes the taint with the same key, value & effect from the node
+// the other taints with the same key (e.g. added by the user before the chaos) are kept as it is
+func removeTaint(clients clients.ClientSets, nodeName string, added corev1.Taint) error {
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		node, err := clients.KubeClient.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		var taints []corev1.Taint
+		for _, taint := range node.Spec.Taints {
+			if taint.Key != added.Key || taint.Value != added.Value || taint.Effect != added.Effect {
+				taints = append(taints, taint)
+			}
+		}
+		if len(taints) == len(node.Spec.Taints) {
+			return nil
+		}
+		node.Spec.Taints = taints
+		_, err = clients.KubeClient.CoreV1().Nodes().Update(node)
+		return err
+	})
+}
+
+// uncordonNode marks the node as schedulable
+func uncordonNode(clients clients.ClientSets, nodeName string) error {
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		node, err := clients.KubeClient.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !node.Spec.Unschedulable {
+			return nil
+		}
+		node.Spec.Unschedulable = false
+		_, err = clients.KubeClient.CoreV1().Nodes().Update(node)
+		return err
+	})
+}
+
+// scale scales the deployment or statefulset to the given replicas
+func scale(clients clients.ClientSets, action, namespace, name string, replicas int32) error {
+	return retries.RetryOnConflict(retries.DefaultRetry, func() error {
+		if action == ScaleDeployment {
+			deployment, err := clients.KubeClient.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			deployment.Spec.Replicas = &replicas
+			_, err = clients.KubeClient.AppsV1().Deployments(namespace).Update(deployment)
+			return err
+		}
+		statefulset, err := clients.KubeClient.AppsV1().StatefulSets(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		statefulset.Spec.Replicas = &replicas
+		_, err = clients.KubeClient.AppsV1().StatefulSets(namespace).Update(statefulset)
+		return err
+	})
+}
+
+// startEC2Instance starts the ec2 instance, if it is not running already
+func startEC2Instance(instanceID, region string) error {
+	state, err := awslib.GetEC2InstanceStatus(instanceID, region)
+	if err != nil {
+		return err
+	}
+	if state == "running" || state == "pending" {
+		return nil
+	}
+	return awslib.EC2Start(instanceID, region)
+}
+
+// attachEBSVolume attaches the ebs volume to the ec2 instance, if it is not attached already
+func attachEBSVolume(volumeID, instanceID, deviceName, region string) error {
+	state, err := awslib.GetEBSStatus(&ebsTypes.ExperimentDetails{EBSVolumeID: volumeID, Ec2InstanceID: instanceID, Region: region})
+	if err != nil {
+		return err
+	}
+	if state != "detached" {
+		log.Infof("[Skip]: The %v ebs volume is in %v state", volumeID, state)
+		return nil
+	}
+	return awslib.EBSVolumeAttach(volumeID, instanceID, deviceName, region)
+}
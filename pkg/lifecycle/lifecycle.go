@@ -0,0 +1,411 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
+	"github.com/litmuschaos/litmus-go/pkg/ledger"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
+	"github.com/litmuschaos/litmus-go/pkg/notify"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/report"
+	"github.com/litmuschaos/litmus-go/pkg/result"
+	"github.com/litmuschaos/litmus-go/pkg/standalone"
+	"github.com/litmuschaos/litmus-go/pkg/status"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Check contains the details of an experiment specific status check
+type Check struct {
+	// Description is logged before running the check and used as the fail step, if the check fails
+	Description string
+	// Run performs the check, it should stop once the context is cancelled
+	Run func(ctx context.Context) error
+}
+
+// InjectFunc injects the chaos with the help of the chaoslib
+// the chaoslib should stop & revert the chaos and return common.ErrChaosStopped, once the context is cancelled
+type InjectFunc func(ctx context.Context, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails) error
+
+// Details contains the experiment specific attributes, needed to drive the experiment lifecycle
+type Details struct {
+	// EnvErr contains the validation errors of the experiment env, returned by GetENV
+	// the experiment fails at PreReq, once the chaosresult is created, if it is set
+	EnvErr error
+	// AppInfo is displayed before the pre-chaos checks
+	AppInfo logrus.Fields
+	// AppNS and AppLabel are used to verify the application under test
+	AppNS    string
+	AppLabel string
+	// TargetContainer refers to the target container of the experiment
+	// it is a reference, as the chaoslib may derive the target container during the chaos injection
+	TargetContainer *string
+	// AuxiliaryAppInfo contains the details of the auxiliary applications, checked in pre & post chaos
+	AuxiliaryAppInfo string
+	Timeout          int
+	Delay            int
+	// SkipAUTCheck skips the application under test status checks,
+	// for the experiments which verify the application with its own checks
+	SkipAUTCheck bool
+	// PreChaosChecks and PostChaosChecks contains the experiment specific status checks
+	PreChaosChecks  []Check
+	PostChaosChecks []Check
+}
+
+// Run drives the experiment through all the phases of its lifecycle
+// it creates the chaosresult (SOT), runs the pre-chaos checks & probes, injects the chaos with the given inject function,
+// runs the post-chaos checks & probes and updates the chaosresult (EOT) along with the corresponding events
+// a nil inject function signifies that no chaoslib matched with the experiment inputs
+// the experiment is marked as stopped, once the context is cancelled (abort signal)
+func Run(ctx context.Context, clients clients.ClientSets, chaosDetails *types.ChaosDetails, details Details, inject InjectFunc) {
+
+	var err error
+	resultDetails := types.ResultDetails{}
+	eventsDetails := types.EventDetails{}
+
+	// the context is also cancelled, once a probe with stopOnFailure fails, which stops & reverts the chaos
+	ctx, halt := probe.WithHalt(ctx)
+	defer halt()
+
+	// Intialise Chaos Result Parameters
+	types.SetResultAttributes(&resultDetails, *chaosDetails)
+
+	// the retries of all the steps are recorded in the metrics
+	retry.SetRetryHook(metrics.Retried)
+
+	// the final phase of the chaosresult is exposed as the phase of the experiment, once it returns
+	// the result details are recorded for the report, along with the timeline of the phases
+	// the queued notifications are posted before it returns, bounded by the webhook timeout per queued notification
+	setPhase("PreReq")
+	defer func() {
+		metrics.SetPhase(resultDetails.Phase)
+		report.SetResult(chaosDetails, resultDetails)
+		notify.Flush()
+	}()
+
+	if probesEnabled(chaosDetails) {
+		// Intialise the probe details. Bail out upon error, as we haven't entered exp business logic yet
+		if err = probe.InitializeProbesInChaosResultDetails(chaosDetails, clients, &resultDetails); err != nil {
+			log.Errorf("Unable to initialize the probes, err: %v", err)
+			return
+		}
+	}
+
+	//Updating the chaos result in the beginning of experiment
+	log.Infof("[PreReq]: Updating the chaos result of %v experiment (SOT)", chaosDetails.ExperimentName)
+	if err = result.ChaosResult(chaosDetails, clients, &resultDetails, "SOT"); err != nil {
+		log.Errorf("Unable to Create the Chaos Result, err: %v", err)
+		failStep := "Updating the chaos result of " + chaosDetails.ExperimentName + " experiment (SOT)"
+		result.RecordAfterFailure(chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
+		return
+	}
+
+	// Set the chaos result uid
+	result.SetResultUID(&resultDetails, clients, chaosDetails)
+
+	// generating the event in chaosresult to marked the verdict as awaited
+	msg := "experiment: " + chaosDetails.ExperimentName + ", Result: Awaited"
+	types.SetResultEventAttributes(&eventsDetails, types.AwaitedVerdict, msg, "Normal", &resultDetails)
+	events.GenerateEvents(&eventsDetails, clients, chaosDetails, "ChaosResult")
+
+	// bail out, if any of the env is invalid, as the experiment can't run with the invalid inputs
+	if details.EnvErr != nil {
+		log.Errorf("Invalid experiment ENV, err: %v", details.EnvErr)
+		failStep := "Validating the experiment ENV, err: " + details.EnvErr.Error()
+		result.RecordAfterFailure(chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
+		return
+	}
+
+	// bail out, if any of the guardrail policies is invalid or the application namespace is protected
+	if err = guardrails.Load(&chaosDetails.Guardrails); err != nil {
+		log.Errorf("Invalid guardrail policies, err: %v", err)
+		failStep := "Validating the guardrail policies, err: " + err.Error()
+		result.RecordAfterFailure(chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
+		return
+	}
+	if violation, ok := guardrails.AsViolation(guardrails.CheckNamespace(chaosDetails.Guardrails, details.AppNS)); ok {
+		log.Errorf("Guardrail violation, err: %v", violation)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, violation.FailStep(), clients, &eventsDetails)
+		return
+	}
+
+	// bail out, if any of the webhook settings is invalid, else notify the start of the experiment
+	if err = notify.Load(chaosDetails); err != nil {
+		log.Errorf("Invalid webhook settings, err: %v", err)
+		failStep := "Validating the webhook settings, err: " + err.Error()
+		result.RecordAfterFailure(chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
+		return
+	}
+	notify.Send(notify.Notification{
+		Event:   notify.SOTEvent,
+		Message: chaosDetails.ExperimentName + " experiment has been started in " + details.AppNS + " namespace",
+	})
+
+	// revert the outstanding chaos of the earlier runs, which died before reverting it
+	log.Info("[Recovery]: Reverting the outstanding chaos of the earlier runs, if any")
+	if err = ledger.Recover(clients, chaosDetails); err != nil {
+		log.Errorf("Unable to revert the outstanding chaos, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, "Reverting the outstanding chaos of the earlier runs", clients, &eventsDetails)
+		return
+	}
+
+	//DISPLAY THE APP INFORMATION
+	log.InfoWithValues("The application information is as follows", details.AppInfo)
+
+	//PRE-CHAOS STATUS CHECKS
+	setPhase("PreChaosCheck")
+	if failStep, err := statusCheck(ctx, "pre-chaos", clients, chaosDetails, details, details.PreChaosChecks); err != nil {
+		if aborted(ctx, err) {
+			recordAfterStop(ctx, chaosDetails, &resultDetails, clients, &eventsDetails)
+			return
+		}
+		log.Errorf("Status check failed, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
+		return
+	}
+
+	// run the probes in the pre-chaos check
+	if err = probeCheck(ctx, "PreChaos", types.PreChaosCheck, clients, chaosDetails, &resultDetails, &eventsDetails); err != nil {
+		if aborted(ctx, err) {
+			recordAfterStop(ctx, chaosDetails, &resultDetails, clients, &eventsDetails)
+			return
+		}
+		log.Errorf("Probe Failed, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, "Failed while running probes", clients, &eventsDetails)
+		return
+	}
+
+	// Including the chaoslib for the experiment
+	if inject == nil {
+		log.Error("[Invalid]: Please Provide the correct LIB")
+		result.RecordAfterFailure(chaosDetails, &resultDetails, "no match found for specified lib", clients, &eventsDetails)
+		return
+	}
+	setPhase("ChaosInjection")
+	notify.Send(notify.Notification{
+		Event:   notify.ChaosInjectEvent,
+		Message: "Injecting " + chaosDetails.ExperimentName + " chaos in " + details.AppNS + " namespace",
+	})
+	metrics.InjectionStarted()
+	chaosDetails.ChaosWindow.StartTime = now()
+	err = inject(ctx, &resultDetails, &eventsDetails)
+	metrics.InjectionEnded()
+	chaosDetails.ChaosWindow.EndTime = now()
+	completeTargets(ctx, chaosDetails, details, err)
+	if aborted(ctx, err) {
+		log.Infof("[Abort]: %v chaos has been stopped", chaosDetails.ExperimentName)
+		recordAfterStop(ctx, chaosDetails, &resultDetails, clients, &eventsDetails)
+		return
+	}
+	// the selected targets are verified against the guardrails, before injecting the chaos
+	if violation, ok := guardrails.AsViolation(err); ok {
+		log.Errorf("Guardrail violation, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, violation.FailStep(), clients, &eventsDetails)
+		return
+	}
+	if err != nil {
+		log.Errorf("Chaos injection failed, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, "failed in chaos injection phase", clients, &eventsDetails)
+		return
+	}
+	log.Infof("[Confirmation]: %v chaos has been injected successfully", chaosDetails.ExperimentName)
+	resultDetails.Verdict = "Pass"
+
+	//POST-CHAOS STATUS CHECKS
+	setPhase("PostChaosCheck")
+	if failStep, err := statusCheck(ctx, "post-chaos", clients, chaosDetails, details, details.PostChaosChecks); err != nil {
+		if aborted(ctx, err) {
+			recordAfterStop(ctx, chaosDetails, &resultDetails, clients, &eventsDetails)
+			return
+		}
+		log.Errorf("Status check failed, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, failStep, clients, &eventsDetails)
+		return
+	}
+
+	// run the probes in the post-chaos check
+	if err = probeCheck(ctx, "PostChaos", types.PostChaosCheck, clients, chaosDetails, &resultDetails, &eventsDetails); err != nil {
+		if aborted(ctx, err) {
+			recordAfterStop(ctx, chaosDetails, &resultDetails, clients, &eventsDetails)
+			return
+		}
+		log.Errorf("Probes Failed, err: %v", err)
+		result.RecordAfterFailure(chaosDetails, &resultDetails, "Failed while running probes", clients, &eventsDetails)
+		return
+	}
+
+	//Updating the chaosResult in the end of experiment
+	log.Infof("[The End]: Updating the chaos result of %v experiment (EOT)", chaosDetails.ExperimentName)
+	if err = result.ChaosResult(chaosDetails, clients, &resultDetails, "EOT"); err != nil {
+		log.Errorf("Unable to Update the Chaos Result, err: %v", err)
+		return
+	}
+
+	// generating the event in chaosresult to marked the verdict as pass/fail
+	msg = "experiment: " + chaosDetails.ExperimentName + ", Result: " + resultDetails.Verdict
+	reason := types.PassVerdict
+	eventType := "Normal"
+	if resultDetails.Verdict != "Pass" {
+		reason = types.FailVerdict
+		eventType = "Warning"
+	}
+	types.SetResultEventAttributes(&eventsDetails, reason, msg, eventType, &resultDetails)
+	events.GenerateEvents(&eventsDetails, clients, chaosDetails, "ChaosResult")
+
+	if chaosDetails.EngineName != "" {
+		msg := chaosDetails.ExperimentName + " experiment has been " + resultDetails.Verdict + "ed"
+		types.SetEngineEventAttributes(&eventsDetails, types.Summary, msg, "Normal", chaosDetails)
+		events.GenerateEvents(&eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	notify.Send(notify.Notification{
+		Event:    notify.EOTEvent,
+		Message:  chaosDetails.ExperimentName + " experiment has been completed",
+		Verdict:  resultDetails.Verdict,
+		FailStep: resultDetails.FailStep,
+		Targets:  chaosDetails.Targets,
+	})
+}
+
+// statusCheck verifies the application under test, the auxiliary applications and runs the experiment specific checks
+// it returns the fail step along with the error, if any of the checks failed
+// it returns common.ErrChaosStopped without waiting for the checks, once the context is cancelled
+// the checks are stopped along with the context, as they run with the same context
+func statusCheck(ctx context.Context, phase string, clients clients.ClientSets, chaosDetails *types.ChaosDetails, details Details, checks []Check) (string, error) {
+
+	type status struct {
+		failStep string
+		err      error
+	}
+
+	done := make(chan status, 1)
+	go func() {
+		failStep, err := runStatusChecks(ctx, phase, clients, chaosDetails, details, checks)
+		done <- status{failStep: failStep, err: err}
+	}()
+
+	select {
+	case s := <-done:
+		return s.failStep, s.err
+	case <-ctx.Done():
+		return "", common.ErrChaosStopped
+	}
+}
+
+// runStatusChecks runs all the status checks for the given phase
+func runStatusChecks(ctx context.Context, phase string, clients clients.ClientSets, chaosDetails *types.ChaosDetails, details Details, checks []Check) (string, error) {
+
+	if !details.SkipAUTCheck {
+		targetContainer := ""
+		if details.TargetContainer != nil {
+			targetContainer = *details.TargetContainer
+		}
+		log.Infof("[Status]: Verify that the AUT (Application Under Test) is running (%v)", phase)
+		if err := status.AUTStatusCheck(ctx, details.AppNS, details.AppLabel, targetContainer, details.Timeout, details.Delay, clients, chaosDetails); err != nil {
+			return "Verify that the AUT (Application Under Test) is running (" + phase + ")", err
+		}
+	}
+
+	if details.AuxiliaryAppInfo != "" {
+		log.Infof("[Status]: Verify that the Auxiliary Applications are running (%v)", phase)
+		if err := status.CheckAuxiliaryApplicationStatus(ctx, details.AuxiliaryAppInfo, details.Timeout, details.Delay, clients); err != nil {
+			return "Verify that the Auxiliary Applications are running (" + phase + ")", err
+		}
+	}
+
+	for _, check := range checks {
+		log.Infof("[Status]: %v", check.Description)
+		if err := check.Run(ctx); err != nil {
+			return check.Description, err
+		}
+	}
+	return "", nil
+}
+
+// probeCheck runs the probes for the given phase and generates the corresponding chaosengine event
+func probeCheck(ctx context.Context, phase, reason string, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails) error {
+
+	if !probesEnabled(chaosDetails) {
+		return nil
+	}
+
+	// marking AUT as running, as we already checked the status of application under test
+	msg := "AUT: Running"
+
+	if len(resultDetails.ProbeDetails) != 0 {
+		if err := probe.RunProbes(ctx, chaosDetails, clients, resultDetails, phase, eventsDetails); err != nil {
+			types.SetEngineEventAttributes(eventsDetails, reason, "AUT: Running, Probes: Unsuccessful", "Warning", chaosDetails)
+			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+			return err
+		}
+		msg = "AUT: Running, Probes: Successful"
+	}
+
+	// generating the events for the pre/post chaos check
+	types.SetEngineEventAttributes(eventsDetails, reason, msg, "Normal", chaosDetails)
+	events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	return nil
+}
+
+// probesEnabled returns true, if the probes are defined for the experiment
+// the probes are derived from the chaosengine or from the spec file in the standalone mode
+func probesEnabled(chaosDetails *types.ChaosDetails) bool {
+	return chaosDetails.EngineName != "" || standalone.Enabled()
+}
+
+// recordAfterStop updates the chaosresult, once the experiment is stopped in between
+// the experiment is marked as failed, if it is halted by a probe with stopOnFailure, else it is marked as stopped (abort signal)
+func recordAfterStop(ctx context.Context, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, clients clients.ClientSets, eventsDetails *types.EventDetails) {
+	if probeName := probe.HaltedBy(ctx); probeName != "" {
+		log.Errorf("[Halt]: %v chaos has been stopped by the %v probe", chaosDetails.ExperimentName, probeName)
+		result.RecordAfterFailure(chaosDetails, resultDetails, "halted by probe "+probeName, clients, eventsDetails)
+		return
+	}
+	result.RecordAfterAbort(chaosDetails, resultDetails, clients, eventsDetails)
+}
+
+// completeTargets marks the chaos status of the injected targets, once the chaos injection completes
+// the chaoslibs mark the targets as reverted once the revert is verified, so the targets which are still injected
+// are marked as reverted if the chaos completed, as failed if it failed and they are kept as it is if the chaos is stopped
+// the target container is recorded for the pods, as it is derived by the chaoslib in most of the experiments
+func completeTargets(ctx context.Context, chaosDetails *types.ChaosDetails, details Details, err error) {
+	switch {
+	case err == nil:
+		common.CompleteTargets(chaosDetails, types.RevertedStatus)
+	case !aborted(ctx, err):
+		common.CompleteTargets(chaosDetails, types.FailedStatus)
+	}
+
+	if details.TargetContainer == nil || *details.TargetContainer == "" {
+		return
+	}
+	for index := range chaosDetails.Targets {
+		if chaosDetails.Targets[index].Kind == "pod" && chaosDetails.Targets[index].Container == "" {
+			chaosDetails.Targets[index].Container = *details.TargetContainer
+		}
+	}
+}
+
+// now returns the current time in RFC3339 format
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// setPhase marks the given phase as the current phase of the experiment, in the metrics & the report
+func setPhase(phase string) {
+	metrics.SetPhase(phase)
+	report.SetPhase(phase)
+}
+
+// aborted returns true, if the experiment is stopped because of the abort signal or halted by a probe
+func aborted(ctx context.Context, err error) bool {
+	return ctx.Err() != nil || errors.Is(err, common.ErrChaosStopped)
+}
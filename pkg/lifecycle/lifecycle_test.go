@@ -0,0 +1,75 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		details      Details
+		injectErr    error
+		wantInjected bool
+		wantVerdict  string
+		wantFailStep string
+	}{
+		{
+			name:         "pass",
+			wantInjected: true,
+			wantVerdict:  "Pass",
+			wantFailStep: "N/A",
+		},
+		{
+			name:         "pre-chaos AUT failure",
+			details:      Details{AppNS: "shop", AppLabel: "app=frontend", Timeout: 1, Delay: 1},
+			wantVerdict:  "Fail",
+			wantFailStep: "Verify that the AUT (Application Under Test) is running (pre-chaos)",
+		},
+		{
+			name:         "inject error",
+			injectErr:    errors.Errorf("unable to create the helper pod"),
+			wantInjected: true,
+			wantVerdict:  "Fail",
+			wantFailStep: "failed in chaos injection phase",
+		},
+		{
+			name:         "inject stopped",
+			injectErr:    common.ErrChaosStopped,
+			wantInjected: true,
+			wantVerdict:  "Stopped",
+			wantFailStep: "Chaos injection stopped!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := clients.NewFakeClientSets(nil, nil, nil)
+			chaosDetails := &types.ChaosDetails{ExperimentName: "pod-delete", ChaosNamespace: "litmus"}
+
+			injected := false
+			Run(context.Background(), clients, chaosDetails, tt.details, func(ctx context.Context, resultDetails *types.ResultDetails, eventsDetails *types.EventDetails) error {
+				injected = true
+				return tt.injectErr
+			})
+
+			if injected != tt.wantInjected {
+				t.Fatalf("expected the chaos injection to be %v, found %v", tt.wantInjected, injected)
+			}
+			result, err := clients.LitmusClient.ChaosResults("litmus").Get("pod-delete", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unable to get the chaosresult: %v", err)
+			}
+			status := result.Status.ExperimentStatus
+			if status.Phase != "Completed" || status.Verdict != tt.wantVerdict || status.FailStep != tt.wantFailStep {
+				t.Fatalf("expected %v verdict with %q fail step, found %+v", tt.wantVerdict, tt.wantFailStep, status)
+			}
+		})
+	}
+}
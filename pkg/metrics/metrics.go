@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// the metrics are exposed by the go-runner at /metrics, once the metrics address is provided
+// all the metrics are labelled with the name of the experiment, while serving them
+// the metrics are recorded even if they are not served, which keeps the callers free from any checks
+
+const namespace = "litmuschaos"
+
+var (
+	experimentPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "experiment_phase",
+		Help:      "Current phase of the experiment, the current phase is set to 1 and others to 0",
+	}, []string{"phase"})
+
+	injectionStartTime = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chaos_injection_start_timestamp_seconds",
+		Help:      "Unix timestamp of the start of the chaos injection",
+	})
+
+	injectionEndTime = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "chaos_injection_end_timestamp_seconds",
+		Help:      "Unix timestamp of the end of the chaos injection",
+	})
+
+	targetedPods = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "targeted_pods",
+		Help:      "Number of pods targeted by the experiment",
+	})
+
+	targetedNodes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "targeted_nodes",
+		Help:      "Number of nodes targeted by the experiment",
+	})
+
+	probeResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "probe_results_total",
+		Help:      "Number of probe runs by the probe name, mode and result (pass/fail)",
+	}, []string{"probe", "mode", "result"})
+
+	helperPodsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "helper_pods_created_total",
+		Help:      "Number of helper pods created by the experiment",
+	})
+
+	helperPodsFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "helper_pods_failed_total",
+		Help:      "Number of helper pods completed with the failed status",
+	})
+
+	retries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retries_total",
+		Help:      "Number of retried attempts of the retry utility",
+	})
+
+	collectors = []prometheus.Collector{
+		experimentPhase,
+		injectionStartTime,
+		injectionEndTime,
+		targetedPods,
+		targetedNodes,
+		probeResults,
+		helperPodsCreated,
+		helperPodsFailed,
+		retries,
+	}
+
+	mu           sync.Mutex
+	currentPhase string
+)
+
+// Serve exposes the metrics at the /metrics path of the given address, in background
+// it returns an error, if it is unable to listen on the given address
+func Serve(address, experimentName string) error {
+
+	registry := prometheus.NewRegistry()
+	registerer := prometheus.WrapRegistererWith(prometheus.Labels{"experiment": experimentName}, registry)
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return errors.Errorf("unable to register the metrics, err: %v", err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Errorf("unable to listen on %v for the metrics, err: %v", address, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	go http.Serve(listener, mux)
+	return nil
+}
+
+// SetPhase marks the given phase as the current phase of the experiment
+func SetPhase(phase string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if currentPhase != "" {
+		experimentPhase.WithLabelValues(currentPhase).Set(0)
+	}
+	experimentPhase.WithLabelValues(phase).Set(1)
+	currentPhase = phase
+}
+
+// InjectionStarted records the start time of the chaos injection
+func InjectionStarted() {
+	injectionStartTime.Set(float64(time.Now().Unix()))
+}
+
+// InjectionEnded records the end time of the chaos injection
+func InjectionEnded() {
+	injectionEndTime.Set(float64(time.Now().Unix()))
+}
+
+// SetTargetedPods records the number of the targeted pods
+func SetTargetedPods(count int) {
+	targetedPods.Set(float64(count))
+}
+
+// SetTargetedNodes records the number of the targeted nodes
+func SetTargetedNodes(count int) {
+	targetedNodes.Set(float64(count))
+}
+
+// ProbeCompleted records the result of a probe run
+func ProbeCompleted(probeName, mode string, err error) {
+	result := "pass"
+	if err != nil {
+		result = "fail"
+	}
+	probeResults.WithLabelValues(probeName, mode, result).Inc()
+}
+
+// HelperPodCreated records the helper pod creation, if it is created successfully
+func HelperPodCreated(err error) {
+	if err == nil {
+		helperPodsCreated.Inc()
+	}
+}
+
+// HelperPodsFailed records the number of the helper pods, completed with the failed status
+func HelperPodsFailed(count int) {
+	helperPodsFailed.Add(float64(count))
+}
+
+// Retried records a retried attempt
+func Retried() {
+	retries.Inc()
+}
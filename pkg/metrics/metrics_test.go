@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// freeAddress returns a local address, which is free to listen on
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen, err: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestServe(t *testing.T) {
+	address := freeAddress(t)
+	if err := Serve(address, "pod-delete"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// record the metrics of an experiment run
+	SetPhase("PreChaos")
+	SetPhase("ChaosInject")
+	InjectionStarted()
+	SetTargetedPods(2)
+	SetTargetedNodes(1)
+	HelperPodCreated(nil)
+	HelperPodCreated(nil)
+	HelperPodCreated(errors.Errorf("unable to create the helper pod"))
+	HelperPodsFailed(1)
+	Retried()
+	ProbeCompleted("check-frontend", "Continuous", nil)
+	ProbeCompleted("check-frontend", "Continuous", nil)
+	ProbeCompleted("check-orders", "Edge", errors.Errorf("unable to match the output"))
+	InjectionEnded()
+	SetPhase("Completed")
+
+	resp, err := http.Get("http://" + address + "/metrics")
+	if err != nil {
+		t.Fatalf("unable to get the metrics, err: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read the metrics, err: %v", err)
+	}
+	metrics := string(body)
+
+	// all the collectors are registered and labelled with the experiment name
+	for _, name := range []string{
+		"litmuschaos_experiment_phase",
+		"litmuschaos_chaos_injection_start_timestamp_seconds",
+		"litmuschaos_chaos_injection_end_timestamp_seconds",
+		"litmuschaos_targeted_pods",
+		"litmuschaos_targeted_nodes",
+		"litmuschaos_probe_results_total",
+		"litmuschaos_helper_pods_created_total",
+		"litmuschaos_helper_pods_failed_total",
+		"litmuschaos_retries_total",
+	} {
+		if !strings.Contains(metrics, "# TYPE "+name+" ") {
+			t.Errorf("expected the %v metric to be registered", name)
+		}
+	}
+
+	for _, sample := range []string{
+		`litmuschaos_experiment_phase{experiment="pod-delete",phase="PreChaos"} 0`,
+		`litmuschaos_experiment_phase{experiment="pod-delete",phase="ChaosInject"} 0`,
+		`litmuschaos_experiment_phase{experiment="pod-delete",phase="Completed"} 1`,
+		`litmuschaos_targeted_pods{experiment="pod-delete"} 2`,
+		`litmuschaos_targeted_nodes{experiment="pod-delete"} 1`,
+		`litmuschaos_helper_pods_created_total{experiment="pod-delete"} 2`,
+		`litmuschaos_helper_pods_failed_total{experiment="pod-delete"} 1`,
+		`litmuschaos_retries_total{experiment="pod-delete"} 1`,
+		`litmuschaos_probe_results_total{experiment="pod-delete",mode="Continuous",probe="check-frontend",result="pass"} 2`,
+		`litmuschaos_probe_results_total{experiment="pod-delete",mode="Edge",probe="check-orders",result="fail"} 1`,
+	} {
+		if !strings.Contains(metrics, sample+"\n") {
+			t.Errorf("expected the %v sample, found:\n%v", sample, metrics)
+		}
+	}
+	if strings.Contains(metrics, `litmuschaos_chaos_injection_start_timestamp_seconds{experiment="pod-delete"} 0`+"\n") {
+		t.Errorf("expected the injection start time to be recorded")
+	}
+}
+
+func TestServeInvalidAddress(t *testing.T) {
+	if err := Serve("invalid-address", "pod-delete"); err == nil {
+		t.Fatalf("expected an error, as the address is invalid")
+	}
+}
@@ -0,0 +1,343 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/config"
+	"github.com/pkg/errors"
+)
+
+// the notifications are posted to the webhooks on the lifecycle transitions of the experiment, so that the on-call engineers
+// are notified in their chat tool, once the chaos starts in a shared environment. The failed deliveries are only logged,
+// they don't fail the experiment. Nothing is posted, if the webhooks are not configured or not loaded yet
+// the notifications are posted asynchronously in the order of sending, so that a slow webhook doesn't delay the experiment
+
+const (
+	// SOTEvent is posted once the experiment starts
+	SOTEvent string = "SOT"
+	// ChaosInjectEvent is posted before injecting the chaos
+	ChaosInjectEvent string = "ChaosInject"
+	// ProbeFailureEvent is posted once a probe fails
+	ProbeFailureEvent string = "ProbeFailure"
+	// AbortEvent is posted once the experiment is aborted
+	AbortEvent string = "Abort"
+	// EOTEvent is posted once the experiment completes, along with its verdict
+	EOTEvent string = "EOT"
+)
+
+// supportedEvents contains all the events, which can be enabled with WEBHOOK_EVENTS
+var supportedEvents = []string{SOTEvent, ChaosInjectEvent, ProbeFailureEvent, AbortEvent, EOTEvent}
+
+const (
+	// SignatureHeader contains the hex encoded HMAC-SHA256 of the payload, in sha256=<signature> format
+	SignatureHeader string = "X-Litmus-Signature"
+	// EventHeader contains the event of the notification
+	EventHeader string = "X-Litmus-Event"
+)
+
+// Config contains the webhook settings, shared by all the experiments
+// the urls & events are comma separated
+type Config struct {
+	URLs    string `env:"WEBHOOK_URLS"`
+	Format  string `env:"WEBHOOK_FORMAT" default:"json" enum:"json|slack"`
+	Secret  string `env:"WEBHOOK_SECRET"`
+	Events  string `env:"WEBHOOK_EVENTS" default:"SOT,ChaosInject,ProbeFailure,Abort,EOT"`
+	Timeout int    `env:"WEBHOOK_TIMEOUT" default:"10" min:"1"`
+}
+
+// Notification contains the details of a lifecycle transition of the experiment
+type Notification struct {
+	Event    string
+	Message  string
+	Verdict  string
+	FailStep string
+	Probe    string
+	Targets  []types.TargetDetails
+}
+
+// Payload is the json payload, posted to the webhooks in json format
+type Payload struct {
+	Event          string                `json:"event"`
+	Experiment     string                `json:"experiment"`
+	Engine         string                `json:"engine,omitempty"`
+	ChaosNamespace string                `json:"chaosNamespace"`
+	ChaosUID       string                `json:"chaosUID,omitempty"`
+	Message        string                `json:"message"`
+	Verdict        string                `json:"verdict,omitempty"`
+	FailStep       string                `json:"failStep,omitempty"`
+	Probe          string                `json:"probe,omitempty"`
+	Targets        []types.TargetDetails `json:"targets,omitempty"`
+	Timestamp      string                `json:"timestamp"`
+}
+
+// queueSize is the max number of the notifications, waiting to be posted
+const queueSize = 100
+
+// delivery is a queued notification, the flush marker only contains the done channel
+type delivery struct {
+	event string
+	body  []byte
+	cfg   *Config
+	done  chan struct{}
+}
+
+var (
+	mu           sync.Mutex
+	settings     *Config
+	chaosDetails types.ChaosDetails
+	client       = &http.Client{}
+	queue        chan delivery
+)
+
+// Load loads the webhook settings from the env and records the experiment details, used in the payloads
+func Load(details *types.ChaosDetails) error {
+	cfg := &Config{}
+	if err := config.Load(cfg, nil); err != nil {
+		return err
+	}
+	for _, event := range split(cfg.Events) {
+		if !contains(supportedEvents, event) {
+			return errors.Errorf("invalid WEBHOOK_EVENTS, %v event is not supported, supported events: %v", event, strings.Join(supportedEvents, ","))
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+
+	settings, chaosDetails = cfg, *details
+	if queue == nil {
+		queue = make(chan delivery, queueSize)
+		go deliver(queue)
+	}
+	return nil
+}
+
+// Tunables returns the env variables of the webhook settings, which are shared by all the experiments
+func Tunables() ([]config.Tunable, error) {
+	return config.Describe(&Config{}, nil)
+}
+
+// Send queues the notification to be posted to all the webhooks, if the event is enabled
+// it doesn't wait for the delivery, the notification is dropped if the queue is full
+func Send(n Notification) {
+	mu.Lock()
+	cfg, details, q := settings, chaosDetails, queue
+	mu.Unlock()
+
+	if cfg == nil || strings.TrimSpace(cfg.URLs) == "" || !contains(split(cfg.Events), n.Event) {
+		return
+	}
+
+	payload := Payload{
+		Event:          n.Event,
+		Experiment:     details.ExperimentName,
+		Engine:         details.EngineName,
+		ChaosNamespace: details.ChaosNamespace,
+		ChaosUID:       string(details.ChaosUID),
+		Message:        n.Message,
+		Verdict:        n.Verdict,
+		FailStep:       n.FailStep,
+		Probe:          n.Probe,
+		Targets:        n.Targets,
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+	}
+	body, err := encode(payload, cfg.Format)
+	if err != nil {
+		log.Errorf("Unable to encode the %v notification, err: %v", n.Event, err)
+		return
+	}
+
+	select {
+	case q <- delivery{event: n.Event, body: body, cfg: cfg}:
+	default:
+		log.Errorf("Unable to post the %v notification, err: the notification queue is full", n.Event)
+	}
+}
+
+// Flush waits for the queued notifications to be posted, at most for the webhook timeout per queued notification
+// (including the one being posted), as every notification is posted to all the webhooks concurrently
+// it is called before the experiment exits, so that the final notifications are not lost
+func Flush() {
+	mu.Lock()
+	cfg, q := settings, queue
+	mu.Unlock()
+
+	if cfg == nil || q == nil {
+		return
+	}
+	timeout := time.After(time.Duration(cfg.Timeout*(len(q)+1)) * time.Second)
+	done := make(chan struct{})
+	select {
+	case q <- delivery{done: done}:
+	case <-timeout:
+		log.Warn("[Notify]: Timed out while waiting for the notifications to be posted")
+		return
+	}
+	select {
+	case <-done:
+	case <-timeout:
+		log.Warn("[Notify]: Timed out while waiting for the notifications to be posted")
+	}
+}
+
+// deliver posts the queued notifications one by one, in the order of sending
+// every notification is posted to all the webhooks concurrently, so it takes at most one webhook timeout
+func deliver(q chan delivery) {
+	for d := range q {
+		if d.done != nil {
+			close(d.done)
+			continue
+		}
+		var wg sync.WaitGroup
+		for _, url := range split(d.cfg.URLs) {
+			wg.Add(1)
+			go func(url string) {
+				defer wg.Done()
+				if err := post(url, d.body, d.event, d.cfg); err != nil {
+					log.Errorf("Unable to post the %v notification, err: %v", d.event, err)
+				}
+			}(url)
+		}
+		wg.Wait()
+	}
+}
+
+// encode returns the body of the payload, in the given format
+func encode(payload Payload, format string) ([]byte, error) {
+	if format == "slack" {
+		return json.Marshal(slackPayload(payload))
+	}
+	return json.Marshal(payload)
+}
+
+// post posts the body to the given webhook url, the body is signed if the secret is provided
+func post(url string, body []byte, event string, cfg *Config) error {
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Errorf("invalid webhook url %v, err: %v", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	if cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(body, cfg.Secret))
+	}
+
+	c := *client
+	c.Timeout = time.Duration(cfg.Timeout) * time.Second
+	resp, err := c.Do(req)
+	if err != nil {
+		return errors.Errorf("unable to reach the %v webhook, err: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("%v webhook responded with %v status", url, resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body, with the given secret
+// the receivers should compute the same over the raw body and compare it with the signature header
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// slack message format, as accepted by the slack incoming webhooks
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color,omitempty"`
+	Fields []slackField `json:"fields,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// slackPayload converts the payload into the slack message format
+func slackPayload(payload Payload) slackMessage {
+
+	fields := []slackField{
+		{Title: "Experiment", Value: payload.Experiment, Short: true},
+		{Title: "Namespace", Value: payload.ChaosNamespace, Short: true},
+	}
+	if payload.Engine != "" {
+		fields = append(fields, slackField{Title: "Engine", Value: payload.Engine, Short: true})
+	}
+	if payload.Verdict != "" {
+		fields = append(fields, slackField{Title: "Verdict", Value: payload.Verdict, Short: true})
+	}
+	if payload.FailStep != "" && payload.Verdict != types.PassVerdict {
+		fields = append(fields, slackField{Title: "Fail Step", Value: payload.FailStep})
+	}
+	if payload.Probe != "" {
+		fields = append(fields, slackField{Title: "Probe", Value: payload.Probe, Short: true})
+	}
+	if len(payload.Targets) != 0 {
+		var targets []string
+		for _, t := range payload.Targets {
+			name := t.Name
+			if t.Namespace != "" {
+				name = t.Namespace + "/" + t.Name
+			}
+			targets = append(targets, fmt.Sprintf("%v %v (%v)", t.Kind, name, t.ChaosStatus))
+		}
+		fields = append(fields, slackField{Title: "Targets", Value: strings.Join(targets, "\n")})
+	}
+
+	return slackMessage{
+		Text:        fmt.Sprintf("*[%v] %v*: %v", payload.Event, payload.Experiment, payload.Message),
+		Attachments: []slackAttachment{{Color: color(payload), Fields: fields}},
+	}
+}
+
+// color returns the color of the slack attachment, derived from the event & verdict
+func color(payload Payload) string {
+	switch {
+	case payload.Event == ProbeFailureEvent || payload.Verdict == types.FailVerdict:
+		return "danger"
+	case payload.Event == AbortEvent || payload.Event == ChaosInjectEvent:
+		return "warning"
+	case payload.Verdict == types.PassVerdict:
+		return "good"
+	}
+	return ""
+}
+
+// split returns the trimmed, non empty values of the comma separated list
+func split(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// contains returns true, if the list contains the given value
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+type request struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func newWebhook(t *testing.T, env map[string]string) (chan request, func()) {
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{event: r.Header.Get(EventHeader), signature: r.Header.Get(SignatureHeader), body: body}
+	}))
+
+	env["WEBHOOK_URLS"] = server.URL
+	for name, value := range env {
+		os.Setenv(name, value)
+	}
+	if err := Load(&types.ChaosDetails{ExperimentName: "pod-delete", ChaosNamespace: "litmus", EngineName: "engine"}); err != nil {
+		t.Fatalf("unable to load the webhook settings, err: %v", err)
+	}
+	return requests, func() {
+		server.Close()
+		for name := range env {
+			os.Unsetenv(name)
+		}
+		settings = nil
+	}
+}
+
+func TestSend(t *testing.T) {
+	requests, cleanup := newWebhook(t, map[string]string{"WEBHOOK_SECRET": "secret", "WEBHOOK_EVENTS": "SOT,EOT"})
+	defer cleanup()
+
+	// the disabled events are not posted
+	Send(Notification{Event: ChaosInjectEvent, Message: "Injecting pod-delete chaos"})
+	Send(Notification{Event: EOTEvent, Message: "pod-delete experiment has been completed", Verdict: types.PassVerdict})
+
+	req := <-requests
+	if req.event != EOTEvent {
+		t.Fatalf("expected the EOT event, found %v", req.event)
+	}
+	if req.signature != "sha256="+Sign(req.body, "secret") {
+		t.Fatalf("unexpected signature %v", req.signature)
+	}
+	payload := Payload{}
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("unable to parse the payload, err: %v", err)
+	}
+	if payload.Experiment != "pod-delete" || payload.Engine != "engine" || payload.Verdict != types.PassVerdict {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+}
+
+func TestSendSlack(t *testing.T) {
+	requests, cleanup := newWebhook(t, map[string]string{"WEBHOOK_FORMAT": "slack"})
+	defer cleanup()
+
+	Send(Notification{Event: ProbeFailureEvent, Message: "The check-frontend httpProbe has been failed", Probe: "check-frontend"})
+
+	req := <-requests
+	message := slackMessage{}
+	if err := json.Unmarshal(req.body, &message); err != nil {
+		t.Fatalf("unable to parse the slack message, err: %v", err)
+	}
+	if message.Text != "*[ProbeFailure] pod-delete*: The check-frontend httpProbe has been failed" || message.Attachments[0].Color != "danger" {
+		t.Fatalf("unexpected slack message %+v", message)
+	}
+	if req.signature != "" {
+		t.Fatalf("expected the unsigned payload, found %v signature", req.signature)
+	}
+}
+
+func TestSendAsync(t *testing.T) {
+	release := make(chan struct{})
+	posted := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		posted <- r.Header.Get(EventHeader)
+	}))
+	defer server.Close()
+
+	os.Setenv("WEBHOOK_URLS", server.URL)
+	defer os.Unsetenv("WEBHOOK_URLS")
+	if err := Load(&types.ChaosDetails{ExperimentName: "pod-delete"}); err != nil {
+		t.Fatalf("unable to load the webhook settings, err: %v", err)
+	}
+	defer func() { settings = nil }()
+
+	// the slow webhook doesn't block the sender
+	start := time.Now()
+	Send(Notification{Event: SOTEvent, Message: "pod-delete experiment has been started"})
+	Send(Notification{Event: EOTEvent, Message: "pod-delete experiment has been completed"})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the notifications to be sent asynchronously, took %v", elapsed)
+	}
+
+	// the queued notifications are posted in order, before the flush returns
+	close(release)
+	Flush()
+	if len(posted) != 2 || <-posted != SOTEvent || <-posted != EOTEvent {
+		t.Fatalf("expected the SOT & EOT notifications to be posted in order")
+	}
+}
+
+func TestFlush(t *testing.T) {
+	posted := make(chan string, 10)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(600 * time.Millisecond)
+		posted <- r.Header.Get(EventHeader)
+	})
+	first, second := httptest.NewServer(handler), httptest.NewServer(handler)
+	defer first.Close()
+	defer second.Close()
+
+	os.Setenv("WEBHOOK_URLS", first.URL+","+second.URL)
+	os.Setenv("WEBHOOK_TIMEOUT", "1")
+	defer os.Unsetenv("WEBHOOK_URLS")
+	defer os.Unsetenv("WEBHOOK_TIMEOUT")
+	if err := Load(&types.ChaosDetails{ExperimentName: "pod-delete"}); err != nil {
+		t.Fatalf("unable to load the webhook settings, err: %v", err)
+	}
+	defer func() { settings = nil }()
+
+	// the queued notifications take longer than a single webhook timeout, but all of them are posted before the flush returns
+	Send(Notification{Event: SOTEvent, Message: "pod-delete experiment has been started"})
+	Send(Notification{Event: ChaosInjectEvent, Message: "Injecting pod-delete chaos"})
+	Send(Notification{Event: EOTEvent, Message: "pod-delete experiment has been completed"})
+	Flush()
+	if len(posted) != 6 {
+		t.Fatalf("expected the notifications to be posted to both the webhooks, found %v", len(posted))
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"WEBHOOK_FORMAT": "xml",
+		"WEBHOOK_EVENTS": "SOT,Finish",
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			os.Setenv(name, value)
+			defer os.Unsetenv(name)
+
+			if err := Load(&types.ChaosDetails{}); err == nil {
+				t.Fatalf("expected the invalid %v to be rejected", name)
+			}
+		})
+	}
+}
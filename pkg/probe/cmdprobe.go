@@ -2,6 +2,7 @@ package probe
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/rand"
 	"os/exec"
@@ -15,6 +16,7 @@ import (
 	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
 	"github.com/litmuschaos/litmus-go/pkg/status"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	litmusexec "github.com/litmuschaos/litmus-go/pkg/utils/exec"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
@@ -27,19 +29,19 @@ import (
 // cmd probe can be used to add the command probes
 // it can be of two types one: which need a source(an external image)
 // another: any inline command which can be run without source image, directly via go-runner image
-func PrepareCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+func PrepareCmdProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
 
 	switch phase {
 	case "PreChaos":
-		if err := PreChaosCmdProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PreChaosCmdProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "PostChaos":
-		if err := PostChaosCmdProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PostChaosCmdProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "DuringChaos":
-		if err := OnChaosCmdProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := OnChaosCmdProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	default:
@@ -49,7 +51,8 @@ func PrepareCmdProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 }
 
 // TriggerInlineCmdProbe trigger the cmd probe and storing the output into the out buffer
-func TriggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+func TriggerInlineCmdProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails) error {
+	var err error
 
 	// It parse the templated command and return normal string
 	// if command doesn't have template, it will return the same command
@@ -60,36 +63,39 @@ func TriggerInlineCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.
 
 	// running the cmd probe command and storing the output into the out buffer
 	// it will retry for some retry count, in each iterations of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the iterval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
+	// each iteration of retry is bounded by the probe timeout, the command is cancelled once the timeout expires
+	// if it fails, it will wait for the interval and execute the command again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
 			var out, errOut bytes.Buffer
 			// run the inline command probe
-			cmd := exec.Command("/bin/sh", "-c", probe.CmdProbeInputs.Command)
+			cmd := exec.CommandContext(ctx, "/bin/sh", "-c", probe.CmdProbeInputs.Command)
 			cmd.Stdout = &out
 			cmd.Stderr = &errOut
 			if err := cmd.Run(); err != nil {
-				return errors.Errorf("unable to run command, err: %v; error output: %v", err, errOut.String())
+				return nil, errors.Errorf("unable to run command, err: %v; error output: %v", err, errOut.String())
 			}
 
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
-			if err = ValidateResult(probe.CmdProbeInputs.Comparator, strings.TrimSpace(out.String()), rc); err != nil {
+			if err := ValidateResult(probe.CmdProbeInputs.Comparator, strings.TrimSpace(out.String()), rc); err != nil {
 				log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
-				return err
+				return nil, err
 			}
 
-			probes := types.ProbeArtifact{}
-			probes.ProbeArtifacts.Register = strings.TrimSpace(out.String())
-			resultDetails.ProbeArtifacts[probe.Name] = probes
-			return nil
+			return strings.TrimSpace(out.String()), nil
 		})
+	if err != nil {
+		return err
+	}
+
+	// storing the output, so that the other probes can use it
+	setProbeArtifact(resultDetails, probe.Name, register.(string))
+	return nil
 }
 
 // TriggerSourceCmdProbe trigger the cmd probe inside the external pod
-func TriggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+func TriggerSourceCmdProbe(ctx context.Context, probe types.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	var err error
 
 	// It parse the templated command and return normal string
 	// if command doesn't have template, it will return the same command
@@ -100,30 +106,36 @@ func TriggerSourceCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails li
 
 	// running the cmd probe command and matching the output
 	// it will retry for some retry count, in each iterations of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the iterval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
+	// each iteration of retry is bounded by the probe timeout, the command is cancelled once the timeout expires
+	// if it fails, it will wait for the interval and execute the command again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
 			command := append([]string{"/bin/sh", "-c"}, probe.CmdProbeInputs.Command)
 			// exec inside the external pod to get the o/p of given command
 			output, err := litmusexec.Exec(&execCommandDetails, clients, command)
 			if err != nil {
-				return errors.Errorf("Unable to get output of cmd command, err: %v", err)
+				return nil, errors.Errorf("Unable to get output of cmd command, err: %v", err)
+			}
+			// the exec can't be cancelled, skip the result if the iteration has already timed out
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
 			}
 
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
 			if err = ValidateResult(probe.CmdProbeInputs.Comparator, strings.TrimSpace(output), rc); err != nil {
 				log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
-				return err
+				return nil, err
 			}
 
-			probes := types.ProbeArtifact{}
-			probes.ProbeArtifacts.Register = strings.TrimSpace(output)
-			resultDetails.ProbeArtifacts[probe.Name] = probes
-			return nil
+			return strings.TrimSpace(output), nil
 		})
+	if err != nil {
+		return err
+	}
+
+	// storing the output, so that the other probes can use it
+	setProbeArtifact(resultDetails, probe.Name, register.(string))
+	return nil
 }
 
 // CreateProbePod creates an external pod with source image for the cmd probe
@@ -193,41 +205,51 @@ func GetRunID() string {
 }
 
 // TriggerInlineContinuousCmdProbe trigger the inline continuous cmd probes
-func TriggerInlineContinuousCmdProbe(probe v1alpha1.ProbeAttributes, chaosresult *types.ResultDetails) {
+func TriggerInlineContinuousCmdProbe(ctx context.Context, probe types.ProbeAttributes, chaosresult *types.ResultDetails) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 	}
 
 	// it trigger the inline cmd probe for the entire duration of chaos and it fails, if any err encounter
 	// it marked the error for the probes, if any
 loop:
 	for {
-		err = TriggerInlineCmdProbe(probe, chaosresult)
+		err = TriggerInlineCmdProbe(ctx, probe, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
 			for index := range chaosresult.ProbeDetails {
 				if chaosresult.ProbeDetails[index].Name == probe.Name {
 					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 					log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
 					break loop
 				}
 			}
 		}
 		// waiting for the probe polling interval
-		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
 	}
 }
 
 // TriggerInlineOnChaosCmdProbe trigger the inline onchaos cmd probes
-func TriggerInlineOnChaosCmdProbe(probe v1alpha1.ProbeAttributes, chaosresult *types.ResultDetails, duration int) {
+func TriggerInlineOnChaosCmdProbe(ctx context.Context, probe types.ProbeAttributes, chaosresult *types.ResultDetails, duration int) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
 	}
 
@@ -245,30 +267,37 @@ loop:
 			endTime = nil
 			break loop
 		default:
-			err = TriggerInlineCmdProbe(probe, chaosresult)
+			err = TriggerInlineCmdProbe(ctx, probe, chaosresult)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
 						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 						log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+						haltOnFailure(ctx, probe)
 						break loop
 					}
 				}
 			}
 			// waiting for the probe polling interval
-			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
 		}
 	}
 }
 
 // TriggerSourceOnChaosCmdProbe trigger the onchaos cmd probes having need some external source image
-func TriggerSourceOnChaosCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails, duration int) {
+func TriggerSourceOnChaosCmdProbe(ctx context.Context, probe types.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails, duration int) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
 	}
 
@@ -287,53 +316,65 @@ loop:
 			break loop
 		default:
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
-			if err = TriggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult); err != nil {
+			if err = TriggerSourceCmdProbe(ctx, probe, execCommandDetails, clients, chaosresult); err != nil {
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
 						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 						log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+						haltOnFailure(ctx, probe)
 						break loop
 					}
 				}
 			}
 			// waiting for the probe polling interval
-			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
 		}
 	}
 }
 
 // TriggerSourceContinuousCmdProbe trigger the continuous cmd probes having need some external source image
-func TriggerSourceContinuousCmdProbe(probe v1alpha1.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails) {
+func TriggerSourceContinuousCmdProbe(ctx context.Context, probe types.ProbeAttributes, execCommandDetails litmusexec.PodDetails, clients clients.ClientSets, chaosresult *types.ResultDetails) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 	}
 
 	// it trigger the cmd probe for the entire duration of chaos and it fails, if any err encounter
 	// it marked the error for the probes, if any
 loop:
 	for {
-		err = TriggerSourceCmdProbe(probe, execCommandDetails, clients, chaosresult)
+		err = TriggerSourceCmdProbe(ctx, probe, execCommandDetails, clients, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
 			for index := range chaosresult.ProbeDetails {
 				if chaosresult.ProbeDetails[index].Name == probe.Name {
 					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 					log.Errorf("The %v cmd probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
 					break loop
 				}
 			}
 		}
 		// waiting for the probe polling interval
-		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
 	}
 }
 
 // ValidateResult validate the probe result to specified comparison operation
 // it supports int, float, string operands
 func ValidateResult(comparator v1alpha1.ComparatorInfo, cmdOutput string, rc int) error {
+	var err error
 
 	compare := cmp.RunCount(rc).
 		FirstValue(cmdOutput).
@@ -360,7 +401,8 @@ func ValidateResult(comparator v1alpha1.ComparatorInfo, cmdOutput string, rc int
 }
 
 //PreChaosCmdProbe trigger the cmd probe for prechaos phase
-func PreChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PreChaosCmdProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "SOT", "Edge":
@@ -379,12 +421,14 @@ func PreChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 
 		// triggering the cmd probe for the inline mode
 		if probe.CmdProbeInputs.Source == "inline" {
-			err = TriggerInlineCmdProbe(probe, resultDetails)
+			err = TriggerInlineCmdProbe(ctx, probe, resultDetails)
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
@@ -393,13 +437,13 @@ func PreChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			}
 		} else {
 
-			execCommandDetails, err := CreateHelperPod(probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
+			execCommandDetails, err := CreateHelperPod(ctx, probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
 			if err != nil {
 				return err
 			}
 
 			// triggering the cmd probe and storing the output into the out buffer
-			err = TriggerSourceCmdProbe(probe, execCommandDetails, clients, resultDetails)
+			err = TriggerSourceCmdProbe(ctx, probe, execCommandDetails, clients, resultDetails)
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
@@ -429,16 +473,16 @@ func PreChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			"Phase":          "PreChaos",
 		})
 		if probe.CmdProbeInputs.Source == "inline" {
-			go TriggerInlineContinuousCmdProbe(probe, resultDetails)
+			go TriggerInlineContinuousCmdProbe(ctx, probe, resultDetails)
 		} else {
 
-			execCommandDetails, err := CreateHelperPod(probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
+			execCommandDetails, err := CreateHelperPod(ctx, probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
 			if err != nil {
 				return err
 			}
 
 			// trigger the continuous cmd probe
-			go TriggerSourceContinuousCmdProbe(probe, execCommandDetails, clients, resultDetails)
+			go TriggerSourceContinuousCmdProbe(ctx, probe, execCommandDetails, clients, resultDetails)
 		}
 
 	}
@@ -446,7 +490,8 @@ func PreChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 }
 
 //PostChaosCmdProbe trigger cmd probe for post chaos phase
-func PostChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PostChaosCmdProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "EOT", "Edge":
@@ -465,12 +510,14 @@ func PostChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 
 		// triggering the cmd probe for the inline mode
 		if probe.CmdProbeInputs.Source == "inline" {
-			err = TriggerInlineCmdProbe(probe, resultDetails)
+			err = TriggerInlineCmdProbe(ctx, probe, resultDetails)
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
@@ -479,13 +526,13 @@ func PostChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			}
 		} else {
 
-			execCommandDetails, err := CreateHelperPod(probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
+			execCommandDetails, err := CreateHelperPod(ctx, probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
 			if err != nil {
 				return err
 			}
 
 			// triggering the cmd probe and storing the output into the out buffer
-			err = TriggerSourceCmdProbe(probe, execCommandDetails, clients, resultDetails)
+			err = TriggerSourceCmdProbe(ctx, probe, execCommandDetails, clients, resultDetails)
 
 			// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 			// it will update the status of all the unrun probes as well
@@ -530,7 +577,7 @@ func PostChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 }
 
 //OnChaosCmdProbe trigger the cmd probe for DuringChaos phase
-func OnChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func OnChaosCmdProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	switch probe.Mode {
 	case "OnChaos":
@@ -546,16 +593,16 @@ func OnChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 			"Phase":          "DuringChaos",
 		})
 		if probe.CmdProbeInputs.Source == "inline" {
-			go TriggerInlineOnChaosCmdProbe(probe, resultDetails, chaosDetails.ChaosDuration)
+			go TriggerInlineOnChaosCmdProbe(ctx, probe, resultDetails, chaosDetails.ChaosDuration)
 		} else {
 
-			execCommandDetails, err := CreateHelperPod(probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
+			execCommandDetails, err := CreateHelperPod(ctx, probe, resultDetails, clients, chaosDetails, probe.CmdProbeInputs.Source)
 			if err != nil {
 				return err
 			}
 
 			// trigger the continuous cmd probe
-			go TriggerSourceOnChaosCmdProbe(probe, execCommandDetails, clients, resultDetails, chaosDetails.ChaosDuration)
+			go TriggerSourceOnChaosCmdProbe(ctx, probe, execCommandDetails, clients, resultDetails, chaosDetails.ChaosDuration)
 		}
 
 	}
@@ -564,7 +611,7 @@ func OnChaosCmdProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 
 // CreateHelperPod create the helper pod with the source image
 // it will be created if the mode is not inline
-func CreateHelperPod(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, sourceImage string) (litmusexec.PodDetails, error) {
+func CreateHelperPod(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails, sourceImage string) (litmusexec.PodDetails, error) {
 	// Generate the run_id
 	runID := GetRunID()
 	SetRunIDForProbe(resultDetails, probe.Name, probe.Type, runID)
@@ -577,7 +624,7 @@ func CreateHelperPod(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 
 	// verify the running status of external probe pod
 	log.Info("[Status]: Checking the status of the probe pod")
-	err = status.CheckApplicationStatus(chaosDetails.ChaosNamespace, "name="+chaosDetails.ExperimentName+"-probe-"+runID, chaosDetails.Timeout, chaosDetails.Delay, clients)
+	err = status.CheckApplicationStatus(ctx, chaosDetails.ChaosNamespace, "name="+chaosDetails.ExperimentName+"-probe-"+runID, chaosDetails.Timeout, chaosDetails.Delay, clients)
 	if err != nil {
 		return litmusexec.PodDetails{}, errors.Errorf("probe pod is not in running state, err: %v", err)
 	}
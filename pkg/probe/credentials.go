@@ -0,0 +1,96 @@
+package probe
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getValue returns the literal value or the value of the secret key, if the secret is referred
+// the secret is read from the chaos namespace
+func getValue(source types.ValueSource, clients clients.ClientSets, namespace string) (string, error) {
+	if source.SecretKeyRef == nil {
+		return source.Value, nil
+	}
+	data, err := getSecretData(source.SecretKeyRef.Name, clients, namespace)
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[source.SecretKeyRef.Key]
+	if !ok {
+		return "", errors.Errorf("%v key not found inside the %v secret", source.SecretKeyRef.Key, source.SecretKeyRef.Name)
+	}
+	return string(value), nil
+}
+
+// getSecretData returns the data of the secret
+func getSecretData(name string, clients clients.ClientSets, namespace string) (map[string][]byte, error) {
+	secret, err := clients.KubeClient.CoreV1().Secrets(namespace).Get(name, v1.GetOptions{})
+	if err != nil {
+		return nil, errors.Errorf("unable to get the %v secret, err: %v", name, err)
+	}
+	return secret.Data, nil
+}
+
+// getTLSConfig returns the tls config with the client certificates and the CA certificate
+// the certificates are read from the secret, if the secret name is provided, or else from the given paths
+func getTLSConfig(config types.TLSConfig, clients clients.ClientSets, namespace string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	var caCert, cert, key []byte
+	switch {
+	case config.SecretName != "":
+		data, err := getSecretData(config.SecretName, clients, namespace)
+		if err != nil {
+			return nil, err
+		}
+		caCert, cert, key = data["ca.crt"], data["tls.crt"], data["tls.key"]
+	default:
+		var err error
+		if caCert, err = readFile(config.CACertPath); err != nil {
+			return nil, err
+		}
+		if cert, err = readFile(config.CertPath); err != nil {
+			return nil, err
+		}
+		if key, err = readFile(config.KeyPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(caCert) != 0 {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("unable to parse the CA certificate")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	if len(cert) != 0 || len(key) != 0 {
+		certificate, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, errors.Errorf("unable to parse the client certificate, err: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// readFile returns the content of the file, it returns nil if the path is empty
+func readFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Errorf("unable to read the %v file, err: %v", path, err)
+	}
+	return data, nil
+}
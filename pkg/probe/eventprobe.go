@@ -0,0 +1,430 @@
+package probe
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the event probe lists the events of the given namespaces in every polling interval and fails on the unexpected events
+// the events, which are recorded (or repeated) after the start of the probe, are verified in the following order:
+// the events matching the deny list are unexpected, the events of the chaos targets & matching the allow list are expected
+// and rest of the events are unexpected, if their reason is one of the reasons (or they are Warning events, if reasons are empty)
+
+// eventMatcher verifies the events with the reasons, allow list & deny list of the event probe
+type eventMatcher struct {
+	namespaces []string
+	reasons    []string
+	allow      []eventFilter
+	deny       []eventFilter
+}
+
+// eventFilter matches the events with the kind & name of the involved object and the reason
+type eventFilter struct {
+	kind    string
+	name    *regexp.Regexp
+	reasons []string
+}
+
+// eventWindow contains the start time & the matcher of the running event probe
+// the targets are read from the chaos details on every check, as they are selected once the chaos starts
+type eventWindow struct {
+	since        time.Time
+	matcher      *eventMatcher
+	chaosDetails *types.ChaosDetails
+}
+
+// eventWindows contains the windows of the running event probes, keyed by the probe name
+var (
+	eventWindowsMu sync.Mutex
+	eventWindows   = map[string]eventWindow{}
+)
+
+// PrepareEventProbe contains the steps to prepare the event probe
+// event probe can be used to add the probe which will verify that no unexpected events are recorded during chaos
+func PrepareEventProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+
+	switch phase {
+	case "PreChaos":
+		if err := PreChaosEventProbe(ctx, probe, clients, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "PostChaos":
+		if err := PostChaosEventProbe(ctx, probe, clients, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "DuringChaos":
+		if err := OnChaosEventProbe(ctx, probe, clients, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("phase '%s' not supported in the event probe", phase)
+	}
+	return nil
+}
+
+// newEventMatcher returns the matcher of the event probe, the namespaces default to the application namespace
+// the namespaces are required, if the application namespace isn't provided, e.g. for the node & cloud experiments
+func newEventMatcher(inputs types.EventProbeInputs, chaosDetails *types.ChaosDetails) (*eventMatcher, error) {
+	matcher := &eventMatcher{
+		namespaces: inputs.Namespaces,
+		reasons:    inputs.Reasons,
+	}
+	if len(matcher.namespaces) == 0 {
+		namespace := chaosDetails.AppDetail.Namespace
+		if namespace == "" {
+			namespace = Getenv("APP_NAMESPACE", "")
+		}
+		if namespace == "" {
+			return nil, errors.Errorf("[Probe]: namespaces are required for the event probe, as the application namespace isn't provided")
+		}
+		matcher.namespaces = []string{namespace}
+	}
+	for _, namespace := range matcher.namespaces {
+		if namespace == "" {
+			return nil, errors.Errorf("[Probe]: empty namespace isn't supported in the event probe")
+		}
+	}
+
+	for _, filter := range inputs.AllowList {
+		allow, err := newEventFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		matcher.allow = append(matcher.allow, allow)
+	}
+	for _, filter := range inputs.DenyList {
+		deny, err := newEventFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		matcher.deny = append(matcher.deny, deny)
+	}
+	return matcher, nil
+}
+
+// newEventFilter compiles the name regex of the filter, it must match the entire name of the involved object
+func newEventFilter(filter types.EventFilter) (eventFilter, error) {
+	result := eventFilter{kind: filter.Kind, reasons: filter.Reasons}
+	if filter.Name != "" {
+		re, err := regexp.Compile("^(?:" + filter.Name + ")$")
+		if err != nil {
+			return result, errors.Errorf("the name regex '%s' is not a valid expression", filter.Name)
+		}
+		result.name = re
+	}
+	return result, nil
+}
+
+// matches checks whether the event matches the kind, name & reasons of the filter
+func (f eventFilter) matches(event corev1.Event) bool {
+	if f.kind != "" && !strings.EqualFold(f.kind, event.InvolvedObject.Kind) {
+		return false
+	}
+	if f.name != nil && !f.name.MatchString(event.InvolvedObject.Name) {
+		return false
+	}
+	return len(f.reasons) == 0 || containsReason(f.reasons, event.Reason)
+}
+
+// unexpected checks whether the event is unexpected
+func (m *eventMatcher) unexpected(event corev1.Event, targets []types.TargetDetails) bool {
+	for _, filter := range m.deny {
+		if filter.matches(event) {
+			return true
+		}
+	}
+	if targeted(event, targets) {
+		return false
+	}
+	for _, filter := range m.allow {
+		if filter.matches(event) {
+			return false
+		}
+	}
+	if len(m.reasons) == 0 {
+		return event.Type == corev1.EventTypeWarning
+	}
+	return containsReason(m.reasons, event.Reason)
+}
+
+// targeted checks whether the involved object of the event is one of the chaos targets
+func targeted(event corev1.Event, targets []types.TargetDetails) bool {
+	for _, target := range targets {
+		if strings.EqualFold(target.Kind, event.InvolvedObject.Kind) && target.Name == event.InvolvedObject.Name &&
+			(target.Namespace == "" || target.Namespace == event.InvolvedObject.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsReason checks whether the reason is present inside the reasons
+func containsReason(reasons []string, reason string) bool {
+	for _, r := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// eventTimestamp returns the time of the last occurrence of the event
+func eventTimestamp(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// TriggerEventProbe lists the events of the namespaces, which are recorded since the start of the probe
+// it fails, if any of them is unexpected
+func TriggerEventProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, window eventWindow) error {
+
+	// the timestamps of the events are in seconds
+	since := window.since.Truncate(time.Second)
+	targets := common.GetTargets(window.chaosDetails)
+
+	// it will retry for some retry count, in each iterations of try it contains following things
+	// it lists the events of all the namespaces and waits for the interval, if the api call fails
+	// the unexpected events are collected per iteration and handed back only by the succeeded iteration
+	result, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
+			var unexpected []string
+			for _, namespace := range window.matcher.namespaces {
+				events, err := clients.KubeClient.CoreV1().Events(namespace).List(v1.ListOptions{})
+				if err != nil {
+					return nil, errors.Errorf("unable to list the events in %v namespace, err: %v", namespace, err)
+				}
+				for _, event := range events.Items {
+					if eventTimestamp(event).Before(since) || !window.matcher.unexpected(event, targets) {
+						continue
+					}
+					unexpected = append(unexpected, event.InvolvedObject.Kind+"/"+event.InvolvedObject.Name+" in "+namespace+
+						" namespace, reason: "+event.Reason+", message: "+strings.TrimSpace(event.Message))
+				}
+			}
+			return unexpected, nil
+		})
+	if err != nil {
+		return err
+	}
+	unexpected := result.([]string)
+
+	setProbeArtifact(resultDetails, probe.Name, strconv.Itoa(len(unexpected)))
+
+	if len(unexpected) != 0 {
+		return errors.Errorf("unexpected events found: [%v]", strings.Join(unexpected, "; "))
+	}
+	return nil
+}
+
+// TriggerContinuousEventProbe trigger the continuous event probes
+func TriggerContinuousEventProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, window eventWindow) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+	}
+
+	// it trigger the event probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		err = TriggerEventProbe(ctx, probe, clients, chaosresult, window)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+					log.Errorf("The %v event probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
+					break loop
+				}
+			}
+		}
+		// waiting for the probe polling interval
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
+	}
+}
+
+// startEventProbe returns the window of the event probe and records it for the postchaos phase
+// the window starts after the initial delay, so the events before the initial delay are skipped
+func startEventProbe(probe types.ProbeAttributes, chaosDetails *types.ChaosDetails) (eventWindow, error) {
+	matcher, err := newEventMatcher(probe.EventProbeInputs, chaosDetails)
+	if err != nil {
+		return eventWindow{}, err
+	}
+	window := eventWindow{
+		since:        time.Now().Add(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second),
+		matcher:      matcher,
+		chaosDetails: chaosDetails,
+	}
+
+	eventWindowsMu.Lock()
+	defer eventWindowsMu.Unlock()
+	eventWindows[probe.Name] = window
+	return window, nil
+}
+
+// takeEventWindow returns the window of the event probe and removes it from the running event probes
+func takeEventWindow(probeName string) (eventWindow, bool) {
+	eventWindowsMu.Lock()
+	defer eventWindowsMu.Unlock()
+	window, ok := eventWindows[probeName]
+	delete(eventWindows, probeName)
+	return window, ok
+}
+
+//PreChaosEventProbe trigger the event probe for prechaos phase
+func PreChaosEventProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "SOT", "EOT", "Edge":
+		err = errors.Errorf("[Probe]: mode '%v' not supported in the %v event probe, it supports Continuous & OnChaos modes", probe.Mode, probe.Name)
+		return MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PreChaos")
+	case "Continuous":
+
+		//DISPLAY THE EVENT PROBE INFO
+		log.InfoWithValues("[Probe]: The event probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespaces":     probe.EventProbeInputs.Namespaces,
+			"Reasons":        probe.EventProbeInputs.Reasons,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		window, err := startEventProbe(probe, chaosDetails)
+		if err != nil {
+			return MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PreChaos")
+		}
+		go TriggerContinuousEventProbe(ctx, probe, clients, resultDetails, window)
+	}
+	return nil
+}
+
+//PostChaosEventProbe trigger the event probe for postchaos phase
+func PostChaosEventProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err = CheckForErrorInContinuousProbe(resultDetails, probe.Name)
+		window, ok := takeEventWindow(probe.Name)
+		switch {
+		case err != nil:
+		case !ok:
+			err = errors.Errorf("[Probe]: the %v event probe hasn't been started", probe.Name)
+		case probe.Mode == "Continuous":
+			// verifying the events recorded after the last polling interval of the continuous probe
+			err = TriggerEventProbe(ctx, probe, clients, resultDetails, window)
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TriggerOnChaosEventProbe trigger the onchaos event probes
+func TriggerOnChaosEventProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, window eventWindow, duration int) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the event probe for the entire duration of chaos and it fails, if any error encounter
+	// the events are verified once more at the end of the chaos duration, so the window covers the entire duration
+	// it marked the error for the probes, if any
+	timeUp := false
+loop:
+	for {
+		err = TriggerEventProbe(ctx, probe, clients, chaosresult, window)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+					haltOnFailure(ctx, probe)
+					break loop
+				}
+			}
+		}
+		if timeUp {
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			break loop
+		}
+
+		// waiting for the probe polling interval or the end of the chaos duration
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-endTime:
+			timeUp = true
+		case <-time.After(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second):
+		}
+	}
+}
+
+//OnChaosEventProbe trigger the event probe for DuringChaos phase
+func OnChaosEventProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE EVENT PROBE INFO
+		log.InfoWithValues("[Probe]: The event probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Namespaces":     probe.EventProbeInputs.Namespaces,
+			"Reasons":        probe.EventProbeInputs.Reasons,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+
+		window, err := startEventProbe(probe, chaosDetails)
+		if err != nil {
+			return MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "DuringChaos")
+		}
+		go TriggerOnChaosEventProbe(ctx, probe, clients, resultDetails, window, chaosDetails.ChaosDuration)
+	}
+	return nil
+}
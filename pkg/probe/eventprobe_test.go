@@ -0,0 +1,137 @@
+package probe
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newEvent(name, namespace, kind, object, eventType, reason string, timestamp time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     v1.ObjectMeta{Name: name, Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object, Namespace: namespace},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        reason + " of " + object,
+		LastTimestamp:  v1.NewTime(timestamp),
+	}
+}
+
+func TestTriggerEventProbe(t *testing.T) {
+	start := time.Now()
+	before := start.Add(-time.Hour)
+	after := start.Add(time.Minute)
+
+	fakeClients := clients.NewFakeClientSets([]runtime.Object{
+		// the events before the window are ignored
+		newEvent("e1", "shop", "Pod", "payment-7d9f-x2k", corev1.EventTypeWarning, "OOMKilling", before),
+		// the events of the target pods are expected
+		newEvent("e2", "shop", "Pod", "checkout-5c6b-abcd", corev1.EventTypeWarning, "BackOff", after),
+		newEvent("e3", "shop", "Pod", "checkout-5c6b-abcd", corev1.EventTypeNormal, "Killing", after),
+		newEvent("e4", "infra", "Node", "node-1", corev1.EventTypeNormal, "NodeReady", after),
+	}, nil, nil)
+
+	tests := []struct {
+		name       string
+		inputs     types.EventProbeInputs
+		targets    []types.TargetDetails
+		unexpected string
+	}{
+		{
+			name: "allowed target pods",
+			inputs: types.EventProbeInputs{
+				Namespaces: []string{"shop", "infra"},
+				AllowList:  []types.EventFilter{{Kind: "Pod", Name: "checkout-.*"}},
+			},
+		},
+		{
+			name: "unexpected warning events",
+			inputs: types.EventProbeInputs{
+				Namespaces: []string{"shop"},
+			},
+			unexpected: "Pod/checkout-5c6b-abcd in shop namespace, reason: BackOff",
+		},
+		{
+			name: "events of the chaos targets",
+			inputs: types.EventProbeInputs{
+				Namespaces: []string{"shop"},
+			},
+			targets: []types.TargetDetails{{Kind: "pod", Name: "checkout-5c6b-abcd", Namespace: "shop"}},
+		},
+		{
+			name: "reasons other than the unexpected reasons",
+			inputs: types.EventProbeInputs{
+				Namespaces: []string{"shop", "infra"},
+				Reasons:    []string{"OOMKilling", "FailedScheduling"},
+			},
+		},
+		{
+			name: "deny list takes precedence over the allow list",
+			inputs: types.EventProbeInputs{
+				Namespaces: []string{"shop", "infra"},
+				AllowList:  []types.EventFilter{{Kind: "Pod", Name: "checkout-.*"}},
+				DenyList:   []types.EventFilter{{Kind: "node", Reasons: []string{"NodeReady", "NodeNotReady"}}},
+			},
+			unexpected: "Node/node-1 in infra namespace, reason: NodeReady",
+		},
+		{
+			name: "name regex matches the entire name",
+			inputs: types.EventProbeInputs{
+				Namespaces: []string{"shop"},
+				AllowList:  []types.EventFilter{{Name: "checkout"}},
+			},
+			unexpected: "reason: BackOff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := types.ProbeAttributes{EventProbeInputs: tt.inputs}
+			probe.Name = "collateral-damage"
+			probe.Type = "eventProbe"
+			probe.RunProperties.ProbeTimeout = 2
+			resultDetails := &types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{}}
+
+			window, err := startEventProbe(probe, &types.ChaosDetails{Targets: tt.targets})
+			if err != nil {
+				t.Fatalf("unable to start the event probe: %v", err)
+			}
+			takeEventWindow(probe.Name)
+			window.since = start
+
+			err = TriggerEventProbe(context.Background(), probe, fakeClients, resultDetails, window)
+			switch {
+			case tt.unexpected == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.unexpected != "" && (err == nil || !strings.Contains(err.Error(), tt.unexpected)):
+				t.Errorf("expected the %q event, got %v", tt.unexpected, err)
+			}
+		})
+	}
+}
+
+func TestEventProbeNamespaces(t *testing.T) {
+	probe := types.ProbeAttributes{}
+	probe.Name = "collateral-damage"
+
+	// the namespaces are required, if the application namespace isn't provided
+	if _, err := startEventProbe(probe, &types.ChaosDetails{}); err == nil {
+		t.Fatal("expected error for the missing namespaces")
+	}
+
+	window, err := startEventProbe(probe, &types.ChaosDetails{AppDetail: types.AppDetails{Namespace: "shop"}})
+	if err != nil {
+		t.Fatalf("unable to start the event probe: %v", err)
+	}
+	takeEventWindow(probe.Name)
+	if len(window.matcher.namespaces) != 1 || window.matcher.namespaces[0] != "shop" {
+		t.Errorf("expected the application namespace, got %v", window.matcher.namespaces)
+	}
+}
@@ -0,0 +1,343 @@
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// PrepareGRPCProbe contains the steps to prepare the grpc probe
+// grpc probe can be used to add the probe which will call the standard grpc.health.v1.Health/Check endpoint and match the serving status
+func PrepareGRPCProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+
+	switch phase {
+	case "PreChaos":
+		if err := PreChaosGRPCProbe(ctx, probe, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "PostChaos":
+		if err := PostChaosGRPCProbe(ctx, probe, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "DuringChaos":
+		OnChaosGRPCProbe(ctx, probe, resultDetails, chaosDetails)
+	default:
+		return errors.Errorf("phase '%s' not supported in the grpc probe", phase)
+	}
+	return nil
+}
+
+// TriggerGRPCProbe run the grpc probe
+func TriggerGRPCProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails) error {
+	var err error
+
+	inputs := probe.GRPCProbeInputs
+
+	// It parse the templated endpoint & service and return normal string
+	// if they don't have template, it will return the same string
+	if inputs.Endpoint, err = ParseCommand(inputs.Endpoint, resultDetails); err != nil {
+		return err
+	}
+	if inputs.Service, err = ParseCommand(inputs.Service, resultDetails); err != nil {
+		return err
+	}
+	if inputs.Endpoint == "" {
+		return errors.Errorf("[Probe]: endpoint is required for the %v grpc probe", probe.Name)
+	}
+
+	expectedStatus := inputs.ExpectedStatus
+	if expectedStatus == "" {
+		expectedStatus = healthpb.HealthCheckResponse_SERVING.String()
+	}
+	if _, ok := healthpb.HealthCheckResponse_ServingStatus_value[expectedStatus]; !ok {
+		return errors.Errorf("[Probe]: unsupported expectedStatus '%v' in the %v grpc probe", expectedStatus, probe.Name)
+	}
+
+	dialOption, err := grpcTransportOption(inputs)
+	if err != nil {
+		return err
+	}
+
+	log.InfoWithValues("[Probe]: GRPC health check informations", logrus.Fields{
+		"Name":            probe.Name,
+		"Endpoint":        inputs.Endpoint,
+		"Service":         inputs.Service,
+		"ExpectedStatus":  expectedStatus,
+		"ResponseTimeout": inputs.ResponseTimeout,
+	})
+
+	// it will retry for some retry count, in each iterations of try it contains following things
+	// each iteration of retry is bounded by the probe timeout, the health check is cancelled once the timeout expires
+	// if it fails, it will wait for the interval and call the health check again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
+			status, err := grpcHealthCheck(ctx, inputs, dialOption)
+			if err != nil {
+				return nil, err
+			}
+
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			// comparing the serving status with the expected status
+			if status != expectedStatus {
+				err := errors.Errorf("{actual value: %v} is not equal to {expected value: %v}, run count: %v", status, expectedStatus, rc)
+				log.Errorf("The %v grpc probe has been Failed, err: %v", probe.Name, err)
+				return nil, err
+			}
+
+			return status, nil
+		})
+	if err != nil {
+		return err
+	}
+
+	// storing the output, so that the other probes can use it
+	setProbeArtifact(resultDetails, probe.Name, register.(string))
+	return nil
+}
+
+// grpcHealthCheck calls the grpc.health.v1.Health/Check endpoint and returns the serving status
+func grpcHealthCheck(ctx context.Context, inputs types.GRPCProbeInputs, dialOption grpc.DialOption) (string, error) {
+	if inputs.ResponseTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(inputs.ResponseTimeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	conn, err := grpc.DialContext(ctx, inputs.Endpoint, dialOption, grpc.WithBlock())
+	if err != nil {
+		return "", errors.Errorf("unable to connect to %v, err: %v", inputs.Endpoint, err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: inputs.Service})
+	if err != nil {
+		return "", errors.Errorf("unable to check the health of %v, err: %v", inputs.Endpoint, err)
+	}
+	return resp.GetStatus().String(), nil
+}
+
+// grpcTransportOption returns the transport credentials of the grpc connection
+// it uses the plaintext connection, if insecure is enabled
+func grpcTransportOption(inputs types.GRPCProbeInputs) (grpc.DialOption, error) {
+	if inputs.Insecure {
+		return grpc.WithInsecure(), nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         inputs.ServerName,
+		InsecureSkipVerify: inputs.InsecureSkipVerify,
+	}
+	if inputs.CACertPath != "" {
+		caCert, err := ioutil.ReadFile(inputs.CACertPath)
+		if err != nil {
+			return nil, errors.Errorf("unable to read the CA certificate, err: %v", err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("unable to parse the CA certificate from %v", inputs.CACertPath)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
+
+// TriggerContinuousGRPCProbe trigger the continuous grpc probes
+func TriggerContinuousGRPCProbe(ctx context.Context, probe types.ProbeAttributes, chaosresult *types.ResultDetails) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+	}
+
+	// it trigger the grpc probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		err = TriggerGRPCProbe(ctx, probe, chaosresult)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+					log.Errorf("The %v grpc probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
+					break loop
+				}
+			}
+		}
+		// waiting for the probe polling interval
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
+	}
+}
+
+//PreChaosGRPCProbe trigger the grpc probe for prechaos phase
+func PreChaosGRPCProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "SOT", "Edge":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.GRPCProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
+		}
+		// trigger the grpc probe
+		err = TriggerGRPCProbe(ctx, probe, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PreChaos"); err != nil {
+			return err
+		}
+	case "Continuous":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.GRPCProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+		go TriggerContinuousGRPCProbe(ctx, probe, resultDetails)
+	}
+	return nil
+}
+
+//PostChaosGRPCProbe trigger the grpc probe for postchaos phase
+func PostChaosGRPCProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "EOT", "Edge":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.GRPCProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
+		}
+
+		// trigger the grpc probe
+		err = TriggerGRPCProbe(ctx, probe, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err = CheckForErrorInContinuousProbe(resultDetails, probe.Name)
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TriggerOnChaosGRPCProbe trigger the onchaos grpc probes
+func TriggerOnChaosGRPCProbe(ctx context.Context, probe types.ProbeAttributes, chaosresult *types.ResultDetails, duration int) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the grpc probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			break loop
+		default:
+			err = TriggerGRPCProbe(ctx, probe, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+						haltOnFailure(ctx, probe)
+						break loop
+					}
+				}
+			}
+
+			// waiting for the probe polling interval
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
+		}
+	}
+}
+
+//OnChaosGRPCProbe trigger the grpc probe for DuringChaos phase
+func OnChaosGRPCProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE GRPC PROBE INFO
+		log.InfoWithValues("[Probe]: The grpc probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.GRPCProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go TriggerOnChaosGRPCProbe(ctx, probe, resultDetails, chaosDetails.ChaosDuration)
+	}
+}
@@ -0,0 +1,92 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startHealthServer starts a grpc server with the standard health service on a random local port
+func startHealthServer(t *testing.T) (*health.Server, string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	return healthServer, listener.Addr().String(), server.Stop
+}
+
+func TestTriggerGRPCProbe(t *testing.T) {
+	healthServer, endpoint, stop := startHealthServer(t)
+	defer stop()
+	healthServer.SetServingStatus("cart", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("checkout", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	tests := []struct {
+		name           string
+		service        string
+		expectedStatus string
+		wantErr        bool
+	}{
+		{name: "overall health", service: ""},
+		{name: "serving service", service: "cart"},
+		{name: "not serving service", service: "checkout", wantErr: true},
+		{name: "expected not serving", service: "checkout", expectedStatus: "NOT_SERVING"},
+		{name: "unknown service", service: "payment", wantErr: true},
+		{name: "unsupported expected status", service: "cart", expectedStatus: "HEALTHY", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := types.ProbeAttributes{
+				GRPCProbeInputs: types.GRPCProbeInputs{
+					Endpoint:        endpoint,
+					Service:         tt.service,
+					Insecure:        true,
+					ExpectedStatus:  tt.expectedStatus,
+					ResponseTimeout: 2000,
+				},
+			}
+			probe.Name = "check-" + tt.service
+			probe.Type = "grpcProbe"
+			probe.RunProperties.ProbeTimeout = 5
+
+			resultDetails := &types.ResultDetails{
+				ProbeDetails:   []types.ProbeDetails{{Name: probe.Name, Type: probe.Type}},
+				ProbeArtifacts: map[string]types.ProbeArtifact{},
+			}
+			err := TriggerGRPCProbe(context.Background(), probe, resultDetails)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("expected error: %v, found %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && resultDetails.ProbeArtifacts[probe.Name].ProbeArtifacts.Register == "" {
+				t.Errorf("expected the serving status inside the probe artifacts")
+			}
+		})
+	}
+}
+
+func TestTriggerGRPCProbeUnreachable(t *testing.T) {
+	_, endpoint, stop := startHealthServer(t)
+	stop()
+
+	probe := types.ProbeAttributes{
+		GRPCProbeInputs: types.GRPCProbeInputs{Endpoint: endpoint, Insecure: true, ResponseTimeout: 200},
+	}
+	probe.Name = "check-server"
+	probe.RunProperties.ProbeTimeout = 1
+	probe.RunProperties.Retry = 1
+
+	resultDetails := &types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{}}
+	if err := TriggerGRPCProbe(context.Background(), probe, resultDetails); err == nil {
+		t.Fatal("expected the probe to fail for the unreachable server")
+	}
+}
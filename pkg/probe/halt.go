@@ -0,0 +1,61 @@
+package probe
+
+import (
+	"context"
+	"sync"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// the continuous & onchaos probes with stopOnFailure halts the experiment, as soon as they fail
+// the experiment context is cancelled, so that the chaoslib stops & reverts the chaos immediately
+// instead of running the chaos for the entire chaos duration, and the experiment is marked as failed
+
+// haltKey is the context key of the halter
+type haltKey struct{}
+
+// halter cancels the experiment context and records the probe, which halted the experiment
+type halter struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	probe  string
+}
+
+// WithHalt returns a copy of the parent context, which is cancelled once a probe with stopOnFailure fails
+func WithHalt(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	return context.WithValue(ctx, haltKey{}, &halter{cancel: cancel}), cancel
+}
+
+// HaltedBy returns the name of the probe, which halted the experiment
+// it returns empty string, if the experiment is not halted by any probe
+func HaltedBy(ctx context.Context) string {
+	h, ok := ctx.Value(haltKey{}).(*halter)
+	if !ok {
+		return ""
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.probe
+}
+
+// haltOnFailure halts the experiment, if the failed probe has stopOnFailure enabled
+// the experiment is not halted, if it is already stopped (abort signal or another probe)
+func haltOnFailure(ctx context.Context, probe types.ProbeAttributes) {
+	if !probe.RunProperties.StopOnFailure {
+		return
+	}
+	h, ok := ctx.Value(haltKey{}).(*halter)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.probe != "" || ctx.Err() != nil {
+		return
+	}
+	log.Errorf("[Halt]: The %v probe has been Failed, stopping the chaos as the stopOnFailure is enabled", probe.Name)
+	h.probe = probe.Name
+	h.cancel()
+}
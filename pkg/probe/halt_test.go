@@ -0,0 +1,49 @@
+package probe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+func newProbe(name string, stopOnFailure bool) types.ProbeAttributes {
+	probe := types.ProbeAttributes{}
+	probe.Name = name
+	probe.RunProperties.StopOnFailure = stopOnFailure
+	return probe
+}
+
+func TestHaltOnFailure(t *testing.T) {
+	ctx, cancel := WithHalt(context.Background())
+	defer cancel()
+
+	// the probes without stopOnFailure doesn't halt the experiment
+	haltOnFailure(ctx, newProbe("check-logs", false))
+	if ctx.Err() != nil || HaltedBy(ctx) != "" {
+		t.Fatalf("expected the experiment to keep running")
+	}
+
+	haltOnFailure(ctx, newProbe("check-frontend", true))
+	if ctx.Err() == nil {
+		t.Fatalf("expected the experiment context to be cancelled")
+	}
+	// the first failed probe is recorded
+	haltOnFailure(ctx, newProbe("check-backend", true))
+	if probeName := HaltedBy(ctx); probeName != "check-frontend" {
+		t.Fatalf("expected the experiment to be halted by check-frontend probe, found %q", probeName)
+	}
+}
+
+func TestHaltOnFailureAfterAbort(t *testing.T) {
+	parent, abort := context.WithCancel(context.Background())
+	ctx, cancel := WithHalt(parent)
+	defer cancel()
+
+	// the aborted experiment is not marked as halted by the probes, which fails because of the abort
+	abort()
+	haltOnFailure(ctx, newProbe("check-frontend", true))
+	if probeName := HaltedBy(ctx); probeName != "" {
+		t.Fatalf("expected the aborted experiment not to be halted, found %q", probeName)
+	}
+}
@@ -2,14 +2,18 @@ package probe
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os/exec"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
-	"crypto/tls"
 	"net/http"
 
 	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
@@ -18,26 +22,28 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 // PrepareHTTPProbe contains the steps to prepare the http probe
 // http probe can be used to add the probe which will send a request to given url and match the status code
-func PrepareHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+func PrepareHTTPProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
 
 	switch phase {
 	case "PreChaos":
-		if err := PreChaosHTTPProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PreChaosHTTPProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "PostChaos":
-		if err := PostChaosHTTPProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PostChaosHTTPProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "DuringChaos":
-		OnChaosHTTPProbe(probe, resultDetails, clients, chaosDetails)
+		OnChaosHTTPProbe(ctx, probe, resultDetails, clients, chaosDetails)
 	default:
 		return errors.Errorf("phase '%s' not supported in the http probe", phase)
 	}
@@ -45,7 +51,8 @@ func PrepareHTTPProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets
 }
 
 // TriggerHTTPProbe run the http probe command
-func TriggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+func TriggerHTTPProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+	var err error
 
 	// It parse the templated url and return normal string
 	// if command doesn't have template, it will return the same command
@@ -54,70 +61,162 @@ func TriggerHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		return err
 	}
 
-	// it fetch the http method type
-	method := getHTTPMethodType(probe.HTTPProbeInputs.Method)
+	// it fetch the http method details
+	method, err := getHTTPMethod(probe.HTTPProbeInputs.Method)
+	if err != nil {
+		return err
+	}
 
-	// initialize simple http client with default attributes
-	timeout := time.Duration(probe.HTTPProbeInputs.ResponseTimeout) * time.Millisecond
-	client := &http.Client{Timeout: timeout}
-	// impose properties to http client with cert check disabled
-	if probe.HTTPProbeInputs.InsecureSkipVerify {
-		transCfg := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		client = &http.Client{Transport: transCfg, Timeout: timeout}
+	// initialize the http client with the tls config, if provided
+	client, err := getHTTPClient(probe.HTTPProbeInputs, clients, chaosDetails.ChaosNamespace)
+	if err != nil {
+		return err
 	}
 
-	switch method {
-	case "Get":
-		log.InfoWithValues("[Probe]: HTTP get method informations", logrus.Fields{
-			"Name":            probe.Name,
-			"URL":             probe.HTTPProbeInputs.URL,
-			"Criteria":        probe.HTTPProbeInputs.Method.Get.Criteria,
-			"ResponseCode":    probe.HTTPProbeInputs.Method.Get.ResponseCode,
-			"ResponseTimeout": probe.HTTPProbeInputs.ResponseTimeout,
-		})
-		if err := httpGet(probe, client, resultDetails); err != nil {
-			return err
-		}
-	case "Post":
-		log.InfoWithValues("[Probe]: HTTP Post method informations", logrus.Fields{
-			"Name":            probe.Name,
-			"URL":             probe.HTTPProbeInputs.URL,
-			"Body":            probe.HTTPProbeInputs.Method.Post.Body,
-			"BodyPath":        probe.HTTPProbeInputs.Method.Post.BodyPath,
-			"ContentType":     probe.HTTPProbeInputs.Method.Post.ContentType,
-			"ResponseTimeout": probe.HTTPProbeInputs.ResponseTimeout,
-		})
-		if err := httpPost(probe, client, resultDetails); err != nil {
-			return err
-		}
+	// it fetch the request headers along with the auth header
+	headers, err := getHTTPHeaders(probe.HTTPProbeInputs, clients, chaosDetails.ChaosNamespace)
+	if err != nil {
+		return err
 	}
-	return nil
+
+	log.InfoWithValues("[Probe]: HTTP "+method.name+" method informations", logrus.Fields{
+		"Name":            probe.Name,
+		"URL":             probe.HTTPProbeInputs.URL,
+		"Criteria":        method.criteria,
+		"ResponseCode":    method.responseCode,
+		"Body":            method.body,
+		"ContentType":     method.contentType,
+		"ResponseTimeout": probe.HTTPProbeInputs.ResponseTimeout,
+	})
+	return httpRequest(ctx, probe, client, method, headers, resultDetails)
+}
+
+// httpMethod contains the details of the http request and the expected response code of the method
+type httpMethod struct {
+	name         string
+	criteria     string
+	responseCode string
+	contentType  string
+	body         string
 }
 
-// it fetch the http method type
-// it supports Get and Post methods
-func getHTTPMethodType(httpMethod v1alpha1.HTTPMethod) string {
-	if !reflect.DeepEqual(httpMethod.Get, v1alpha1.GetMethod{}) {
-		return "Get"
+// getHTTPMethod fetch the http method details
+// it supports get, post, put, patch, delete and head methods
+func getHTTPMethod(method types.HTTPMethod) (httpMethod, error) {
+	switch {
+	case !reflect.DeepEqual(method.Get, v1alpha1.GetMethod{}):
+		return httpMethod{name: http.MethodGet, criteria: method.Get.Criteria, responseCode: method.Get.ResponseCode}, nil
+	case !reflect.DeepEqual(method.Delete, v1alpha1.GetMethod{}):
+		return httpMethod{name: http.MethodDelete, criteria: method.Delete.Criteria, responseCode: method.Delete.ResponseCode}, nil
+	case !reflect.DeepEqual(method.Head, v1alpha1.GetMethod{}):
+		return httpMethod{name: http.MethodHead, criteria: method.Head.Criteria, responseCode: method.Head.ResponseCode}, nil
+	case !reflect.DeepEqual(method.Put, v1alpha1.PostMethod{}):
+		return getHTTPMethodWithBody(http.MethodPut, method.Put)
+	case !reflect.DeepEqual(method.Patch, v1alpha1.PostMethod{}):
+		return getHTTPMethodWithBody(http.MethodPatch, method.Patch)
+	default:
+		return getHTTPMethodWithBody(http.MethodPost, method.Post)
 	}
-	return "Post"
 }
 
-// httpGet send the http Get request to the given URL and verify the response code to follow the specified criteria
-func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails *types.ResultDetails) error {
+// getHTTPMethodWithBody fetch the details of the http method, which contains the request body
+func getHTTPMethodWithBody(name string, method v1alpha1.PostMethod) (httpMethod, error) {
+	body, err := getHTTPBody(method)
+	if err != nil {
+		return httpMethod{}, err
+	}
+	return httpMethod{
+		name:         name,
+		criteria:     method.Criteria,
+		responseCode: method.ResponseCode,
+		contentType:  method.ContentType,
+		body:         body,
+	}, nil
+}
+
+// getHTTPClient returns the http client with the response timeout and the tls config, if provided
+func getHTTPClient(inputs types.HTTPProbeInputs, clients clients.ClientSets, namespace string) (*http.Client, error) {
+	timeout := time.Duration(inputs.ResponseTimeout) * time.Millisecond
+
+	tlsInputs := inputs.TLS
+	tlsInputs.InsecureSkipVerify = tlsInputs.InsecureSkipVerify || inputs.InsecureSkipVerify
+	if reflect.DeepEqual(tlsInputs, types.TLSConfig{}) {
+		return &http.Client{Timeout: timeout}, nil
+	}
+
+	// impose properties to http client with the certificates or with cert check disabled
+	tlsConfig, err := getTLSConfig(tlsInputs, clients, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}, Timeout: timeout}, nil
+}
+
+// getHTTPHeaders returns the request headers along with the auth header
+// the values of the headers, bearer token and basic auth credentials can be sourced from the secrets
+func getHTTPHeaders(inputs types.HTTPProbeInputs, clients clients.ClientSets, namespace string) (http.Header, error) {
+	headers := http.Header{}
+	for _, header := range inputs.Headers {
+		value, err := getValue(header.ValueSource, clients, namespace)
+		if err != nil {
+			return nil, err
+		}
+		headers.Add(header.Name, value)
+	}
+
+	switch {
+	case inputs.Auth.BearerToken != nil:
+		token, err := getValue(*inputs.Auth.BearerToken, clients, namespace)
+		if err != nil {
+			return nil, err
+		}
+		headers.Set("Authorization", "Bearer "+strings.TrimSpace(token))
+	case inputs.Auth.BasicAuth != nil:
+		username, err := getValue(inputs.Auth.BasicAuth.Username, clients, namespace)
+		if err != nil {
+			return nil, err
+		}
+		password, err := getValue(inputs.Auth.BasicAuth.Password, clients, namespace)
+		if err != nil {
+			return nil, err
+		}
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	}
+	return headers, nil
+}
+
+// httpRequest send the http request to the given URL and verify the response code to follow the specified criteria
+// it also verify the response body and the jsonpath fields, if provided
+func httpRequest(ctx context.Context, probe types.ProbeAttributes, client *http.Client, method httpMethod, headers http.Header, resultDetails *types.ResultDetails) error {
 	// it will retry for some retry count, in each iterations of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
+	// each iteration of retry is bounded by the probe timeout, the request is cancelled once the timeout expires
+	// if it fails, it will wait for the interval and send the request again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (result interface{}, err error) {
+			req, err := http.NewRequest(method.name, probe.HTTPProbeInputs.URL, strings.NewReader(method.body))
+			if err != nil {
+				return nil, retry.Permanent(err)
+			}
+			for name, values := range headers {
+				req.Header[name] = values
+			}
+			if method.contentType != "" {
+				req.Header.Set("Content-Type", method.contentType)
+			}
+
+			// recording the latency & the outcome of the request
+			start := time.Now()
+			defer func() { recordLatency(ctx, probe.Name, start, err != nil) }()
+
 			// getting the response from the given url
-			resp, err := client.Get(probe.HTTPProbeInputs.URL)
+			resp, err := client.Do(req.WithContext(ctx))
 			if err != nil {
-				return err
+				return nil, err
+			}
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxHTTPBodySize))
+			if err != nil {
+				return nil, errors.Errorf("unable to read the response body, err: %v", err)
 			}
 
 			code := strconv.Itoa(resp.StatusCode)
@@ -126,47 +225,88 @@ func httpGet(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails
 			// comparing the response code with the expected criteria
 			if err = cmp.RunCount(rc).
 				FirstValue(code).
-				SecondValue(probe.HTTPProbeInputs.Method.Get.ResponseCode).
-				Criteria(probe.HTTPProbeInputs.Method.Get.Criteria).
+				SecondValue(method.responseCode).
+				Criteria(method.criteria).
 				CompareInt(); err != nil {
-				log.Errorf("The %v http probe get method has Failed, err: %v", probe.Name, err)
-				return err
+				log.Errorf("The %v http probe %v method has Failed, err: %v", probe.Name, strings.ToLower(method.name), err)
+				return nil, err
+			}
+
+			// comparing the response body with the expected criteria
+			if err = validateHTTPBody(probe.HTTPProbeInputs, string(body), rc); err != nil {
+				log.Errorf("The %v http probe %v method has Failed, err: %v", probe.Name, strings.ToLower(method.name), err)
+				return nil, err
 			}
-			return nil
-		})
-}
 
-// httpPost send the http post request to the given URL
-func httpPost(probe v1alpha1.ProbeAttributes, client *http.Client, resultDetails *types.ResultDetails) error {
-	body, err := getHTTPBody(probe.HTTPProbeInputs.Method.Post)
+			// the response body is stored, once the iteration succeeds, so that the other probes can use it
+			return strings.TrimSpace(string(body)), nil
+		})
 	if err != nil {
 		return err
 	}
-	// it will retry for some retry count, in each iterations of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
-			resp, err := client.Post(probe.HTTPProbeInputs.URL, probe.HTTPProbeInputs.Method.Post.ContentType, strings.NewReader(body))
-			if err != nil {
-				return err
-			}
-			code := strconv.Itoa(resp.StatusCode)
-			rc := getAndIncrementRunCount(resultDetails, probe.Name)
 
-			// comparing the response code with the expected criteria
-			if err = cmp.RunCount(rc).
-				FirstValue(code).
-				SecondValue(probe.HTTPProbeInputs.Method.Post.ResponseCode).
-				Criteria(probe.HTTPProbeInputs.Method.Post.Criteria).
-				CompareInt(); err != nil {
-				log.Errorf("The %v http probe post method has Failed, err: %v", probe.Name, err)
-				return err
-			}
-			return nil
-		})
+	setProbeArtifact(resultDetails, probe.Name, register.(string))
+	return nil
+}
+
+// maxHTTPBodySize is the max size of the response body, which is read by the http probe
+const maxHTTPBodySize = 1 << 20
+
+// validateHTTPBody verify the response body with the string comparator
+// and the fields of the json response body, extracted with the jsonpath, with their comparators
+func validateHTTPBody(inputs types.HTTPProbeInputs, body string, rc int) error {
+	if inputs.ResponseBody.Criteria != "" {
+		if err := cmp.RunCount(rc).
+			FirstValue(strings.TrimSpace(body)).
+			SecondValue(inputs.ResponseBody.Value).
+			Criteria(inputs.ResponseBody.Criteria).
+			CompareString(); err != nil {
+			return err
+		}
+	}
+	if len(inputs.JSONPath) == 0 {
+		return nil
+	}
+
+	// the numbers are decoded as json.Number, so that they are printed in the same format as the response
+	var data interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return errors.Errorf("unable to parse the json response body, err: %v", err)
+	}
+	for _, check := range inputs.JSONPath {
+		value, err := getJSONPathValue(data, check.Path)
+		if err != nil {
+			return err
+		}
+		comparator := check.Comparator
+		if comparator.Type == "" {
+			comparator.Type = "string"
+		}
+		if err := ValidateResult(comparator, value, rc); err != nil {
+			return errors.Errorf("jsonpath %v: %v", check.Path, err)
+		}
+	}
+	return nil
+}
+
+// getJSONPathValue returns the value of the field, extracted with the jsonpath
+// the jsonpath can be provided with or without the surrounding braces, e.g. {.status} or .status
+func getJSONPathValue(data interface{}, path string) (string, error) {
+	expression := strings.TrimSpace(path)
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	parser := jsonpath.New("probe")
+	if err := parser.Parse(expression); err != nil {
+		return "", errors.Errorf("unable to parse the %v jsonpath, err: %v", path, err)
+	}
+	var out bytes.Buffer
+	if err := parser.Execute(&out, data); err != nil {
+		return "", errors.Errorf("unable to find the %v jsonpath in the response body, err: %v", path, err)
+	}
+	return out.String(), nil
 }
 
 // getHTTPBody fetch the http body for the post request
@@ -198,36 +338,48 @@ func getHTTPBody(httpBody v1alpha1.PostMethod) (string, error) {
 }
 
 // TriggerContinuousHTTPProbe trigger the continuous http probes
-func TriggerContinuousHTTPProbe(probe v1alpha1.ProbeAttributes, chaosresult *types.ResultDetails) {
+func TriggerContinuousHTTPProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, chaosresult *types.ResultDetails) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 	}
 
 	// it trigger the http probe for the entire duration of chaos and it fails, if any error encounter
 	// it marked the error for the probes, if any
 loop:
 	for {
-		err = TriggerHTTPProbe(probe, chaosresult)
+		err = TriggerHTTPProbe(ctx, probe, clients, chaosDetails, chaosresult)
+		// the failed requests are counted in the error rate, if the error rate slo is provided, instead of failing the probe
+		if hasErrorRateSLO(probe.HTTPProbeInputs.SLO) {
+			err = nil
+		}
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
 			for index := range chaosresult.ProbeDetails {
 				if chaosresult.ProbeDetails[index].Name == probe.Name {
 					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 					log.Errorf("The %v http probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
 					break loop
 				}
 			}
 		}
 		// waiting for the probe polling interval
-		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
 	}
 }
 
 //PreChaosHTTPProbe trigger the http probe for prechaos phase
-func PreChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PreChaosHTTPProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "SOT", "Edge":
@@ -244,10 +396,12 @@ func PreChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 		// trigger the http probe
-		err = TriggerHTTPProbe(probe, resultDetails)
+		err = TriggerHTTPProbe(ctx, probe, clients, chaosDetails, resultDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
@@ -264,13 +418,14 @@ func PreChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 			"Mode":           probe.Mode,
 			"Phase":          "PreChaos",
 		})
-		go TriggerContinuousHTTPProbe(probe, resultDetails)
+		go TriggerContinuousHTTPProbe(ctx, probe, clients, chaosDetails, resultDetails)
 	}
 	return nil
 }
 
 //PostChaosHTTPProbe trigger the http probe for postchaos phase
-func PostChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PostChaosHTTPProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "EOT", "Edge":
@@ -287,11 +442,13 @@ func PostChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 
 		// trigger the http probe
-		err = TriggerHTTPProbe(probe, resultDetails)
+		err = TriggerHTTPProbe(ctx, probe, clients, chaosDetails, resultDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
@@ -301,6 +458,10 @@ func PostChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 	case "Continuous", "OnChaos":
 		// it will check for the error, It will detect the error if any error encountered in probe during chaos
 		err = CheckForErrorInContinuousProbe(resultDetails, probe.Name)
+		// summarise the latency over the chaos window and verify it against the slo, if provided
+		if sloErr := setLatencySummary(probe, chaosDetails, resultDetails); err == nil {
+			err = sloErr
+		}
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
 			return err
@@ -310,12 +471,15 @@ func PostChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 }
 
 // TriggerOnChaosHTTPProbe trigger the onchaos http probes
-func TriggerOnChaosHTTPProbe(probe v1alpha1.ProbeAttributes, chaosresult *types.ResultDetails, duration int) {
+func TriggerOnChaosHTTPProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, chaosresult *types.ResultDetails, duration int) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
 	}
 
@@ -333,25 +497,33 @@ loop:
 			endTime = nil
 			break loop
 		default:
-			err = TriggerHTTPProbe(probe, chaosresult)
+			err = TriggerHTTPProbe(ctx, probe, clients, chaosDetails, chaosresult)
+			// the failed requests are counted in the error rate, if the error rate slo is provided, instead of failing the probe
+			if hasErrorRateSLO(probe.HTTPProbeInputs.SLO) {
+				err = nil
+			}
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
 						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+						haltOnFailure(ctx, probe)
 						break loop
 					}
 				}
 			}
 
 			// waiting for the probe polling interval
-			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
 		}
 	}
 }
 
 //OnChaosHTTPProbe trigger the http probe for DuringChaos phase
-func OnChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+func OnChaosHTTPProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
 
 	switch probe.Mode {
 	case "OnChaos":
@@ -364,6 +536,6 @@ func OnChaosHTTPProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			"Mode":           probe.Mode,
 			"Phase":          "DuringChaos",
 		})
-		go TriggerOnChaosHTTPProbe(probe, resultDetails, chaosDetails.ChaosDuration)
+		go TriggerOnChaosHTTPProbe(ctx, probe, clients, chaosDetails, resultDetails, chaosDetails.ChaosDuration)
 	}
 }
@@ -0,0 +1,313 @@
+package probe
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTriggerHTTPProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/health":
+			w.Write([]byte(`{"status":"DEGRADED","replicas":{"ready":2,"ratio":0.66}}`))
+		case r.URL.Path == "/admin" && r.Header.Get("Authorization") != "Bearer s3cr3t":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/basic":
+			if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		case r.URL.Path == "/items":
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Header().Set("X-Method", r.Method)
+			w.Write([]byte(r.Method + " " + r.Header.Get("X-Tenant") + " " + string(body)))
+		}
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-credentials", Namespace: "litmus"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t\n")},
+	}
+	fakeClients := clients.NewFakeClientSets([]runtime.Object{secret}, nil, nil)
+	get := func(code string) types.HTTPMethod {
+		method := types.HTTPMethod{}
+		method.Get = v1alpha1.GetMethod{Criteria: "==", ResponseCode: code}
+		return method
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		inputs       types.HTTPProbeInputs
+		wantErr      bool
+		wantArtifact string
+	}{
+		{name: "status code only", path: "/health", inputs: types.HTTPProbeInputs{Method: get("200")}},
+		{
+			name:    "degraded body",
+			path:    "/health",
+			inputs:  types.HTTPProbeInputs{Method: get("200"), ResponseBody: v1alpha1.ComparatorInfo{Criteria: "notMatches", Value: "DEGRADED|DOWN"}},
+			wantErr: true,
+		},
+		{
+			name: "jsonpath checks",
+			path: "/health",
+			inputs: types.HTTPProbeInputs{Method: get("200"), JSONPath: []types.JSONPathCheck{
+				{Path: ".replicas.ready", Comparator: v1alpha1.ComparatorInfo{Type: "int", Criteria: ">=", Value: "2"}},
+				{Path: "{.replicas.ratio}", Comparator: v1alpha1.ComparatorInfo{Type: "float", Criteria: ">", Value: "0.5"}},
+			}},
+		},
+		{
+			name: "failed jsonpath check",
+			path: "/health",
+			inputs: types.HTTPProbeInputs{Method: get("200"), JSONPath: []types.JSONPathCheck{
+				{Path: ".status", Comparator: v1alpha1.ComparatorInfo{Criteria: "equal", Value: "OK"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "missing jsonpath",
+			path: "/health",
+			inputs: types.HTTPProbeInputs{Method: get("200"), JSONPath: []types.JSONPathCheck{
+				{Path: ".version", Comparator: v1alpha1.ComparatorInfo{Criteria: "equal", Value: "1"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:   "bearer token from the secret",
+			path:   "/admin",
+			inputs: types.HTTPProbeInputs{Method: get("200"), Auth: types.HTTPAuth{BearerToken: &types.ValueSource{SecretKeyRef: &types.SecretKeyRef{Name: "app-credentials", Key: "token"}}}},
+		},
+		{name: "missing bearer token", path: "/admin", inputs: types.HTTPProbeInputs{Method: get("200")}, wantErr: true},
+		{
+			name:   "basic auth",
+			path:   "/basic",
+			inputs: types.HTTPProbeInputs{Method: get("200"), Auth: types.HTTPAuth{BasicAuth: &types.BasicAuth{Username: types.ValueSource{Value: "admin"}, Password: types.ValueSource{Value: "pass"}}}},
+		},
+		{
+			name: "put with headers",
+			path: "/items",
+			inputs: types.HTTPProbeInputs{
+				Method:  types.HTTPMethod{Put: v1alpha1.PostMethod{Body: "item", ContentType: "text/plain", Criteria: "==", ResponseCode: "200"}},
+				Headers: []types.HTTPHeader{{Name: "X-Tenant", ValueSource: types.ValueSource{Value: "shop"}}},
+			},
+			wantArtifact: "PUT shop item",
+		},
+		{name: "delete", path: "/items", inputs: types.HTTPProbeInputs{Method: types.HTTPMethod{Delete: v1alpha1.GetMethod{Criteria: "==", ResponseCode: "200"}}}, wantArtifact: "DELETE"},
+		{name: "head", path: "/items", inputs: types.HTTPProbeInputs{Method: types.HTTPMethod{Head: v1alpha1.GetMethod{Criteria: "==", ResponseCode: "200"}}}},
+		{
+			name:    "missing secret",
+			path:    "/admin",
+			inputs:  types.HTTPProbeInputs{Method: get("200"), Auth: types.HTTPAuth{BearerToken: &types.ValueSource{SecretKeyRef: &types.SecretKeyRef{Name: "unknown", Key: "token"}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := types.ProbeAttributes{HTTPProbeInputs: tt.inputs}
+			probe.Name = "check-frontend"
+			probe.Type = "httpProbe"
+			probe.HTTPProbeInputs.URL = server.URL + tt.path
+			probe.RunProperties.ProbeTimeout = 2
+
+			resultDetails := &types.ResultDetails{
+				ProbeDetails:   []types.ProbeDetails{{Name: probe.Name, Type: probe.Type}},
+				ProbeArtifacts: map[string]types.ProbeArtifact{},
+			}
+			err := TriggerHTTPProbe(context.Background(), probe, fakeClients, &types.ChaosDetails{ChaosNamespace: "litmus"}, resultDetails)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("expected error: %v, found %v", tt.wantErr, err)
+			}
+			if got := resultDetails.ProbeArtifacts[probe.Name].ProbeArtifacts.Register; tt.wantArtifact != "" && got != tt.wantArtifact {
+				t.Errorf("expected %q response body inside the probe artifacts, found %q", tt.wantArtifact, got)
+			}
+		})
+	}
+}
+
+func TestSummariseLatency(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Second)
+	samples := []latencySample{
+		// the request before the chaos is not summarised
+		{time: start.Add(-time.Minute), latency: time.Second},
+	}
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, latencySample{
+			time:    start.Add(time.Duration(i) * 100 * time.Millisecond),
+			latency: time.Duration(i) * time.Millisecond,
+			failed:  i%50 == 0,
+		})
+	}
+	latencyMu.Lock()
+	latencySamples["check-latency"] = samples
+	latencyMu.Unlock()
+	defer resetLatency("check-latency")
+
+	window := types.ChaosWindow{StartTime: start.Format(time.RFC3339), EndTime: start.Add(10 * time.Second).Format(time.RFC3339)}
+	summary := summariseLatency("check-latency", window)
+	if summary.Requests != 100 || summary.Errors != 2 || summary.ErrorRate != 2 {
+		t.Fatalf("unexpected requests & errors: %+v", summary)
+	}
+	if summary.P50 != 50*time.Millisecond || summary.P95 != 95*time.Millisecond || summary.P99 != 99*time.Millisecond || summary.Max != 100*time.Millisecond {
+		t.Fatalf("unexpected latency percentiles: %+v", summary)
+	}
+
+	tests := []struct {
+		slo     []types.SLOCheck
+		wantErr bool
+	}{
+		{slo: []types.SLOCheck{{Metric: "p95", Criteria: "<", Value: "300"}, {Metric: "errorRate", Criteria: "<=", Value: "2"}}},
+		{slo: []types.SLOCheck{{Metric: "p99", Criteria: "<", Value: "90"}}, wantErr: true},
+		{slo: []types.SLOCheck{{Metric: "errorRate", Criteria: "<", Value: "1"}}, wantErr: true},
+		{slo: []types.SLOCheck{{Metric: "p90", Criteria: "<", Value: "300"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := validateSLO(tt.slo, summary); tt.wantErr != (err != nil) {
+			t.Errorf("slo %+v: expected error: %v, found %v", tt.slo, tt.wantErr, err)
+		}
+	}
+}
+
+func TestContinuousHTTPProbeSLO(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// every fourth request fails
+		if atomic.AddInt32(&requests, 1)%4 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	probe := types.ProbeAttributes{}
+	probe.Name = "check-slo"
+	probe.Type = "httpProbe"
+	probe.Mode = "Continuous"
+	probe.HTTPProbeInputs.URL = server.URL
+	probe.HTTPProbeInputs.Method.Get = v1alpha1.GetMethod{Criteria: "==", ResponseCode: "200"}
+	probe.HTTPProbeInputs.SLO = []types.SLOCheck{{Metric: "errorRate", Criteria: "<", Value: "1"}}
+	probe.RunProperties.ProbeTimeout = 1
+	defer resetLatency(probe.Name)
+
+	resultDetails := &types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{}}
+	InitializeProbes([]types.ProbeAttributes{probe}, resultDetails)
+	chaosDetails := &types.ChaosDetails{ChaosNamespace: "litmus"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	TriggerContinuousHTTPProbe(ctx, probe, clients.NewFakeClientSets(nil, nil, nil), chaosDetails, resultDetails)
+
+	if err := PostChaosHTTPProbe(context.Background(), probe, resultDetails, clients.NewFakeClientSets(nil, nil, nil), chaosDetails); err == nil {
+		t.Fatal("expected the probe to fail the error rate slo")
+	}
+	latency := resultDetails.ProbeDetails[0].Latency
+	if latency == nil || latency.Requests < 4 || latency.Errors == 0 {
+		t.Fatalf("expected the latency summary of the failed requests, found %+v", latency)
+	}
+}
+
+func TestContinuousHTTPProbeLatencySLO(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	probe := types.ProbeAttributes{}
+	probe.Name = "check-p95"
+	probe.Type = "httpProbe"
+	probe.Mode = "Continuous"
+	probe.HTTPProbeInputs.URL = server.URL
+	probe.HTTPProbeInputs.Method.Get = v1alpha1.GetMethod{Criteria: "==", ResponseCode: "200"}
+	probe.HTTPProbeInputs.SLO = []types.SLOCheck{{Metric: "p95", Criteria: "<", Value: "1000"}}
+	probe.RunProperties.ProbeTimeout = 1
+	defer resetLatency(probe.Name)
+
+	resultDetails := &types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{}}
+	InitializeProbes([]types.ProbeAttributes{probe}, resultDetails)
+	chaosDetails := &types.ChaosDetails{ChaosNamespace: "litmus"}
+
+	// the failed requests fail the probe, as the slo doesn't contain the error rate criteria
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	TriggerContinuousHTTPProbe(ctx, probe, clients.NewFakeClientSets(nil, nil, nil), chaosDetails, resultDetails)
+
+	if resultDetails.ProbeDetails[0].IsProbeFailedWithError == nil {
+		t.Fatal("expected the probe to fail on the failed requests")
+	}
+}
+
+// resetLatency removes the recorded requests of the probe
+func resetLatency(probeName string) {
+	latencyMu.Lock()
+	defer latencyMu.Unlock()
+	delete(latencySamples, probeName)
+}
+
+func TestContinuousHTTPProbesInParallel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	newProbe := func(name, path string) types.ProbeAttributes {
+		probe := types.ProbeAttributes{}
+		probe.Name = name
+		probe.Type = "httpProbe"
+		probe.Mode = "Continuous"
+		probe.HTTPProbeInputs.URL = server.URL + path
+		probe.HTTPProbeInputs.Method.Get = v1alpha1.GetMethod{Criteria: "==", ResponseCode: "200"}
+		probe.RunProperties.ProbeTimeout = 1
+		return probe
+	}
+	// the second probe reads the artifact of the first probe, while it is updated
+	probes := []types.ProbeAttributes{
+		newProbe("check-frontend", "/frontend"),
+		newProbe("check-cart", `/cart{{ (index . "check-frontend").ProbeArtifacts.Register }}`),
+	}
+
+	resultDetails := &types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{}}
+	InitializeProbes(probes, resultDetails)
+	chaosDetails := &types.ChaosDetails{ChaosNamespace: "litmus"}
+	fakeClients := clients.NewFakeClientSets(nil, nil, nil)
+
+	// the probes update the shared chaosresult in parallel, it is verified with the race detector
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, probe := range probes {
+		wg.Add(1)
+		go func(probe types.ProbeAttributes) {
+			defer wg.Done()
+			TriggerContinuousHTTPProbe(ctx, probe, fakeClients, chaosDetails, resultDetails)
+		}(probe)
+	}
+	wg.Wait()
+
+	// the abandoned attempts may still update the run counts, so they are read under the lock
+	artifactsMu.Lock()
+	defer artifactsMu.Unlock()
+	for index := range resultDetails.ProbeDetails {
+		if resultDetails.ProbeDetails[index].RunCount == 0 {
+			t.Fatalf("expected the %v probe to run", resultDetails.ProbeDetails[index].Name)
+		}
+	}
+	if got := resultDetails.ProbeArtifacts["check-frontend"].ProbeArtifacts.Register; got != "/frontend" {
+		t.Fatalf("unexpected artifact of the check-frontend probe: %q", got)
+	}
+	if got := resultDetails.ProbeArtifacts["check-cart"].ProbeArtifacts.Register; got != "/cart" && got != "/cart/frontend" {
+		t.Fatalf("unexpected artifact of the check-cart probe: %q", got)
+	}
+}
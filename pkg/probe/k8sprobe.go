@@ -1,14 +1,17 @@
 package probe
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,18 +22,18 @@ import (
 
 // PrepareK8sProbe contains the steps to prepare the k8s probe
 // k8s probe can be used to add the probe which needs client-go for command execution, no extra binaries/command
-func PrepareK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, phase string, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
+func PrepareK8sProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, phase string, eventsDetails *types.EventDetails, chaosDetails *types.ChaosDetails) error {
 	switch phase {
 	case "PreChaos":
-		if err := PreChaosK8sProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PreChaosK8sProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "PostChaos":
-		if err := PostChaosK8sProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PostChaosK8sProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "DuringChaos":
-		OnChaosK8sProbe(probe, resultDetails, clients, chaosDetails)
+		OnChaosK8sProbe(ctx, probe, resultDetails, clients, chaosDetails)
 	default:
 		return fmt.Errorf("phase '%s' not supported in the k8s probe", phase)
 	}
@@ -38,7 +41,8 @@ func PrepareK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 }
 
 // TriggerK8sProbe run the k8s probe command
-func TriggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+func TriggerK8sProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails) error {
+	var err error
 
 	inputs := probe.K8sProbeInputs
 
@@ -55,12 +59,10 @@ func TriggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 	}
 
 	// it will retry for some retry count, in each iterations of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the iterval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
+	// each iteration of retry is bounded by the probe timeout, the command is cancelled once the timeout expires
+	// if it fails, it will wait for the interval and execute the command again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
 			//defining the gvr for the requested resource
 			gvr := schema.GroupVersionResource{
 				Group:    inputs.Group,
@@ -70,14 +72,14 @@ func TriggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 
 			switch inputs.Operation {
 			case "create", "Create":
-				if err = CreateResource(probe, gvr, clients); err != nil {
+				if err := CreateResource(probe, gvr, clients); err != nil {
 					log.Errorf("The %v k8s probe has Failed, err: %v", probe.Name, err)
-					return err
+					return nil, err
 				}
 			case "delete", "Delete":
-				if err = DeleteResource(probe, gvr, clients); err != nil {
+				if err := DeleteResource(probe, gvr, clients); err != nil {
 					log.Errorf("The %v k8s probe has Failed, err: %v", probe.Name, err)
-					return err
+					return nil, err
 				}
 			case "present", "Present":
 				resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(inputs.Namespace).List(v1.ListOptions{
@@ -86,7 +88,7 @@ func TriggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 				})
 				if err != nil || len(resourceList.Items) == 0 {
 					log.Errorf("The %v k8s probe has Failed, err: %v", probe.Name, err)
-					return fmt.Errorf("unable to list the resources with matching selector, err: %v", err)
+					return nil, fmt.Errorf("unable to list the resources with matching selector, err: %v", err)
 				}
 			case "absent", "Absent":
 				resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(inputs.Namespace).List(v1.ListOptions{
@@ -94,51 +96,74 @@ func TriggerK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets,
 					LabelSelector: inputs.LabelSelector,
 				})
 				if err != nil {
-					return fmt.Errorf("unable to list the resources with matching selector, err: %v", err)
+					return nil, fmt.Errorf("unable to list the resources with matching selector, err: %v", err)
 				}
 				if len(resourceList.Items) != 0 {
 					log.Errorf("The %v k8s probe has Failed, err: %v", probe.Name, err)
-					return fmt.Errorf("resource is not deleted yet due to, err: %v", err)
+					return nil, fmt.Errorf("resource is not deleted yet due to, err: %v", err)
 				}
+			case "check", "Check":
+				// the values (or the count) are stored, once the iteration succeeds, so that the other probes can use them
+				values, err := CheckResources(inputs, gvr, clients, resultDetails, probe.Name)
+				if err != nil {
+					log.Errorf("The %v k8s probe has Failed, err: %v", probe.Name, err)
+					return nil, err
+				}
+				return values, nil
 			default:
-				return fmt.Errorf("operation type '%s' not supported in the k8s probe", inputs.Operation)
+				return nil, retry.Permanent(fmt.Errorf("operation type '%s' not supported in the k8s probe", inputs.Operation))
 			}
 
-			return nil
+			return nil, nil
 		})
+	if err != nil {
+		return err
+	}
+	if register != nil {
+		setProbeArtifact(resultDetails, probe.Name, register.(string))
+	}
+	return nil
 }
 
 // TriggerContinuousK8sProbe trigger the continuous k8s probes
-func TriggerContinuousK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails) {
+func TriggerContinuousK8sProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 	}
 
 	// it trigger the k8s probe for the entire duration of chaos and it fails, if any error encounter
 	// marked the error for the probes, if any
 loop:
 	for {
-		err = TriggerK8sProbe(probe, clients, chaosresult)
+		err = TriggerK8sProbe(ctx, probe, clients, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
 			for index := range chaosresult.ProbeDetails {
 				if chaosresult.ProbeDetails[index].Name == probe.Name {
 					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 					log.Errorf("The %v k8s probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
 					break loop
 				}
 			}
 		}
 		// waiting for the probe polling interval
-		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
 	}
 }
 
 // CreateResource creates the resource from the data provided inside data field
-func CreateResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
+func CreateResource(probe types.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
+	var err error
 	decUnstructured := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
 	// Decode YAML manifest into unstructured.Unstructured
 	data := &unstructured.Unstructured{}
@@ -146,13 +171,13 @@ func CreateResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResou
 	if err != nil {
 		return err
 	}
-	_, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).Create(data, v1.CreateOptions{})
+	_, err = clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).Create(data, v1.CreateOptions{})
 
 	return err
 }
 
 // DeleteResource deletes the resource with matching label & field selector
-func DeleteResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
+func DeleteResource(probe types.ProbeAttributes, gvr schema.GroupVersionResource, clients clients.ClientSets) error {
 	resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(probe.K8sProbeInputs.Namespace).List(v1.ListOptions{
 		FieldSelector: probe.K8sProbeInputs.FieldSelector,
 		LabelSelector: probe.K8sProbeInputs.LabelSelector,
@@ -170,8 +195,61 @@ func DeleteResource(probe v1alpha1.ProbeAttributes, gvr schema.GroupVersionResou
 	return nil
 }
 
+// CheckResources verifies the value of the jsonpath of every resource with matching label & field selector
+// it verifies the number of the matched resources, if the jsonpath is not provided
+// it returns the values (or the count), which are stored inside the probe artifacts
+func CheckResources(inputs types.K8sProbeInputs, gvr schema.GroupVersionResource, clients clients.ClientSets, resultDetails *types.ResultDetails, probeName string) (string, error) {
+	resourceList, err := clients.DynamicClient.Resource(gvr).Namespace(inputs.Namespace).List(v1.ListOptions{
+		FieldSelector: inputs.FieldSelector,
+		LabelSelector: inputs.LabelSelector,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to list the resources with matching selector, err: %v", err)
+	}
+
+	comparator := inputs.Comparator
+	rc := getAndIncrementRunCount(resultDetails, probeName)
+
+	var values []string
+	switch inputs.JSONPath {
+	case "":
+		if comparator.Type == "" {
+			comparator.Type = "int"
+		}
+		count := strconv.Itoa(len(resourceList.Items))
+		if err := ValidateResult(comparator, count, rc); err != nil {
+			return "", fmt.Errorf("number of the matched resources: %v", err)
+		}
+		values = append(values, count)
+	default:
+		if len(resourceList.Items) == 0 {
+			return "", fmt.Errorf("no resources found with matching selector")
+		}
+		if comparator.Type == "" {
+			comparator.Type = "string"
+		}
+		for index, resource := range resourceList.Items {
+			value, err := getJSONPathValue(resource.Object, inputs.JSONPath)
+			if err != nil {
+				return "", fmt.Errorf("%v resource: %v", resource.GetName(), err)
+			}
+			// logging the comparison of the first resource only
+			if index > 0 {
+				rc = 0
+			}
+			if err := ValidateResult(comparator, value, rc); err != nil {
+				return "", fmt.Errorf("%v resource: %v", resource.GetName(), err)
+			}
+			values = append(values, value)
+		}
+	}
+
+	return strings.Join(values, ","), nil
+}
+
 //PreChaosK8sProbe trigger the k8s probe for prechaos phase
-func PreChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PreChaosK8sProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "SOT", "Edge":
@@ -187,10 +265,12 @@ func PreChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 		// triggering the k8s probe
-		err = TriggerK8sProbe(probe, clients, resultDetails)
+		err = TriggerK8sProbe(ctx, probe, clients, resultDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
@@ -207,13 +287,14 @@ func PreChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 			"Mode":           probe.Mode,
 			"Phase":          "PreChaos",
 		})
-		go TriggerContinuousK8sProbe(probe, clients, resultDetails)
+		go TriggerContinuousK8sProbe(ctx, probe, clients, resultDetails)
 	}
 	return nil
 }
 
 //PostChaosK8sProbe trigger the k8s probe for postchaos phase
-func PostChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PostChaosK8sProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "EOT", "Edge":
@@ -229,10 +310,12 @@ func PostChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 		// triggering the k8s probe
-		err = TriggerK8sProbe(probe, clients, resultDetails)
+		err = TriggerK8sProbe(ctx, probe, clients, resultDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
@@ -251,7 +334,7 @@ func PostChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 }
 
 //OnChaosK8sProbe trigger the k8s probe for DuringChaos phase
-func OnChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+func OnChaosK8sProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
 
 	switch probe.Mode {
 	case "OnChaos":
@@ -264,18 +347,21 @@ func OnChaosK8sProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Result
 			"Mode":           probe.Mode,
 			"Phase":          "DuringChaos",
 		})
-		go TriggerOnChaosK8sProbe(probe, clients, resultDetails, chaosDetails.ChaosDuration)
+		go TriggerOnChaosK8sProbe(ctx, probe, clients, resultDetails, chaosDetails.ChaosDuration)
 	}
 
 }
 
 // TriggerOnChaosK8sProbe trigger the onchaos k8s probes
-func TriggerOnChaosK8sProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, duration int) {
+func TriggerOnChaosK8sProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosresult *types.ResultDetails, duration int) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
 	}
 
@@ -293,19 +379,23 @@ loop:
 			endTime = nil
 			break loop
 		default:
-			err = TriggerK8sProbe(probe, clients, chaosresult)
+			err = TriggerK8sProbe(ctx, probe, clients, chaosresult)
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 			if err != nil {
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
 						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 						log.Errorf("The %v k8s probe has been Failed, err: %v", probe.Name, err)
+						haltOnFailure(ctx, probe)
 						break loop
 					}
 				}
 			}
 			// waiting for the probe polling interval
-			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
 		}
 	}
 }
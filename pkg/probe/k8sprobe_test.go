@@ -0,0 +1,114 @@
+package probe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newDeployment returns the unstructured deployment with the given available replicas and the available condition
+func newDeployment(name string, availableReplicas int64, available string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "shop",
+			"labels":    map[string]interface{}{"app": name},
+		},
+		"status": map[string]interface{}{
+			"availableReplicas": availableReplicas,
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Progressing", "status": "True"},
+				map[string]interface{}{"type": "Available", "status": available},
+			},
+		},
+	}}
+}
+
+func TestTriggerK8sProbeCheck(t *testing.T) {
+	fakeClients := clients.NewFakeClientSets(nil, nil, []runtime.Object{
+		newDeployment("cart", 3, "True"),
+		newDeployment("checkout", 1, "False"),
+	})
+
+	tests := []struct {
+		name          string
+		labelSelector string
+		jsonPath      string
+		comparator    v1alpha1.ComparatorInfo
+		wantErr       bool
+		wantArtifact  string
+	}{
+		{
+			name:          "available replicas",
+			labelSelector: "app=cart",
+			jsonPath:      "{.status.availableReplicas}",
+			comparator:    v1alpha1.ComparatorInfo{Type: "int", Criteria: ">=", Value: "3"},
+			wantArtifact:  "3",
+		},
+		{
+			name:       "available replicas of all the deployments",
+			jsonPath:   ".status.availableReplicas",
+			comparator: v1alpha1.ComparatorInfo{Type: "int", Criteria: ">=", Value: "3"},
+			wantErr:    true,
+		},
+		{
+			name:          "available condition",
+			labelSelector: "app=cart",
+			jsonPath:      `{.status.conditions[?(@.type=="Available")].status}`,
+			comparator:    v1alpha1.ComparatorInfo{Criteria: "equal", Value: "True"},
+			wantArtifact:  "True",
+		},
+		{
+			name:          "unavailable condition",
+			labelSelector: "app=checkout",
+			jsonPath:      `{.status.conditions[?(@.type=="Available")].status}`,
+			comparator:    v1alpha1.ComparatorInfo{Criteria: "equal", Value: "True"},
+			wantErr:       true,
+		},
+		{name: "count", comparator: v1alpha1.ComparatorInfo{Criteria: "==", Value: "2"}, wantArtifact: "2"},
+		{name: "count mismatch", labelSelector: "app=cart", comparator: v1alpha1.ComparatorInfo{Criteria: "==", Value: "2"}, wantErr: true},
+		{
+			name:          "no matched resources",
+			labelSelector: "app=payment",
+			jsonPath:      "{.status.availableReplicas}",
+			comparator:    v1alpha1.ComparatorInfo{Type: "int", Criteria: ">=", Value: "1"},
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := types.ProbeAttributes{}
+			probe.Name = "check-deployments"
+			probe.Type = "k8sProbe"
+			probe.K8sProbeInputs.Group = "apps"
+			probe.K8sProbeInputs.Version = "v1"
+			probe.K8sProbeInputs.Resource = "deployments"
+			probe.K8sProbeInputs.Namespace = "shop"
+			probe.K8sProbeInputs.LabelSelector = tt.labelSelector
+			probe.K8sProbeInputs.Operation = "check"
+			probe.K8sProbeInputs.JSONPath = tt.jsonPath
+			probe.K8sProbeInputs.Comparator = tt.comparator
+			probe.RunProperties.ProbeTimeout = 2
+
+			resultDetails := &types.ResultDetails{
+				ProbeDetails:   []types.ProbeDetails{{Name: probe.Name, Type: probe.Type}},
+				ProbeArtifacts: map[string]types.ProbeArtifact{},
+			}
+			err := TriggerK8sProbe(context.Background(), probe, fakeClients, resultDetails)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("expected error: %v, found %v", tt.wantErr, err)
+			}
+			if got := resultDetails.ProbeArtifacts[probe.Name].ProbeArtifacts.Register; got != tt.wantArtifact {
+				t.Errorf("expected %q inside the probe artifacts, found %q", tt.wantArtifact, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package probe
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// the http probe records the latency & the outcome of every request, the continuous & onchaos http probes
+// summarise them (p50/p95/p99/max latency and the error rate) over the chaos window, at the end of the chaos
+// the summary is stored inside the probe status and it is verified against the slo of the probe, if provided
+
+// latencySample contains the latency & the outcome of a request
+type latencySample struct {
+	time    time.Time
+	latency time.Duration
+	failed  bool
+}
+
+var (
+	latencyMu      sync.Mutex
+	latencySamples = map[string][]latencySample{}
+)
+
+// recordLatency records the latency & the outcome of the request of the probe
+// the requests, which are cancelled by the abort or the halt, are not recorded
+func recordLatency(ctx context.Context, probeName string, start time.Time, failed bool) {
+	if ctx.Err() == context.Canceled {
+		return
+	}
+	latencyMu.Lock()
+	defer latencyMu.Unlock()
+	latencySamples[probeName] = append(latencySamples[probeName], latencySample{time: start, latency: time.Since(start), failed: failed})
+}
+
+// summariseLatency returns the latency summary of the probe requests over the chaos window
+// it summarises all the requests, if the chaos window isn't recorded
+func summariseLatency(probeName string, window types.ChaosWindow) types.LatencySummary {
+	start, _ := time.Parse(time.RFC3339, window.StartTime)
+	end, _ := time.Parse(time.RFC3339, window.EndTime)
+
+	latencyMu.Lock()
+	defer latencyMu.Unlock()
+
+	summary := types.LatencySummary{}
+	var latencies []time.Duration
+	for _, sample := range latencySamples[probeName] {
+		// the chaos window has second precision
+		if !start.IsZero() && sample.time.Before(start) {
+			continue
+		}
+		if !end.IsZero() && !sample.time.Before(end.Add(time.Second)) {
+			continue
+		}
+		summary.Requests++
+		if sample.failed {
+			summary.Errors++
+		}
+		latencies = append(latencies, sample.latency)
+	}
+	if summary.Requests == 0 {
+		return summary
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	summary.ErrorRate = float64(summary.Errors) * 100 / float64(summary.Requests)
+	summary.P50 = percentile(latencies, 50)
+	summary.P95 = percentile(latencies, 95)
+	summary.P99 = percentile(latencies, 99)
+	summary.Max = latencies[len(latencies)-1]
+	return summary
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// setLatencySummary summarises the latency of the continuous & onchaos http probe and stores it inside the probe details
+// it verifies the summary against the slo of the probe, if provided
+func setLatencySummary(probe types.ProbeAttributes, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+	summary := summariseLatency(probe.Name, chaosDetails.ChaosWindow)
+	for index := range resultDetails.ProbeDetails {
+		if resultDetails.ProbeDetails[index].Name == probe.Name {
+			resultDetails.ProbeDetails[index].Latency = &summary
+		}
+	}
+	log.Infof("[Probe]: The latency summary of the %v probe, %v", probe.Name, summary.String())
+
+	if len(probe.HTTPProbeInputs.SLO) == 0 {
+		return nil
+	}
+	if summary.Requests == 0 {
+		return errors.Errorf("no requests are recorded during the chaos for the %v probe", probe.Name)
+	}
+	return validateSLO(probe.HTTPProbeInputs.SLO, summary)
+}
+
+// validateSLO verifies the latency summary against the slo
+// the latencies are compared in milliseconds and the error rate in percentage
+func validateSLO(slo []types.SLOCheck, summary types.LatencySummary) error {
+	for _, check := range slo {
+		var value float64
+		switch strings.ToLower(check.Metric) {
+		case "p50":
+			value = toMilliseconds(summary.P50)
+		case "p95":
+			value = toMilliseconds(summary.P95)
+		case "p99":
+			value = toMilliseconds(summary.P99)
+		case "max":
+			value = toMilliseconds(summary.Max)
+		case "errorrate":
+			value = summary.ErrorRate
+		default:
+			return errors.Errorf("metric '%v' not supported in the http probe slo", check.Metric)
+		}
+		if err := cmp.RunCount(1).
+			FirstValue(strconv.FormatFloat(value, 'f', -1, 64)).
+			SecondValue(check.Value).
+			Criteria(check.Criteria).
+			CompareFloat(); err != nil {
+			return errors.Errorf("slo %v: %v", check.Metric, err)
+		}
+	}
+	return nil
+}
+
+// hasErrorRateSLO checks whether the slo contains the error rate criteria
+// the failed requests fail the probe as usual, if only the latency criteria are provided
+func hasErrorRateSLO(slo []types.SLOCheck) bool {
+	for _, check := range slo {
+		if strings.ToLower(check.Metric) == "errorrate" {
+			return true
+		}
+	}
+	return false
+}
+
+// toMilliseconds returns the duration in milliseconds
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,489 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// the log probe reads the logs of the matched pods during the probe window and counts the log lines, which match the pattern
+// the logs are read in every polling interval, from the last read log line of every container, with the timestamps
+// the notMatches & atMost criteria fail as soon as they are violated, whereas the matches criteria is verified in the end
+
+// logWindow contains the number of the matched log lines, since the start of the log probe
+type logWindow struct {
+	mu        sync.Mutex
+	pattern   *regexp.Regexp
+	since     time.Time
+	lastSeen  map[string]time.Time
+	matches   int
+	lastMatch string
+}
+
+// logWindows contains the windows of the running log probes, keyed by the probe name
+var (
+	logWindowsMu sync.Mutex
+	logWindows   = map[string]*logWindow{}
+)
+
+// PrepareLogProbe contains the steps to prepare the log probe
+// log probe can be used to add the probe which will verify the occurrences of the pattern in the pod logs during chaos
+func PrepareLogProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+
+	switch phase {
+	case "PreChaos":
+		if err := PreChaosLogProbe(ctx, probe, clients, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "PostChaos":
+		if err := PostChaosLogProbe(ctx, probe, clients, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "DuringChaos":
+		if err := OnChaosLogProbe(ctx, probe, clients, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("phase '%s' not supported in the log probe", phase)
+	}
+	return nil
+}
+
+// getLogProbeInputs returns the inputs of the log probe, with the defaults of the application details
+func getLogProbeInputs(probe types.ProbeAttributes, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) (types.LogProbeInputs, error) {
+	var err error
+
+	inputs := probe.LogProbeInputs
+
+	// It parse the templated label selector & pattern and return normal string
+	// if they don't have template, it will return the same string
+	if inputs.LabelSelector, err = ParseCommand(inputs.LabelSelector, resultDetails); err != nil {
+		return inputs, err
+	}
+	if inputs.Pattern, err = ParseCommand(inputs.Pattern, resultDetails); err != nil {
+		return inputs, err
+	}
+	if inputs.Namespace == "" {
+		inputs.Namespace = chaosDetails.AppDetail.Namespace
+	}
+	if inputs.LabelSelector == "" {
+		inputs.LabelSelector = chaosDetails.AppDetail.Label
+	}
+	if inputs.Namespace == "" || inputs.LabelSelector == "" {
+		return inputs, errors.Errorf("[Probe]: namespace & labelSelector are required for the %v log probe, as the application details aren't provided", probe.Name)
+	}
+	if inputs.Pattern == "" {
+		return inputs, errors.Errorf("[Probe]: pattern is required for the %v log probe", probe.Name)
+	}
+
+	switch inputs.Criteria {
+	case "matches", "Matches", "notMatches", "NotMatches", "atMost", "AtMost":
+	default:
+		return inputs, errors.Errorf("[Probe]: criteria '%v' not supported in the %v log probe", inputs.Criteria, probe.Name)
+	}
+	return inputs, nil
+}
+
+// newLogWindow creates the window of the log probe, the log lines older than the since time are skipped
+func newLogWindow(probeName, pattern string, since time.Time) (*logWindow, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Errorf("the probe regex '%s' is not a valid expression", pattern)
+	}
+	window := &logWindow{
+		pattern:  re,
+		since:    since,
+		lastSeen: map[string]time.Time{},
+	}
+
+	logWindowsMu.Lock()
+	defer logWindowsMu.Unlock()
+	logWindows[probeName] = window
+	return window, nil
+}
+
+// takeLogWindow returns the window of the log probe and removes it from the running log probes
+func takeLogWindow(probeName string) *logWindow {
+	logWindowsMu.Lock()
+	defer logWindowsMu.Unlock()
+	window := logWindows[probeName]
+	delete(logWindows, probeName)
+	return window
+}
+
+// read reads the new log lines of the containers of all the matched pods
+// the containers, which are not started yet, are skipped
+// the log streams are bound to the context, so the read stops once the attempt times out or the experiment is aborted
+func (w *logWindow) read(ctx context.Context, clients clients.ClientSets, inputs types.LogProbeInputs) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pods, err := clients.KubeClient.CoreV1().Pods(inputs.Namespace).List(v1.ListOptions{LabelSelector: inputs.LabelSelector})
+	if err != nil {
+		return errors.Errorf("unable to list the pods with %v label in %v namespace, err: %v", inputs.LabelSelector, inputs.Namespace, err)
+	}
+	if len(pods.Items) == 0 {
+		log.Warnf("[Probe]: No pods found with %v label in %v namespace", inputs.LabelSelector, inputs.Namespace)
+	}
+
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if inputs.Container != "" && container.Name != inputs.Container {
+				continue
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			key := pod.Name + "/" + container.Name
+			since := v1.NewTime(w.lastRead(key))
+			stream, err := clients.KubeClient.CoreV1().Pods(inputs.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container:  container.Name,
+				SinceTime:  &since,
+				Timestamps: true,
+			}).Context(ctx).Stream()
+			if err != nil {
+				log.Warnf("[Probe]: Unable to read the logs of %v container, err: %v", key, err)
+				continue
+			}
+			if err := w.scanWithContext(ctx, stream, key); err != nil {
+				return errors.Errorf("unable to read the logs of %v container, err: %v", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// lastRead returns the timestamp of the last read log line of the container
+func (w *logWindow) lastRead(key string) time.Time {
+	if last, ok := w.lastSeen[key]; ok {
+		return last
+	}
+	return w.since
+}
+
+// scan counts the log lines of the container, which match the pattern
+// the log lines are read again from the start of the second of the since time, so the lines older than the last read line are skipped
+func (w *logWindow) scan(r io.Reader, key string) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		timestamp, line := splitLogTimestamp(scanner.Text())
+		if !timestamp.IsZero() {
+			if !timestamp.After(w.lastRead(key)) {
+				continue
+			}
+			w.lastSeen[key] = timestamp
+		}
+		if w.pattern.MatchString(line) {
+			w.matches++
+			w.lastMatch = line
+		}
+	}
+	return scanner.Err()
+}
+
+// scanWithContext scans the log stream and closes it, the stream is closed as soon as the context is cancelled
+// to unblock the pending read of the stream
+func (w *logWindow) scanWithContext(ctx context.Context, stream io.ReadCloser, key string) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-done:
+		}
+	}()
+	defer stream.Close()
+
+	if err := w.scan(stream, key); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return ctx.Err()
+}
+
+// count returns the number of the matched log lines and the last matched log line
+func (w *logWindow) count() (int, string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.matches, w.lastMatch
+}
+
+// splitLogTimestamp splits the RFC3339 timestamp, added by the kubelet, from the log line
+// it returns the zero timestamp, if the log line doesn't contain the timestamp
+func splitLogTimestamp(line string) (time.Time, string) {
+	index := strings.Index(line, " ")
+	if index < 0 {
+		return time.Time{}, line
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, line[:index])
+	if err != nil {
+		return time.Time{}, line
+	}
+	return timestamp, line[index+1:]
+}
+
+// validateLogMatches verifies the number of the matched log lines with the criteria
+// the matches criteria is verified only in the end, as the pattern may appear later in the window
+func validateLogMatches(inputs types.LogProbeInputs, matches int, final bool, rc int) error {
+
+	comparator := v1alpha1.ComparatorInfo{Type: "int"}
+	switch inputs.Criteria {
+	case "matches", "Matches":
+		if !final {
+			return nil
+		}
+		comparator.Criteria, comparator.Value = ">=", "1"
+	case "notMatches", "NotMatches":
+		comparator.Criteria, comparator.Value = "==", "0"
+	case "atMost", "AtMost":
+		comparator.Criteria, comparator.Value = "<=", strconv.Itoa(inputs.Count)
+	default:
+		return errors.Errorf("criteria '%v' not supported in the log probe", inputs.Criteria)
+	}
+
+	if err := ValidateResult(comparator, strconv.Itoa(matches), rc); err != nil {
+		return errors.Errorf("the '%v' pattern has been matched with %v log lines, err: %v", inputs.Pattern, matches, err)
+	}
+	return nil
+}
+
+// TriggerLogProbe reads the new log lines of the matched pods and verifies the number of the matched log lines
+func TriggerLogProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, inputs types.LogProbeInputs, resultDetails *types.ResultDetails, window *logWindow) error {
+
+	// it will retry for some retry count, in each iterations of try it contains following things
+	// it reads the logs from the last read log line, so the retried iteration doesn't count the log lines again
+	if err := probeRetry(probe).
+		Do(ctx, func(ctx context.Context, attempt uint) error {
+			return window.read(ctx, clients, inputs)
+		}); err != nil {
+		return err
+	}
+
+	matches, lastMatch := window.count()
+	rc := getAndIncrementRunCount(resultDetails, probe.Name)
+	if err := validateLogMatches(inputs, matches, false, rc); err != nil {
+		log.Errorf("The %v log probe has been Failed, last matched log line: %v", probe.Name, lastMatch)
+		return err
+	}
+
+	setProbeArtifact(resultDetails, probe.Name, strconv.Itoa(matches))
+	return nil
+}
+
+// TriggerContinuousLogProbe trigger the continuous log probes
+func TriggerContinuousLogProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, inputs types.LogProbeInputs, chaosresult *types.ResultDetails, window *logWindow) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+	}
+
+	// it trigger the log probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		err = TriggerLogProbe(ctx, probe, clients, inputs, chaosresult, window)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+					log.Errorf("The %v log probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
+					break loop
+				}
+			}
+		}
+		// waiting for the probe polling interval
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
+	}
+}
+
+//PreChaosLogProbe trigger the log probe for prechaos phase
+func PreChaosLogProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "SOT", "EOT", "Edge":
+		err = errors.Errorf("[Probe]: mode '%v' not supported in the %v log probe, it supports Continuous & OnChaos modes", probe.Mode, probe.Name)
+		return MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PreChaos")
+	case "Continuous":
+
+		//DISPLAY THE LOG PROBE INFO
+		log.InfoWithValues("[Probe]: The log probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Pattern":        probe.LogProbeInputs.Pattern,
+			"Criteria":       probe.LogProbeInputs.Criteria,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		window, inputs, err := startLogProbe(probe, chaosDetails, resultDetails)
+		if err != nil {
+			return MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PreChaos")
+		}
+		go TriggerContinuousLogProbe(ctx, probe, clients, inputs, resultDetails, window)
+	}
+	return nil
+}
+
+// startLogProbe returns the inputs and the window of the log probe
+// the window starts after the initial delay, so the log lines before the initial delay are skipped
+func startLogProbe(probe types.ProbeAttributes, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) (*logWindow, types.LogProbeInputs, error) {
+	inputs, err := getLogProbeInputs(probe, chaosDetails, resultDetails)
+	if err != nil {
+		return nil, inputs, err
+	}
+	since := time.Now().Add(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+	window, err := newLogWindow(probe.Name, inputs.Pattern, since)
+	return window, inputs, err
+}
+
+//PostChaosLogProbe trigger the log probe for postchaos phase
+func PostChaosLogProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err = CheckForErrorInContinuousProbe(resultDetails, probe.Name)
+		if err == nil {
+			// verifying the matched log lines of the entire window, for the matches criteria
+			err = verifyLogWindow(ctx, probe, clients, chaosDetails, resultDetails)
+		}
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyLogWindow verifies the number of the matched log lines of the entire window
+// the continuous probe reads the remaining log lines, whereas the onchaos probe reads them at the end of the chaos duration
+func verifyLogWindow(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+
+	window := takeLogWindow(probe.Name)
+	if window == nil {
+		return errors.Errorf("[Probe]: the %v log probe hasn't been started", probe.Name)
+	}
+	inputs, err := getLogProbeInputs(probe, chaosDetails, resultDetails)
+	if err != nil {
+		return err
+	}
+
+	if probe.Mode == "Continuous" {
+		if err := probeRetry(probe).
+			Do(ctx, func(ctx context.Context, attempt uint) error {
+				return window.read(ctx, clients, inputs)
+			}); err != nil {
+			return err
+		}
+	}
+
+	matches, _ := window.count()
+	log.Infof("[Probe]: The '%v' pattern has been matched with %v log lines in the %v log probe", inputs.Pattern, matches, probe.Name)
+	return validateLogMatches(inputs, matches, true, getAndIncrementRunCount(resultDetails, probe.Name))
+}
+
+// TriggerOnChaosLogProbe trigger the onchaos log probes
+func TriggerOnChaosLogProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, inputs types.LogProbeInputs, chaosresult *types.ResultDetails, window *logWindow, duration int) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the log probe for the entire duration of chaos and it fails, if any error encounter
+	// the logs are read once more at the end of the chaos duration, so the window covers the entire duration
+	// it marked the error for the probes, if any
+	timeUp := false
+loop:
+	for {
+		err = TriggerLogProbe(ctx, probe, clients, inputs, chaosresult, window)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+					haltOnFailure(ctx, probe)
+					break loop
+				}
+			}
+		}
+		if timeUp {
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			break loop
+		}
+
+		// waiting for the probe polling interval or the end of the chaos duration
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-endTime:
+			timeUp = true
+		case <-time.After(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second):
+		}
+	}
+}
+
+//OnChaosLogProbe trigger the log probe for DuringChaos phase
+func OnChaosLogProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE LOG PROBE INFO
+		log.InfoWithValues("[Probe]: The log probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Pattern":        probe.LogProbeInputs.Pattern,
+			"Criteria":       probe.LogProbeInputs.Criteria,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+
+		window, inputs, err := startLogProbe(probe, chaosDetails, resultDetails)
+		if err != nil {
+			return MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "DuringChaos")
+		}
+		go TriggerOnChaosLogProbe(ctx, probe, clients, inputs, resultDetails, window, chaosDetails.ChaosDuration)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package probe
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+func TestLogWindowScan(t *testing.T) {
+	since := time.Date(2021, 3, 1, 10, 0, 0, 500000000, time.UTC)
+	window, err := newLogWindow("no-oom", "OutOfMemoryError", since)
+	if err != nil {
+		t.Fatalf("unable to create the log window: %v", err)
+	}
+	defer takeLogWindow("no-oom")
+
+	// the logs are read from the start of the second of the since time
+	first := strings.Join([]string{
+		"2021-03-01T10:00:00.100000000Z java.lang.OutOfMemoryError: before the window",
+		"2021-03-01T10:00:01.000000000Z connected to broker",
+		"2021-03-01T10:00:02.000000000Z java.lang.OutOfMemoryError: Java heap space",
+	}, "\n")
+	if err := window.scan(strings.NewReader(first), "app/main"); err != nil {
+		t.Fatalf("unable to scan the logs: %v", err)
+	}
+	// the next read starts from the second of the last read log line
+	second := strings.Join([]string{
+		"2021-03-01T10:00:02.000000000Z java.lang.OutOfMemoryError: Java heap space",
+		"2021-03-01T10:00:03.000000000Z java.lang.OutOfMemoryError: GC overhead limit exceeded",
+	}, "\n")
+	if err := window.scan(strings.NewReader(second), "app/main"); err != nil {
+		t.Fatalf("unable to scan the logs: %v", err)
+	}
+	// the log lines of the other containers are read from the since time
+	if err := window.scan(strings.NewReader("2021-03-01T10:00:01.000000000Z OutOfMemoryError in sidecar"), "app/sidecar"); err != nil {
+		t.Fatalf("unable to scan the logs: %v", err)
+	}
+
+	matches, lastMatch := window.count()
+	if matches != 3 {
+		t.Errorf("expected 3 matched log lines, got %v", matches)
+	}
+	if lastMatch != "OutOfMemoryError in sidecar" {
+		t.Errorf("unexpected last matched log line: %q", lastMatch)
+	}
+}
+
+func TestValidateLogMatches(t *testing.T) {
+	tests := []struct {
+		criteria string
+		count    int
+		matches  int
+		final    bool
+		wantErr  bool
+	}{
+		{criteria: "matches", matches: 0, final: false},
+		{criteria: "matches", matches: 0, final: true, wantErr: true},
+		{criteria: "matches", matches: 2, final: true},
+		{criteria: "notMatches", matches: 0, final: true},
+		{criteria: "notMatches", matches: 1, final: false, wantErr: true},
+		{criteria: "atMost", count: 2, matches: 2, final: false},
+		{criteria: "atMost", count: 2, matches: 3, final: false, wantErr: true},
+		{criteria: "atLeast", matches: 1, final: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		inputs := types.LogProbeInputs{Pattern: "OutOfMemoryError", Criteria: tt.criteria, Count: tt.count}
+		err := validateLogMatches(inputs, tt.matches, tt.final, 0)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v criteria with %v matches (final: %v): expected error %v, got %v", tt.criteria, tt.matches, tt.final, tt.wantErr, err)
+		}
+	}
+}
+
+func TestGetLogProbeInputs(t *testing.T) {
+	probe := types.ProbeAttributes{LogProbeInputs: types.LogProbeInputs{Pattern: "reconnected to broker", Criteria: "matches"}}
+	probe.Name = "reconnect"
+	chaosDetails := &types.ChaosDetails{AppDetail: types.AppDetails{Namespace: "kafka", Label: "app=consumer"}}
+	resultDetails := &types.ResultDetails{ProbeArtifacts: map[string]types.ProbeArtifact{}}
+
+	inputs, err := getLogProbeInputs(probe, chaosDetails, resultDetails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inputs.Namespace != "kafka" || inputs.LabelSelector != "app=consumer" {
+		t.Errorf("expected the application namespace & label, got %v & %v", inputs.Namespace, inputs.LabelSelector)
+	}
+
+	// the namespace & label selector are required, if the application details aren't provided
+	if _, err := getLogProbeInputs(probe, &types.ChaosDetails{}, resultDetails); err == nil {
+		t.Errorf("expected error for the empty namespace & label selector")
+	}
+
+	probe.LogProbeInputs.Pattern = ""
+	if _, err := getLogProbeInputs(probe, chaosDetails, resultDetails); err == nil {
+		t.Errorf("expected error for the empty pattern")
+	}
+}
+
+func TestLogWindowScanWithContext(t *testing.T) {
+	window, err := newLogWindow("reconnect", "reconnected to broker", time.Time{})
+	if err != nil {
+		t.Fatalf("unable to create the log window: %v", err)
+	}
+	defer takeLogWindow("reconnect")
+
+	// the stream is closed once the context is cancelled, even though it doesn't return any more log lines
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- window.scanWithContext(ctx, reader, "consumer/main")
+	}()
+	writer.Write([]byte("2021-03-01T10:00:01.000000000Z reconnected to broker\n"))
+
+	select {
+	case err := <-done:
+		if err != context.DeadlineExceeded {
+			t.Errorf("expected the context error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the scan isn't stopped once the context is cancelled")
+	}
+	if matches, _ := window.count(); matches != 1 {
+		t.Errorf("expected 1 matched log line, got %v", matches)
+	}
+}
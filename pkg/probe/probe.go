@@ -2,32 +2,52 @@ package probe
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/kyokomi/emoji"
-	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
+	"github.com/litmuschaos/litmus-go/pkg/notify"
+	"github.com/litmuschaos/litmus-go/pkg/standalone"
 	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-var err error
+// artifactsMu guards the probe artifacts & run counts, as the continuous & onchaos probes update them in parallel
+var artifactsMu sync.Mutex
+
+// engineGVR is the group version resource of the chaosengines
+var engineGVR = schema.GroupVersionResource{Group: "litmuschaos.io", Version: "v1alpha1", Resource: "chaosengines"}
 
 // RunProbes contains the steps to trigger the probes
-// It contains steps to trigger all three probes: k8sprobe, httpprobe, cmdprobe
-func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+// It contains steps to trigger all the probes: k8sprobe, httpprobe, cmdprobe, promprobe, grpcprobe, tcpprobe, logprobe, eventprobe, sqlprobe
+// the probes are stopped, once the context is cancelled
+func RunProbes(ctx context.Context, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
 
 	// get the probes details from the chaosengine
 	probes, err := GetProbesFromEngine(chaosDetails, clients)
 	if err != nil {
 		return err
 	}
+	return RunProbeList(ctx, probes, chaosDetails, clients, resultDetails, phase, eventsDetails)
+}
+
+// RunProbeList triggers the given probes for the phase
+// it runs the probes, which are not defined inside the chaosengine, like the shared probes of the workflow
+func RunProbeList(ctx context.Context, probes []types.ProbeAttributes, chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+	var err error
 
 	var probeError []error
 
@@ -38,22 +58,47 @@ func RunProbes(chaosDetails *types.ChaosDetails, clients clients.ClientSets, res
 			switch strings.ToLower(probe.Type) {
 			case "k8sprobe":
 				// it contains steps to prepare the k8s probe
-				if err = PrepareK8sProbe(probe, resultDetails, clients, phase, eventsDetails, chaosDetails); err != nil {
+				if err = PrepareK8sProbe(ctx, probe, resultDetails, clients, phase, eventsDetails, chaosDetails); err != nil {
 					probeError = append(probeError, err)
 				}
 			case "cmdprobe":
 				// it contains steps to prepare cmd probe
-				if err = PrepareCmdProbe(probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+				if err = PrepareCmdProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
 					probeError = append(probeError, err)
 				}
 			case "httpprobe":
 				// it contains steps to prepare http probe
-				if err = PrepareHTTPProbe(probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+				if err = PrepareHTTPProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
 					probeError = append(probeError, err)
 				}
 			case "promprobe":
 				// it contains steps to prepare prom probe
-				if err = PreparePromProbe(probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+				if err = PreparePromProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+					probeError = append(probeError, err)
+				}
+			case "grpcprobe":
+				// it contains steps to prepare grpc probe
+				if err = PrepareGRPCProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+					probeError = append(probeError, err)
+				}
+			case "tcpprobe":
+				// it contains steps to prepare tcp probe
+				if err = PrepareTCPProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+					probeError = append(probeError, err)
+				}
+			case "logprobe":
+				// it contains steps to prepare log probe
+				if err = PrepareLogProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+					probeError = append(probeError, err)
+				}
+			case "eventprobe":
+				// it contains steps to prepare event probe
+				if err = PrepareEventProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
+					probeError = append(probeError, err)
+				}
+			case "sqlprobe":
+				// it contains steps to prepare sql probe
+				if err = PrepareSQLProbe(ctx, probe, clients, chaosDetails, resultDetails, phase, eventsDetails); err != nil {
 					probeError = append(probeError, err)
 				}
 			default:
@@ -96,17 +141,36 @@ func SetProbeVerdictAfterFailure(resultDetails *types.ResultDetails) {
 }
 
 // GetProbesFromEngine fetch the details of the probes from the chaosengines
-func GetProbesFromEngine(chaosDetails *types.ChaosDetails, clients clients.ClientSets) ([]v1alpha1.ProbeAttributes, error) {
+// or from the spec file in the standalone mode
+func GetProbesFromEngine(chaosDetails *types.ChaosDetails, clients clients.ClientSets) ([]types.ProbeAttributes, error) {
 
-	var Probes []v1alpha1.ProbeAttributes
+	var Probes []types.ProbeAttributes
+
+	// the probes are defined inside the spec file in the standalone mode
+	if standalone.Enabled() {
+		return standalone.Probes(), nil
+	}
 
-	engine, err := clients.LitmusClient.ChaosEngines(chaosDetails.ChaosNamespace).Get(chaosDetails.EngineName, v1.GetOptions{})
+	// the chaosengine is fetched as unstructured, as the typed client drops the probe attributes unknown to the chaos-operator types
+	engine, err := clients.DynamicClient.Resource(engineGVR).Namespace(chaosDetails.ChaosNamespace).Get(chaosDetails.EngineName, v1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("unable to Get the chaosengine, err: %v", err)
 	}
+	data, err := engine.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the chaosengine, err: %v", err)
+	}
+	engineSpec := struct {
+		Spec struct {
+			Experiments []types.Experiment `json:"experiments"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(data, &engineSpec); err != nil {
+		return nil, fmt.Errorf("unable to parse the chaosengine, err: %v", err)
+	}
 
 	// get all the probes defined inside chaosengine for the corresponding experiment
-	experimentSpec := engine.Spec.Experiments
+	experimentSpec := engineSpec.Spec.Experiments
 	for _, experiment := range experimentSpec {
 
 		if experiment.Name == chaosDetails.ExperimentName {
@@ -122,13 +186,19 @@ func GetProbesFromEngine(chaosDetails *types.ChaosDetails, clients clients.Clien
 // it fetch the probe details from the chaosengine and set into the chaosresult
 func InitializeProbesInChaosResultDetails(chaosDetails *types.ChaosDetails, clients clients.ClientSets, chaosresult *types.ResultDetails) error {
 
-	probeDetails := []types.ProbeDetails{}
 	// get the probes from the chaosengine
 	probes, err := GetProbesFromEngine(chaosDetails, clients)
 	if err != nil {
 		return err
 	}
+	InitializeProbes(probes, chaosresult)
+	return nil
+}
+
+// InitializeProbes sets the initial status of the given probes inside the chaosresult details
+func InitializeProbes(probes []types.ProbeAttributes, chaosresult *types.ResultDetails) {
 
+	probeDetails := []types.ProbeDetails{}
 	// set the probe details for k8s probe
 	for _, probe := range probes {
 		tempProbe := types.ProbeDetails{}
@@ -141,13 +211,15 @@ func InitializeProbesInChaosResultDetails(chaosDetails *types.ChaosDetails, clie
 
 	chaosresult.ProbeDetails = probeDetails
 	chaosresult.ProbeArtifacts = map[string]types.ProbeArtifact{}
-	return nil
 }
 
 //getAndIncrementRunCount return the run count for the specified probe
 func getAndIncrementRunCount(resultDetails *types.ResultDetails, probeName string) int {
-	for index, probe := range resultDetails.ProbeDetails {
-		if probeName == probe.Name {
+	artifactsMu.Lock()
+	defer artifactsMu.Unlock()
+
+	for index := range resultDetails.ProbeDetails {
+		if probeName == resultDetails.ProbeDetails[index].Name {
 			resultDetails.ProbeDetails[index].RunCount++
 			return resultDetails.ProbeDetails[index].RunCount
 		}
@@ -208,6 +280,8 @@ func SetRunIDForProbe(resultDetails *types.ResultDetails, probeName, probeType,
 
 // MarkedVerdictInEnd add the probe status in the chaosresult
 func MarkedVerdictInEnd(err error, resultDetails *types.ResultDetails, probeName, mode, probeType, phase string) error {
+	metrics.ProbeCompleted(probeName, mode, err)
+
 	// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 	if err != nil {
 		log.ErrorWithValues("[Probe]: "+probeName+" probe has been Failed "+emoji.Sprint(":cry:"), logrus.Fields{
@@ -216,6 +290,10 @@ func MarkedVerdictInEnd(err error, resultDetails *types.ResultDetails, probeName
 			"ProbeInstance": phase,
 			"ProbeStatus":   "Failed",
 		})
+		// the failures of the continuous & onchaos probes are already notified, once they are recorded
+		if err != CheckForErrorInContinuousProbe(resultDetails, probeName) {
+			notifyFailure(probeName, probeType, phase, err)
+		}
 		return err
 	}
 
@@ -243,22 +321,47 @@ func MarkedVerdictInEnd(err error, resultDetails *types.ResultDetails, probeName
 	return nil
 }
 
+// notifyFailure sends the probe failure notification, as soon as the probe fails
+func notifyFailure(probeName, probeType, instance string, err error) {
+	notify.Send(notify.Notification{
+		Event:   notify.ProbeFailureEvent,
+		Message: "The " + probeName + " " + probeType + " has been failed (" + instance + "), err: " + err.Error(),
+		Probe:   probeName,
+	})
+}
+
 //CheckForErrorInContinuousProbe check for the error in the continuous probes
 func CheckForErrorInContinuousProbe(resultDetails *types.ResultDetails, probeName string) error {
 
-	for index, probe := range resultDetails.ProbeDetails {
-		if probe.Name == probeName {
+	for index := range resultDetails.ProbeDetails {
+		if resultDetails.ProbeDetails[index].Name == probeName {
 			return resultDetails.ProbeDetails[index].IsProbeFailedWithError
 		}
 	}
 	return nil
 }
 
+// setProbeArtifact stores the output of the probe, so that the other probes can use it
+func setProbeArtifact(resultDetails *types.ResultDetails, probeName, register string) {
+	artifactsMu.Lock()
+	defer artifactsMu.Unlock()
+
+	probes := types.ProbeArtifact{}
+	probes.ProbeArtifacts.Register = register
+	resultDetails.ProbeArtifacts[probeName] = probes
+}
+
 // ParseCommand parse the templated command and replace the templated value by actual value
 // if command doesn't have template, it will return the same command
 func ParseCommand(templatedCommand string, resultDetails *types.ResultDetails) (string, error) {
 
-	register := resultDetails.ProbeArtifacts
+	// the artifacts are copied, as the other probes may update them in parallel
+	artifactsMu.Lock()
+	register := make(map[string]types.ProbeArtifact, len(resultDetails.ProbeArtifacts))
+	for name, artifact := range resultDetails.ProbeArtifacts {
+		register[name] = artifact
+	}
+	artifactsMu.Unlock()
 
 	t := template.Must(template.New("t1").Parse(templatedCommand))
 
@@ -279,3 +382,13 @@ func Getenv(key string, defaultValue string) string {
 	}
 	return value
 }
+
+// probeRetry returns the retry model of the probe, it retries for the retry count
+// each iteration polls the probe after every interval until it succeeds or the probe timeout expires
+// and it waits for the interval between the iterations
+func probeRetry(probe types.ProbeAttributes) *retry.Model {
+	return retry.Times(uint(probe.RunProperties.Retry)).
+		AttemptTimeout(time.Duration(probe.RunProperties.ProbeTimeout) * time.Second).
+		Poll(time.Duration(probe.RunProperties.Interval) * time.Second).
+		Wait(time.Duration(probe.RunProperties.Interval) * time.Second)
+}
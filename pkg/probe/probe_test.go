@@ -0,0 +1,137 @@
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestMarkedVerdictInEnd(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		mode        string
+		phase       string
+		wantCount   int
+		wantStatus  string
+		statusKey   string
+		wantErr     bool
+		wantAwaited bool
+	}{
+		{name: "failed probe", err: errors.New("probe failed"), mode: "SOT", phase: "PreChaos", statusKey: "PreChaos", wantErr: true, wantAwaited: true},
+		{name: "SOT probe", mode: "SOT", phase: "PreChaos", statusKey: "PreChaos", wantCount: 1, wantStatus: "Passed"},
+		{name: "EOT probe", mode: "EOT", phase: "PostChaos", statusKey: "PostChaos", wantCount: 1, wantStatus: "Passed"},
+		{name: "edge probe in pre-chaos", mode: "Edge", phase: "PreChaos", statusKey: "PreChaos", wantCount: 0, wantStatus: "Passed"},
+		{name: "edge probe in post-chaos", mode: "Edge", phase: "PostChaos", statusKey: "PostChaos", wantCount: 1, wantStatus: "Passed"},
+		{name: "continuous probe in pre-chaos", mode: "Continuous", phase: "PreChaos", statusKey: "Continuous", wantCount: 0, wantStatus: "Passed"},
+		{name: "continuous probe in post-chaos", mode: "Continuous", phase: "PostChaos", statusKey: "Continuous", wantCount: 1, wantStatus: "Passed"},
+		{name: "onchaos probe during chaos", mode: "OnChaos", phase: "DuringChaos", statusKey: "OnChaos", wantCount: 0, wantStatus: "Passed"},
+		{name: "onchaos probe in post-chaos", mode: "OnChaos", phase: "PostChaos", statusKey: "OnChaos", wantCount: 1, wantStatus: "Passed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resultDetails := &types.ResultDetails{
+				ProbeDetails: []types.ProbeDetails{
+					{
+						Name:   "check-app",
+						Type:   "httpProbe",
+						Status: map[string]string{tt.statusKey: "Awaited"},
+					},
+				},
+			}
+
+			err := MarkedVerdictInEnd(tt.err, resultDetails, "check-app", tt.mode, "httpProbe", tt.phase)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("expected error: %v, found %v", tt.wantErr, err)
+			}
+			if resultDetails.PassedProbeCount != tt.wantCount {
+				t.Errorf("expected %v passed probes, found %v", tt.wantCount, resultDetails.PassedProbeCount)
+			}
+			status := resultDetails.ProbeDetails[0].Status[tt.statusKey]
+			if tt.wantAwaited && status != "Awaited" {
+				t.Errorf("expected the failed probe to be awaited, found %v", status)
+			}
+			if tt.wantStatus != "" && !strings.HasPrefix(status, tt.wantStatus) {
+				t.Errorf("expected %v status, found %v", tt.wantStatus, status)
+			}
+		})
+	}
+}
+
+func TestMarkedVerdictInEndScore(t *testing.T) {
+	// the edge probe is counted once, after passing in both pre & post chaos
+	resultDetails := &types.ResultDetails{
+		ProbeDetails: []types.ProbeDetails{
+			{Name: "edge", Type: "cmdProbe", Status: map[string]string{"PreChaos": "Awaited", "PostChaos": "Awaited"}},
+			{Name: "sot", Type: "k8sProbe", Status: map[string]string{"PreChaos": "Awaited"}},
+			{Name: "eot", Type: "promProbe", Status: map[string]string{"PostChaos": "Awaited"}},
+		},
+	}
+
+	steps := []struct {
+		probeName, mode, probeType, phase string
+		err                               error
+	}{
+		{"edge", "Edge", "cmdProbe", "PreChaos", nil},
+		{"sot", "SOT", "k8sProbe", "PreChaos", nil},
+		{"edge", "Edge", "cmdProbe", "PostChaos", nil},
+		{"eot", "EOT", "promProbe", "PostChaos", errors.New("query failed")},
+	}
+	for _, step := range steps {
+		MarkedVerdictInEnd(step.err, resultDetails, step.probeName, step.mode, step.probeType, step.phase)
+	}
+
+	if resultDetails.PassedProbeCount != 2 {
+		t.Fatalf("expected 2 passed probes, found %v", resultDetails.PassedProbeCount)
+	}
+}
+
+func TestGetProbesFromEngine(t *testing.T) {
+	engine := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "litmuschaos.io/v1alpha1",
+		"kind":       "ChaosEngine",
+		"metadata":   map[string]interface{}{"name": "nginx-chaos", "namespace": "litmus"},
+		"spec": map[string]interface{}{
+			"experiments": []interface{}{
+				map[string]interface{}{
+					"name": "pod-delete",
+					"spec": map[string]interface{}{
+						"probe": []interface{}{
+							map[string]interface{}{
+								"name": "check-frontend",
+								"type": "httpProbe",
+								"mode": "Continuous",
+								"runProperties": map[string]interface{}{
+									"probeTimeout":  int64(5),
+									"retry":         int64(2),
+									"stopOnFailure": true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	clients := clients.NewFakeClientSets(nil, nil, []runtime.Object{engine})
+	chaosDetails := &types.ChaosDetails{ChaosNamespace: "litmus", EngineName: "nginx-chaos", ExperimentName: "pod-delete"}
+
+	probes, err := GetProbesFromEngine(chaosDetails, clients)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(probes) != 1 {
+		t.Fatalf("expected 1 probe, found %v", len(probes))
+	}
+	// the attributes of both the chaos-operator and the extended schema are decoded
+	runProperties := probes[0].RunProperties
+	if probes[0].Name != "check-frontend" || runProperties.ProbeTimeout != 5 || runProperties.Retry != 2 || !runProperties.StopOnFailure {
+		t.Fatalf("unexpected probe attributes: %+v", probes[0])
+	}
+}
@@ -1,9 +1,9 @@
 package probe
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"os/exec"
+	"io/ioutil"
 	"strings"
 	"time"
 
@@ -13,26 +13,26 @@ import (
 	"github.com/litmuschaos/litmus-go/pkg/math"
 	cmp "github.com/litmuschaos/litmus-go/pkg/probe/comparator"
 	"github.com/litmuschaos/litmus-go/pkg/types"
-	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 // PreparePromProbe contains the steps to prepare the prometheus probe
 // which compares the metrices output exposed at the given endpoint
-func PreparePromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+func PreparePromProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
 
 	switch phase {
 	case "PreChaos":
-		if err := PreChaosPromProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PreChaosPromProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "PostChaos":
-		if err := PostChaosPromProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := PostChaosPromProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	case "DuringChaos":
-		if err := OnChaosPromProbe(probe, resultDetails, clients, chaosDetails); err != nil {
+		if err := OnChaosPromProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
 			return err
 		}
 	default:
@@ -42,7 +42,8 @@ func PreparePromProbe(probe v1alpha1.ProbeAttributes, clients clients.ClientSets
 }
 
 //PreChaosPromProbe trigger the prometheus probe for prechaos phase
-func PreChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PreChaosPromProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "SOT", "Edge":
@@ -61,11 +62,13 @@ func PreChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 
 		// triggering the prom probe and storing the output into the out buffer
-		err = TriggerPromProbe(probe, resultDetails)
+		err = TriggerPromProbe(ctx, probe, clients, chaosDetails, resultDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
@@ -87,14 +90,15 @@ func PreChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resu
 		})
 
 		// trigger the continuous cmd probe
-		go TriggerContinuousPromProbe(probe, resultDetails)
+		go TriggerContinuousPromProbe(ctx, probe, clients, chaosDetails, resultDetails)
 	}
 
 	return nil
 }
 
 //PostChaosPromProbe trigger the prometheus probe for postchaos phase
-func PostChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func PostChaosPromProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
 
 	switch probe.Mode {
 	case "EOT", "Edge":
@@ -113,11 +117,13 @@ func PostChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 		// waiting for initial delay
 		if probe.RunProperties.InitialDelaySeconds != 0 {
 			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-			time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
 		}
 
 		// triggering the prom probe and storing the output into the out buffer
-		err = TriggerPromProbe(probe, resultDetails)
+		err = TriggerPromProbe(ctx, probe, clients, chaosDetails, resultDetails)
 
 		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
 		// it will update the status of all the unrun probes as well
@@ -140,7 +146,7 @@ func PostChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Res
 }
 
 //OnChaosPromProbe trigger the prom probe for DuringChaos phase
-func OnChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func OnChaosPromProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	switch probe.Mode {
 	case "OnChaos":
@@ -157,98 +163,218 @@ func OnChaosPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.Resul
 		})
 
 		// trigger the continuous prom probe
-		go TriggerOnChaosPromProbe(probe, resultDetails, chaosDetails.ChaosDuration)
+		go TriggerOnChaosPromProbe(ctx, probe, clients, chaosDetails, resultDetails, chaosDetails.ChaosDuration)
 	}
 	return nil
 }
 
-// TriggerPromProbe trigger the prometheus probe inside the external pod
-func TriggerPromProbe(probe v1alpha1.ProbeAttributes, resultDetails *types.ResultDetails) error {
+// TriggerPromProbe trigger the prometheus probe, which queries the prometheus http api
+func TriggerPromProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+	var err error
 
-	// running the prom probe command and matching the output
-	// it will retry for some retry count, in each iterations of try it contains following things
-	// it contains a timeout per iteration of retry. if the timeout expires without success then it will go to next try
-	// for a timeout, it will run the command, if it fails wait for the interval and again execute the command until timeout expires
-	return retry.Times(uint(probe.RunProperties.Retry)).
-		Timeout(int64(probe.RunProperties.ProbeTimeout)).
-		Wait(time.Duration(probe.RunProperties.Interval) * time.Second).
-		TryWithTimeout(func(attempt uint) error {
-			var command string
-			// It will use query or queryPath to get the prometheus metrics
-			// if both are provided, it will use query
-			if probe.PromProbeInputs.Query != "" {
-				command = "promql --host " + probe.PromProbeInputs.Endpoint + " \"" + probe.PromProbeInputs.Query + "\"" + " --output csv"
-			} else if probe.PromProbeInputs.QueryPath != "" {
-				command = "promql --host " + probe.PromProbeInputs.Endpoint + " \"$(cat " + probe.PromProbeInputs.QueryPath + ")\"" + " --output csv"
-			} else {
-				return errors.Errorf("[Probe]: Any one of query or queryPath is required")
-			}
+	inputs := probe.PromProbeInputs
 
-			var out, errOut bytes.Buffer
-			// run the inline command probe
-			cmd := exec.Command("/bin/sh", "-c", command)
-			cmd.Stdout = &out
-			cmd.Stderr = &errOut
-			if err := cmd.Run(); err != nil {
-				return fmt.Errorf("unable to run command, err: %v; error output: %v", err, errOut.String())
-			}
+	// It will use query or queryPath to get the prometheus metrics
+	// if both are provided, it will use query
+	query := inputs.Query
+	if query == "" {
+		if inputs.QueryPath == "" {
+			return errors.Errorf("[Probe]: Any one of query or queryPath is required")
+		}
+		data, err := ioutil.ReadFile(inputs.QueryPath)
+		if err != nil {
+			return errors.Errorf("unable to read the query from %v, err: %v", inputs.QueryPath, err)
+		}
+		query = strings.TrimSpace(string(data))
+	}
+
+	// It parse the templated query and return normal string
+	// if query doesn't have template, it will return the same query
+	if query, err = ParseCommand(query, resultDetails); err != nil {
+		return err
+	}
+
+	match := strings.ToLower(inputs.Match)
+	switch match {
+	case "":
+		match = "all"
+	case "all", "any":
+	default:
+		return errors.Errorf("[Probe]: match '%v' not supported in the prom probe", inputs.Match)
+	}
+
+	queryRange, err := getPromRange(inputs, chaosDetails.ChaosWindow)
+	if err != nil {
+		return err
+	}
 
-			// extract the values from the metrics
-			value, err := ExtractValueFromMetrics(strings.TrimSpace(out.String()))
+	// initialize the http client & the headers with the auth and the tls config, if provided
+	httpInputs := types.HTTPProbeInputs{Auth: inputs.Auth, TLS: inputs.TLS}
+	httpInputs.ResponseTimeout = inputs.ResponseTimeout
+	client, err := getHTTPClient(httpInputs, clients, chaosDetails.ChaosNamespace)
+	if err != nil {
+		return err
+	}
+	headers, err := getHTTPHeaders(httpInputs, clients, chaosDetails.ChaosNamespace)
+	if err != nil {
+		return err
+	}
+
+	// it will retry for some retry count, in each iterations of try it contains following things
+	// each iteration of retry is bounded by the probe timeout, the query is cancelled once the timeout expires
+	// if it fails, it will wait for the interval and run the query again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
+			series, err := queryPrometheus(ctx, client, headers, inputs.Endpoint, query, queryRange)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			rc := getAndIncrementRunCount(resultDetails, probe.Name)
-			// comparing the metrics output with the expected criteria
-			if err = cmp.RunCount(rc).
-				FirstValue(value).
-				SecondValue(probe.PromProbeInputs.Comparator.Value).
-				Criteria(probe.PromProbeInputs.Comparator.Criteria).
-				CompareFloat(); err != nil {
+			// comparing the values of the series with the expected criteria
+			if err = validatePromSeries(series, inputs.Comparator, match, rc); err != nil {
 				log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
-				return err
+				return nil, err
 			}
-			return nil
+
+			// storing the latest value of every series, so that the other probes can use it
+			var values []string
+			for _, s := range series {
+				values = append(values, s.values[len(s.values)-1])
+			}
+			return strings.Join(values, ","), nil
 		})
+	if err != nil {
+		return err
+	}
+	setProbeArtifact(resultDetails, probe.Name, register.(string))
+	return nil
+}
+
+// getPromRange returns the chaos window as the time range of the range query
+// it returns nil for the instant query
+func getPromRange(inputs types.PromProbeInputs, window types.ChaosWindow) (*promRange, error) {
+	switch strings.ToLower(inputs.QueryType) {
+	case "", "instant":
+		return nil, nil
+	case "range":
+	default:
+		return nil, errors.Errorf("[Probe]: queryType '%v' not supported in the prom probe", inputs.QueryType)
+	}
+
+	step := 15 * time.Second
+	if inputs.Step != "" {
+		var err error
+		if step, err = time.ParseDuration(inputs.Step); err != nil || step <= 0 {
+			return nil, errors.Errorf("[Probe]: invalid step '%v' of the range query", inputs.Step)
+		}
+	}
+
+	// the range query is evaluated till now, if the chaos is still running
+	start, err := time.Parse(time.RFC3339, window.StartTime)
+	if err != nil {
+		return nil, errors.Errorf("[Probe]: the range query needs the chaos window, the chaos hasn't been injected yet")
+	}
+	end := time.Now()
+	if window.EndTime != "" {
+		if end, err = time.Parse(time.RFC3339, window.EndTime); err != nil {
+			return nil, errors.Errorf("unable to parse the end time of the chaos window, err: %v", err)
+		}
+	}
+	return &promRange{start: start, end: end, step: step}, nil
+}
+
+// validatePromSeries verify the values of the series with the comparator
+// all or any of the series must satisfy it, and every sample of the series must satisfy it for the range query
+func validatePromSeries(series []promSeries, comparator v1alpha1.ComparatorInfo, match string, rc int) error {
+	if len(series) == 0 {
+		return errors.Errorf("the query didn't return any series")
+	}
+
+	var failures []string
+	for _, s := range series {
+		err := validatePromValues(s.values, comparator, rc)
+		// logging the comparison of the first value only
+		rc = 0
+		switch {
+		case err == nil && match == "any":
+			return nil
+		case err != nil && match == "all":
+			return errors.Errorf("series %v: %v", seriesName(s.labels), err)
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("series %v: %v", seriesName(s.labels), err))
+		}
+	}
+	if len(failures) != 0 {
+		return errors.Errorf("none of the series satisfied the criteria, %v", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// validatePromValues verify that every value of the series satisfy the comparator
+func validatePromValues(values []string, comparator v1alpha1.ComparatorInfo, rc int) error {
+	if len(values) == 0 {
+		return errors.Errorf("the series doesn't contain any sample")
+	}
+	for _, value := range values {
+		if err := cmp.RunCount(rc).
+			FirstValue(value).
+			SecondValue(comparator.Value).
+			Criteria(comparator.Criteria).
+			CompareFloat(); err != nil {
+			return err
+		}
+		rc = 0
+	}
+	return nil
 }
 
 // TriggerContinuousPromProbe trigger the continuous prometheus probe
-func TriggerContinuousPromProbe(probe v1alpha1.ProbeAttributes, chaosresult *types.ResultDetails) {
+func TriggerContinuousPromProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, chaosresult *types.ResultDetails) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 	}
 
 	// it trigger the prom probe for the entire duration of chaos and it fails, if any err encounter
 	// it marked the error for the probes, if any
 loop:
 	for {
-		err = TriggerPromProbe(probe, chaosresult)
+		err = TriggerPromProbe(ctx, probe, clients, chaosDetails, chaosresult)
 		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
 		if err != nil {
 			for index := range chaosresult.ProbeDetails {
 				if chaosresult.ProbeDetails[index].Name == probe.Name {
 					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 					log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
 					break loop
 				}
 			}
 		}
 		// waiting for the probe polling interval
-		time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
 	}
 }
 
 // TriggerOnChaosPromProbe trigger the onchaos prom probe
-func TriggerOnChaosPromProbe(probe v1alpha1.ProbeAttributes, chaosresult *types.ResultDetails, duration int) {
+func TriggerOnChaosPromProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, chaosresult *types.ResultDetails, duration int) {
+	var err error
 
 	// waiting for initial delay
 	if probe.RunProperties.InitialDelaySeconds != 0 {
 		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
-		time.Sleep(time.Duration(probe.RunProperties.InitialDelaySeconds) * time.Second)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
 		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
 	}
 
@@ -267,52 +393,21 @@ loop:
 			break loop
 		default:
 			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
-			if err = TriggerPromProbe(probe, chaosresult); err != nil {
+			if err = TriggerPromProbe(ctx, probe, clients, chaosDetails, chaosresult); err != nil {
 				for index := range chaosresult.ProbeDetails {
 					if chaosresult.ProbeDetails[index].Name == probe.Name {
 						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
 						log.Errorf("The %v prom probe has been Failed, err: %v", probe.Name, err)
+						haltOnFailure(ctx, probe)
 						break loop
 					}
 				}
 			}
 			// waiting for the probe polling interval
-			time.Sleep(time.Duration(probe.RunProperties.ProbePollingInterval) * time.Second)
-		}
-	}
-}
-
-// ExtractValueFromMetrics extract the value field from the prometheus metrix
-func ExtractValueFromMetrics(metrics string) (string, error) {
-
-	// spliting the metrics based on newline as metrics may have multiple entries
-	rows := strings.Split(metrics, "\n")
-
-	// output should contains exact one metrics entry along with header
-	// erroring out the cases where it contains more or less entries
-	if len(rows) > 2 {
-		return "", errors.Errorf("metrics entries can't be more than two")
-	} else if len(rows) < 2 {
-		return "", errors.Errorf("metrics doesn't contains required values")
-	}
-
-	// deriving the index for the value column from the headers
-	headerColumn := strings.Split(rows[0], ",")
-	indexForValueColumn := -1
-	for index := range headerColumn {
-		if strings.ToLower(headerColumn[index]) == "value" {
-			indexForValueColumn = index
-			break
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
 		}
 	}
-	if indexForValueColumn == -1 {
-		return "", errors.Errorf("metrics entries doesn't contains value column")
-	}
-
-	// splitting the metrics entries which are availble as comma separated
-	values := strings.Split(rows[1], ",")
-	if values[indexForValueColumn] == "" {
-		return "", errors.Errorf("error while parsing value from derived matrics")
-	}
-	return values[indexForValueColumn], nil
 }
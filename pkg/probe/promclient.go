@@ -0,0 +1,142 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// promSeries contains the labels and the values of a series of the query result
+// it contains a single value for the instant query and all the samples for the range query
+type promSeries struct {
+	labels map[string]string
+	values []string
+}
+
+// promRange contains the time range and the resolution of the range query
+type promRange struct {
+	start time.Time
+	end   time.Time
+	step  time.Duration
+}
+
+// promResponse is the response of the prometheus http api
+type promResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// promSample is the [<timestamp>, "<value>"] pair of the query result
+type promSample [2]interface{}
+
+// queryPrometheus runs the query with the /api/v1/query api or with the /api/v1/query_range api, if the range is provided
+func queryPrometheus(ctx context.Context, client *http.Client, headers http.Header, endpoint, query string, queryRange *promRange) ([]promSeries, error) {
+	form := url.Values{"query": {query}}
+	api := "/api/v1/query"
+	if queryRange != nil {
+		api = "/api/v1/query_range"
+		form.Set("start", strconv.FormatInt(queryRange.start.Unix(), 10))
+		form.Set("end", strconv.FormatInt(queryRange.end.Unix(), 10))
+		form.Set("step", strconv.FormatFloat(queryRange.step.Seconds(), 'f', -1, 64))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(endpoint, "/")+api, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Errorf("unable to query the prometheus, err: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("unable to read the prometheus response, err: %v", err)
+	}
+
+	result := promResponse{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, errors.Errorf("unable to parse the prometheus response, status code: %v, err: %v", resp.StatusCode, err)
+	}
+	if result.Status != "success" {
+		return nil, errors.Errorf("prometheus query failed, status code: %v, err: %v: %v", resp.StatusCode, result.ErrorType, result.Error)
+	}
+	return parsePromResult(result.Data.ResultType, result.Data.Result)
+}
+
+// parsePromResult returns the series of the query result
+// it supports the vector, matrix, scalar and string result types
+func parsePromResult(resultType string, data json.RawMessage) ([]promSeries, error) {
+	switch resultType {
+	case "vector":
+		var vector []struct {
+			Metric map[string]string `json:"metric"`
+			Value  promSample        `json:"value"`
+		}
+		if err := json.Unmarshal(data, &vector); err != nil {
+			return nil, errors.Errorf("unable to parse the vector result, err: %v", err)
+		}
+		series := make([]promSeries, 0, len(vector))
+		for _, v := range vector {
+			series = append(series, promSeries{labels: v.Metric, values: []string{sampleValue(v.Value)}})
+		}
+		return series, nil
+	case "matrix":
+		var matrix []struct {
+			Metric map[string]string `json:"metric"`
+			Values []promSample      `json:"values"`
+		}
+		if err := json.Unmarshal(data, &matrix); err != nil {
+			return nil, errors.Errorf("unable to parse the matrix result, err: %v", err)
+		}
+		series := make([]promSeries, 0, len(matrix))
+		for _, m := range matrix {
+			s := promSeries{labels: m.Metric}
+			for _, sample := range m.Values {
+				s.values = append(s.values, sampleValue(sample))
+			}
+			series = append(series, s)
+		}
+		return series, nil
+	case "scalar", "string":
+		var sample promSample
+		if err := json.Unmarshal(data, &sample); err != nil {
+			return nil, errors.Errorf("unable to parse the %v result, err: %v", resultType, err)
+		}
+		return []promSeries{{values: []string{sampleValue(sample)}}}, nil
+	default:
+		return nil, errors.Errorf("result type '%v' not supported in the prom probe", resultType)
+	}
+}
+
+// sampleValue returns the value of the sample
+func sampleValue(sample promSample) string {
+	value, _ := sample[1].(string)
+	return value
+}
+
+// seriesName returns the name of the series, derived from its labels
+func seriesName(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "{}"
+	}
+	data, _ := json.Marshal(labels)
+	return string(data)
+}
@@ -0,0 +1,117 @@
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTriggerPromProbe(t *testing.T) {
+	start := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/v1/query" && r.Form.Get("query") == "error_rate":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"service":"cart"},"value":[1600000000,"0.2"]},
+				{"metric":{"service":"checkout"},"value":[1600000000,"3.5"]}]}}`))
+		case r.URL.Path == "/api/v1/query" && r.Form.Get("query") == "scalar(up)":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1600000000,"1"]}}`))
+		case r.URL.Path == "/api/v1/query_range":
+			if r.Form.Get("start") != strconv.FormatInt(start.Unix(), 10) || r.Form.Get("step") != "30" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"unexpected range"}`))
+				return
+			}
+			w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+				{"metric":{"service":"cart"},"values":[[1600000000,"0.2"],[1600000030,"0.8"],[1600000060,"1.4"]]}]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		}
+	}))
+	defer server.Close()
+
+	less := func(value string) v1alpha1.ComparatorInfo {
+		return v1alpha1.ComparatorInfo{Type: "float", Criteria: "<", Value: value}
+	}
+	tests := []struct {
+		name         string
+		inputs       types.PromProbeInputs
+		wantErr      bool
+		wantArtifact string
+	}{
+		{name: "all series", inputs: promInputs("error_rate", less("5"), "", ""), wantArtifact: "0.2,3.5"},
+		{name: "all series failed", inputs: promInputs("error_rate", less("1"), "", ""), wantErr: true},
+		{name: "any series", inputs: promInputs("error_rate", less("1"), "any", ""), wantArtifact: "0.2,3.5"},
+		{name: "none of the series", inputs: promInputs("error_rate", less("0.1"), "any", ""), wantErr: true},
+		{name: "scalar", inputs: promInputs("scalar(up)", v1alpha1.ComparatorInfo{Criteria: "==", Value: "1"}, "", ""), wantArtifact: "1"},
+		{name: "range over the chaos window", inputs: promInputs("error_rate", less("2"), "", "range"), wantArtifact: "1.4"},
+		{name: "range failed for a sample", inputs: promInputs("error_rate", less("1"), "", "range"), wantErr: true},
+		{name: "query error", inputs: promInputs("rate(", less("1"), "", ""), wantErr: true},
+		{name: "unsupported match", inputs: promInputs("error_rate", less("1"), "some", ""), wantErr: true},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus-credentials", Namespace: "litmus"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	fakeClients := clients.NewFakeClientSets([]runtime.Object{secret}, nil, nil)
+	chaosDetails := &types.ChaosDetails{
+		ChaosNamespace: "litmus",
+		ChaosWindow:    types.ChaosWindow{StartTime: start.Format(time.RFC3339), EndTime: start.Add(time.Minute).Format(time.RFC3339)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := types.ProbeAttributes{PromProbeInputs: tt.inputs}
+			probe.Name = "check-error-rate"
+			probe.Type = "promProbe"
+			probe.PromProbeInputs.Endpoint = server.URL
+			probe.PromProbeInputs.Step = "30s"
+			probe.PromProbeInputs.Auth.BearerToken = &types.ValueSource{SecretKeyRef: &types.SecretKeyRef{Name: "prometheus-credentials", Key: "token"}}
+			probe.RunProperties.ProbeTimeout = 2
+
+			resultDetails := &types.ResultDetails{
+				ProbeDetails:   []types.ProbeDetails{{Name: probe.Name, Type: probe.Type}},
+				ProbeArtifacts: map[string]types.ProbeArtifact{},
+			}
+			err := TriggerPromProbe(context.Background(), probe, fakeClients, chaosDetails, resultDetails)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("expected error: %v, found %v", tt.wantErr, err)
+			}
+			if got := resultDetails.ProbeArtifacts[probe.Name].ProbeArtifacts.Register; got != tt.wantArtifact {
+				t.Errorf("expected %q inside the probe artifacts, found %q", tt.wantArtifact, got)
+			}
+		})
+	}
+}
+
+func TestPromRangeWithoutChaosWindow(t *testing.T) {
+	inputs := types.PromProbeInputs{QueryType: "range"}
+	if _, err := getPromRange(inputs, types.ChaosWindow{}); err == nil {
+		t.Fatal("expected the range query to fail without the chaos window")
+	}
+}
+
+func promInputs(query string, comparator v1alpha1.ComparatorInfo, match, queryType string) types.PromProbeInputs {
+	inputs := types.PromProbeInputs{Match: match, QueryType: queryType}
+	inputs.Query = query
+	inputs.Comparator = comparator
+	return inputs
+}
@@ -0,0 +1,401 @@
+package probe
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	// registers the postgres driver
+	_ "github.com/lib/pq"
+)
+
+// PrepareSQLProbe contains the steps to prepare the sql probe
+// sql probe can be used to add the probe which will run the query on the database and match the first column of the first row
+func PrepareSQLProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+
+	switch phase {
+	case "PreChaos":
+		if err := PreChaosSQLProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "PostChaos":
+		if err := PostChaosSQLProbe(ctx, probe, resultDetails, clients, chaosDetails); err != nil {
+			return err
+		}
+	case "DuringChaos":
+		OnChaosSQLProbe(ctx, probe, resultDetails, clients, chaosDetails)
+	default:
+		return errors.Errorf("phase '%s' not supported in the sql probe", phase)
+	}
+	return nil
+}
+
+// TriggerSQLProbe run the sql probe
+func TriggerSQLProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) error {
+	var err error
+
+	inputs := probe.SQLProbeInputs
+
+	// It parse the templated query and return normal string
+	// if it doesn't have template, it will return the same string
+	if inputs.Query, err = ParseCommand(inputs.Query, resultDetails); err != nil {
+		return err
+	}
+	if inputs.Query == "" {
+		return errors.Errorf("[Probe]: query is required for the %v sql probe", probe.Name)
+	}
+	if inputs.Comparator.Type == "" {
+		inputs.Comparator.Type = "string"
+	}
+
+	driver, dataSource, err := getSQLDataSource(inputs, clients, chaosDetails.ChaosNamespace)
+	if err != nil {
+		return err
+	}
+
+	log.InfoWithValues("[Probe]: SQL query informations", logrus.Fields{
+		"Name":            probe.Name,
+		"Driver":          driver,
+		"Query":           inputs.Query,
+		"Criteria":        inputs.Comparator.Criteria,
+		"Value":           inputs.Comparator.Value,
+		"ResponseTimeout": inputs.ResponseTimeout,
+	})
+
+	// it will retry for some retry count, in each iterations of try it contains following things
+	// each iteration of retry is bounded by the probe timeout, the query is cancelled once the timeout expires
+	// if it fails, it will wait for the interval and run the query again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
+			value, err := sqlQuery(ctx, driver, dataSource, inputs)
+			if err != nil {
+				return nil, err
+			}
+
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			// comparing the value with the expected criteria, if provided
+			if inputs.Comparator.Criteria != "" {
+				if err = ValidateResult(inputs.Comparator, value, rc); err != nil {
+					log.Errorf("The %v sql probe has been Failed, err: %v", probe.Name, err)
+					return nil, err
+				}
+			}
+
+			return value, nil
+		})
+	if err != nil {
+		return err
+	}
+
+	// storing the output, so that the other probes can use it
+	setProbeArtifact(resultDetails, probe.Name, register.(string))
+	return nil
+}
+
+// getSQLDataSource returns the name of the driver and the data source of the database
+// the credentials & the data source are read from the secret, if the secret key reference is provided
+func getSQLDataSource(inputs types.SQLProbeInputs, clients clients.ClientSets, namespace string) (string, string, error) {
+
+	var driver string
+	switch strings.ToLower(inputs.Driver) {
+	case "postgres", "postgresql":
+		driver = "postgres"
+	case "mysql":
+		driver = "mysql"
+	default:
+		return "", "", errors.Errorf("driver '%v' not supported in the sql probe", inputs.Driver)
+	}
+
+	dataSource, err := getValue(inputs.DataSource, clients, namespace)
+	if err != nil || dataSource != "" {
+		return driver, dataSource, err
+	}
+
+	if inputs.Endpoint == "" {
+		return "", "", errors.Errorf("endpoint or data source is required for the sql probe")
+	}
+	username, err := getValue(inputs.Username, clients, namespace)
+	if err != nil {
+		return "", "", err
+	}
+	password, err := getValue(inputs.Password, clients, namespace)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch driver {
+	case "postgres":
+		params := url.Values{}
+		for key, value := range inputs.Params {
+			params.Set(key, value)
+		}
+		dataSource := url.URL{
+			Scheme:   "postgres",
+			Host:     inputs.Endpoint,
+			Path:     "/" + inputs.Database,
+			RawQuery: params.Encode(),
+		}
+		if username != "" {
+			dataSource.User = url.UserPassword(username, password)
+		}
+		return driver, dataSource.String(), nil
+	default:
+		config := mysql.NewConfig()
+		config.User = username
+		config.Passwd = password
+		config.Net = "tcp"
+		config.Addr = inputs.Endpoint
+		config.DBName = inputs.Database
+		config.Params = inputs.Params
+		return driver, config.FormatDSN(), nil
+	}
+}
+
+// sqlQuery runs the query and returns the first column of the first row
+func sqlQuery(ctx context.Context, driver, dataSource string, inputs types.SQLProbeInputs) (string, error) {
+	if inputs.ResponseTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(inputs.ResponseTimeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	db, err := sql.Open(driver, dataSource)
+	if err != nil {
+		return "", errors.Errorf("unable to open the %v database, err: %v", driver, err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, inputs.Query)
+	if err != nil {
+		return "", errors.Errorf("unable to run the query, err: %v", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", errors.Errorf("unable to read the columns of the query result, err: %v", err)
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", errors.Errorf("unable to read the query result, err: %v", err)
+		}
+		return "", errors.Errorf("the query didn't return any rows")
+	}
+
+	// all the columns are scanned, as the scan requires the destination of every column
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", errors.Errorf("unable to read the query result, err: %v", err)
+	}
+	if len(values) == 0 || !values[0].Valid {
+		return "", errors.Errorf("the first column of the query result is NULL")
+	}
+	return strings.TrimSpace(values[0].String), nil
+}
+
+// TriggerContinuousSQLProbe trigger the continuous sql probes
+func TriggerContinuousSQLProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, chaosresult *types.ResultDetails) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+	}
+
+	// it trigger the sql probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		err = TriggerSQLProbe(ctx, probe, clients, chaosDetails, chaosresult)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+					log.Errorf("The %v sql probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
+					break loop
+				}
+			}
+		}
+		// waiting for the probe polling interval
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
+	}
+}
+
+//PreChaosSQLProbe trigger the sql probe for prechaos phase
+func PreChaosSQLProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "SOT", "Edge":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         probe.SQLProbeInputs.Driver,
+			"Query":          probe.SQLProbeInputs.Query,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
+		}
+		// trigger the sql probe
+		err = TriggerSQLProbe(ctx, probe, clients, chaosDetails, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PreChaos"); err != nil {
+			return err
+		}
+	case "Continuous":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         probe.SQLProbeInputs.Driver,
+			"Query":          probe.SQLProbeInputs.Query,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+		go TriggerContinuousSQLProbe(ctx, probe, clients, chaosDetails, resultDetails)
+	}
+	return nil
+}
+
+//PostChaosSQLProbe trigger the sql probe for postchaos phase
+func PostChaosSQLProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "EOT", "Edge":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         probe.SQLProbeInputs.Driver,
+			"Query":          probe.SQLProbeInputs.Query,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
+		}
+
+		// trigger the sql probe
+		err = TriggerSQLProbe(ctx, probe, clients, chaosDetails, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err = CheckForErrorInContinuousProbe(resultDetails, probe.Name)
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TriggerOnChaosSQLProbe trigger the onchaos sql probes
+func TriggerOnChaosSQLProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, chaosresult *types.ResultDetails, duration int) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the sql probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			break loop
+		default:
+			err = TriggerSQLProbe(ctx, probe, clients, chaosDetails, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+						haltOnFailure(ctx, probe)
+						break loop
+					}
+				}
+			}
+
+			// waiting for the probe polling interval
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
+		}
+	}
+}
+
+//OnChaosSQLProbe trigger the sql probe for DuringChaos phase
+func OnChaosSQLProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) {
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE SQL PROBE INFO
+		log.InfoWithValues("[Probe]: The sql probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Driver":         probe.SQLProbeInputs.Driver,
+			"Query":          probe.SQLProbeInputs.Query,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go TriggerOnChaosSQLProbe(ctx, probe, clients, chaosDetails, resultDetails, chaosDetails.ChaosDuration)
+	}
+}
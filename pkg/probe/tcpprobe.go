@@ -0,0 +1,346 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// PrepareTCPProbe contains the steps to prepare the tcp probe
+// tcp probe can be used to add the probe which will dial the given endpoint, send the payload and match the response
+func PrepareTCPProbe(ctx context.Context, probe types.ProbeAttributes, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, phase string, eventsDetails *types.EventDetails) error {
+
+	switch phase {
+	case "PreChaos":
+		if err := PreChaosTCPProbe(ctx, probe, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "PostChaos":
+		if err := PostChaosTCPProbe(ctx, probe, resultDetails, chaosDetails); err != nil {
+			return err
+		}
+	case "DuringChaos":
+		OnChaosTCPProbe(ctx, probe, resultDetails, chaosDetails)
+	default:
+		return errors.Errorf("phase '%s' not supported in the tcp probe", phase)
+	}
+	return nil
+}
+
+// TriggerTCPProbe run the tcp probe
+func TriggerTCPProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails) error {
+	var err error
+
+	inputs := probe.TCPProbeInputs
+
+	// It parse the templated endpoint & payload and return normal string
+	// if they don't have template, it will return the same string
+	if inputs.Endpoint, err = ParseCommand(inputs.Endpoint, resultDetails); err != nil {
+		return err
+	}
+	if inputs.Payload, err = ParseCommand(inputs.Payload, resultDetails); err != nil {
+		return err
+	}
+	if inputs.Endpoint == "" {
+		return errors.Errorf("[Probe]: endpoint is required for the %v tcp probe", probe.Name)
+	}
+
+	switch strings.ToLower(inputs.Protocol) {
+	case "", "tcp":
+		inputs.Protocol = "tcp"
+	case "udp":
+		// the udp dial doesn't contact the endpoint, so the probe can only be checked with the response of the payload
+		if inputs.Payload == "" || inputs.Comparator.Criteria == "" {
+			return errors.Errorf("[Probe]: payload & comparator are required for the udp protocol in the %v tcp probe", probe.Name)
+		}
+		inputs.Protocol = "udp"
+	default:
+		return errors.Errorf("[Probe]: protocol '%v' not supported in the %v tcp probe", inputs.Protocol, probe.Name)
+	}
+	if inputs.Comparator.Type == "" {
+		inputs.Comparator.Type = "string"
+	}
+
+	log.InfoWithValues("[Probe]: TCP dial informations", logrus.Fields{
+		"Name":            probe.Name,
+		"Endpoint":        inputs.Endpoint,
+		"Protocol":        inputs.Protocol,
+		"Criteria":        inputs.Comparator.Criteria,
+		"Value":           inputs.Comparator.Value,
+		"ResponseTimeout": inputs.ResponseTimeout,
+	})
+
+	// it will retry for some retry count, in each iterations of try it contains following things
+	// each iteration of retry is bounded by the probe timeout, the connection is closed once the timeout expires
+	// if it fails, it will wait for the interval and dial the endpoint again
+	register, err := probeRetry(probe).
+		DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
+			response, err := tcpDial(ctx, inputs)
+			if err != nil {
+				return nil, err
+			}
+
+			rc := getAndIncrementRunCount(resultDetails, probe.Name)
+			// comparing the response with the expected criteria, if provided
+			if inputs.Comparator.Criteria != "" {
+				if err = ValidateResult(inputs.Comparator, response, rc); err != nil {
+					log.Errorf("The %v tcp probe has been Failed, err: %v", probe.Name, err)
+					return nil, err
+				}
+			}
+
+			return response, nil
+		})
+	if err != nil {
+		return err
+	}
+
+	// storing the output, so that the other probes can use it
+	setProbeArtifact(resultDetails, probe.Name, register.(string))
+	return nil
+}
+
+// tcpDial dials the endpoint and sends the payload, if provided
+// it returns the response (or the banner) with the surrounding whitespaces trimmed, if the comparator is provided
+func tcpDial(ctx context.Context, inputs types.TCPProbeInputs) (string, error) {
+	if inputs.ResponseTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(inputs.ResponseTimeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, inputs.Protocol, inputs.Endpoint)
+	if err != nil {
+		return "", errors.Errorf("unable to dial %v, err: %v", inputs.Endpoint, err)
+	}
+	defer conn.Close()
+
+	// the write & read operations are bounded by the same deadline
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	// close the connection once the context is cancelled, to unblock the pending write & read operations
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if inputs.Payload != "" {
+		if _, err := conn.Write([]byte(inputs.Payload)); err != nil {
+			return "", errors.Errorf("unable to send the payload to %v, err: %v", inputs.Endpoint, err)
+		}
+	}
+	if inputs.Comparator.Criteria == "" {
+		return "", nil
+	}
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if n == 0 && err != nil {
+		return "", errors.Errorf("unable to read the response from %v, err: %v", inputs.Endpoint, err)
+	}
+	return strings.TrimSpace(string(buf[:n])), nil
+}
+
+// TriggerContinuousTCPProbe trigger the continuous tcp probes
+func TriggerContinuousTCPProbe(ctx context.Context, probe types.ProbeAttributes, chaosresult *types.ResultDetails) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+	}
+
+	// it trigger the tcp probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		err = TriggerTCPProbe(ctx, probe, chaosresult)
+		// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+		if err != nil {
+			for index := range chaosresult.ProbeDetails {
+				if chaosresult.ProbeDetails[index].Name == probe.Name {
+					chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+					notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+					log.Errorf("The %v tcp probe has been Failed, err: %v", probe.Name, err)
+					haltOnFailure(ctx, probe)
+					break loop
+				}
+			}
+		}
+		// waiting for the probe polling interval
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+			break loop
+		}
+	}
+}
+
+//PreChaosTCPProbe trigger the tcp probe for prechaos phase
+func PreChaosTCPProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "SOT", "Edge":
+
+		//DISPLAY THE TCP PROBE INFO
+		log.InfoWithValues("[Probe]: The tcp probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.TCPProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
+		}
+		// trigger the tcp probe
+		err = TriggerTCPProbe(ctx, probe, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PreChaos"); err != nil {
+			return err
+		}
+	case "Continuous":
+
+		//DISPLAY THE TCP PROBE INFO
+		log.InfoWithValues("[Probe]: The tcp probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.TCPProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PreChaos",
+		})
+		go TriggerContinuousTCPProbe(ctx, probe, resultDetails)
+	}
+	return nil
+}
+
+//PostChaosTCPProbe trigger the tcp probe for postchaos phase
+func PostChaosTCPProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) error {
+	var err error
+
+	switch probe.Mode {
+	case "EOT", "Edge":
+
+		//DISPLAY THE TCP PROBE INFO
+		log.InfoWithValues("[Probe]: The tcp probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.TCPProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "PostChaos",
+		})
+
+		// waiting for initial delay
+		if probe.RunProperties.InitialDelaySeconds != 0 {
+			log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+				return err
+			}
+		}
+
+		// trigger the tcp probe
+		err = TriggerTCPProbe(ctx, probe, resultDetails)
+
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		// it will update the status of all the unrun probes as well
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	case "Continuous", "OnChaos":
+		// it will check for the error, It will detect the error if any error encountered in probe during chaos
+		err = CheckForErrorInContinuousProbe(resultDetails, probe.Name)
+		// failing the probe, if the success condition doesn't met after the retry & timeout combinations
+		if err = MarkedVerdictInEnd(err, resultDetails, probe.Name, probe.Mode, probe.Type, "PostChaos"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TriggerOnChaosTCPProbe trigger the onchaos tcp probes
+func TriggerOnChaosTCPProbe(ctx context.Context, probe types.ProbeAttributes, chaosresult *types.ResultDetails, duration int) {
+	var err error
+
+	// waiting for initial delay
+	if probe.RunProperties.InitialDelaySeconds != 0 {
+		log.Infof("[Wait]: Waiting for %vs before probe execution", probe.RunProperties.InitialDelaySeconds)
+		if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.InitialDelaySeconds); err != nil {
+			return
+		}
+		duration = math.Maximum(0, duration-probe.RunProperties.InitialDelaySeconds)
+	}
+
+	endTime := time.After(time.Duration(duration) * time.Second)
+
+	// it trigger the tcp probe for the entire duration of chaos and it fails, if any error encounter
+	// it marked the error for the probes, if any
+loop:
+	for {
+		select {
+		case <-endTime:
+			log.Infof("[Chaos]: Time is up for the %v probe", probe.Name)
+			break loop
+		default:
+			err = TriggerTCPProbe(ctx, probe, chaosresult)
+			// record the error inside the probeDetails, we are maintaining a dedicated variable for the err, inside probeDetails
+			if err != nil {
+				for index := range chaosresult.ProbeDetails {
+					if chaosresult.ProbeDetails[index].Name == probe.Name {
+						chaosresult.ProbeDetails[index].IsProbeFailedWithError = err
+						notifyFailure(probe.Name, probe.Type, probe.Mode, err)
+						haltOnFailure(ctx, probe)
+						break loop
+					}
+				}
+			}
+
+			// waiting for the probe polling interval
+			if err := common.WaitForDurationWithContext(ctx, probe.RunProperties.ProbePollingInterval); err != nil {
+				break loop
+			}
+		}
+	}
+}
+
+//OnChaosTCPProbe trigger the tcp probe for DuringChaos phase
+func OnChaosTCPProbe(ctx context.Context, probe types.ProbeAttributes, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails) {
+
+	switch probe.Mode {
+	case "OnChaos":
+
+		//DISPLAY THE TCP PROBE INFO
+		log.InfoWithValues("[Probe]: The tcp probe information is as follows", logrus.Fields{
+			"Name":           probe.Name,
+			"Endpoint":       probe.TCPProbeInputs.Endpoint,
+			"Run Properties": probe.RunProperties,
+			"Mode":           probe.Mode,
+			"Phase":          "DuringChaos",
+		})
+		go TriggerOnChaosTCPProbe(ctx, probe, resultDetails, chaosDetails.ChaosDuration)
+	}
+}
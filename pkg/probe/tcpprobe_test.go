@@ -0,0 +1,149 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+)
+
+// startTCPServer starts a tcp server on a random local port, which sends the banner and replies +PONG to the PING commands
+func startTCPServer(t *testing.T, banner string) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				if banner != "" {
+					conn.Write([]byte(banner + "\r\n"))
+					return
+				}
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					if scanner.Text() == "PING" {
+						conn.Write([]byte("+PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+	return listener.Addr().String(), func() { listener.Close() }
+}
+
+func newTCPProbe(inputs types.TCPProbeInputs) (types.ProbeAttributes, *types.ResultDetails) {
+	probe := types.ProbeAttributes{TCPProbeInputs: inputs}
+	probe.Name = "check-redis"
+	probe.Type = "tcpProbe"
+	probe.RunProperties.ProbeTimeout = 2
+	resultDetails := &types.ResultDetails{
+		ProbeDetails:   []types.ProbeDetails{{Name: probe.Name, Type: probe.Type}},
+		ProbeArtifacts: map[string]types.ProbeArtifact{},
+	}
+	return probe, resultDetails
+}
+
+func TestTriggerTCPProbe(t *testing.T) {
+	redis, stopRedis := startTCPServer(t, "")
+	defer stopRedis()
+	smtp, stopSMTP := startTCPServer(t, "220 mail.example.com ESMTP")
+	defer stopSMTP()
+
+	tests := []struct {
+		name         string
+		inputs       types.TCPProbeInputs
+		wantErr      bool
+		wantResponse string
+	}{
+		{name: "dial only", inputs: types.TCPProbeInputs{Endpoint: redis}},
+		{
+			name:         "ping pong",
+			inputs:       types.TCPProbeInputs{Endpoint: redis, Payload: "PING\r\n", Comparator: v1alpha1.ComparatorInfo{Criteria: "equal", Value: "+PONG"}},
+			wantResponse: "+PONG",
+		},
+		{
+			name:    "unexpected response",
+			inputs:  types.TCPProbeInputs{Endpoint: redis, Payload: "PING\r\n", Comparator: v1alpha1.ComparatorInfo{Criteria: "equal", Value: "+OK"}},
+			wantErr: true,
+		},
+		{
+			name:         "banner",
+			inputs:       types.TCPProbeInputs{Endpoint: smtp, Comparator: v1alpha1.ComparatorInfo{Criteria: "contains", Value: "ESMTP"}},
+			wantResponse: "220 mail.example.com ESMTP",
+		},
+		{
+			name:    "no response",
+			inputs:  types.TCPProbeInputs{Endpoint: redis, Comparator: v1alpha1.ComparatorInfo{Criteria: "contains", Value: "PONG"}, ResponseTimeout: 100},
+			wantErr: true,
+		},
+		{name: "unsupported protocol", inputs: types.TCPProbeInputs{Endpoint: redis, Protocol: "sctp"}, wantErr: true},
+		{name: "udp without payload", inputs: types.TCPProbeInputs{Endpoint: redis, Protocol: "udp", Comparator: v1alpha1.ComparatorInfo{Criteria: "contains", Value: "PONG"}}, wantErr: true},
+		{name: "udp without comparator", inputs: types.TCPProbeInputs{Endpoint: redis, Protocol: "udp", Payload: "PING\r\n"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe, resultDetails := newTCPProbe(tt.inputs)
+			err := TriggerTCPProbe(context.Background(), probe, resultDetails)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("expected error: %v, found %v", tt.wantErr, err)
+			}
+			if got := resultDetails.ProbeArtifacts[probe.Name].ProbeArtifacts.Register; got != tt.wantResponse {
+				t.Errorf("expected %q response inside the probe artifacts, found %q", tt.wantResponse, got)
+			}
+		})
+	}
+}
+
+func TestTriggerTCPProbeUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	defer conn.Close()
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			conn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	probe, resultDetails := newTCPProbe(types.TCPProbeInputs{
+		Endpoint:   conn.LocalAddr().String(),
+		Protocol:   "udp",
+		Payload:    "hello",
+		Comparator: v1alpha1.ComparatorInfo{Criteria: "equal", Value: "hello"},
+	})
+	if err := TriggerTCPProbe(context.Background(), probe, resultDetails); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTriggerTCPProbeTemplatedEndpoint(t *testing.T) {
+	redis, stop := startTCPServer(t, "")
+	defer stop()
+	host, port, _ := net.SplitHostPort(redis)
+
+	probe, resultDetails := newTCPProbe(types.TCPProbeInputs{Endpoint: host + ":{{ .port.ProbeArtifacts.Register }}"})
+	resultDetails.ProbeArtifacts["port"] = types.ProbeArtifact{}
+	artifact := resultDetails.ProbeArtifacts["port"]
+	artifact.ProbeArtifacts.Register = port
+	resultDetails.ProbeArtifacts["port"] = artifact
+
+	if err := TriggerTCPProbe(context.Background(), probe, resultDetails); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
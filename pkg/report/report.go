@@ -0,0 +1,193 @@
+package report
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// the report summarises a run of the experiment, it is written by the go-runner once the experiment completes
+// the phases are recorded while the experiment runs, along with the targets & result details in the end
+// like the metrics, they are recorded even if the report is not requested, which keeps the callers free from any checks
+
+const (
+	// MarkdownFormat writes the report in markdown format
+	MarkdownFormat string = "markdown"
+	// HTMLFormat writes the report as a html page
+	HTMLFormat string = "html"
+	// JUnitFormat writes the report in junit xml format, the probes & the experiment verdict are reported as test cases
+	JUnitFormat string = "junit"
+)
+
+// Report contains the summary of a run of the experiment
+type Report struct {
+	Experiment             string
+	Verdict                string
+	FailStep               string
+	ProbeSuccessPercentage string
+	StartTime              time.Time
+	EndTime                time.Time
+	Timeline               []Phase
+	Targets                []types.TargetDetails
+	Parameters             []Parameter
+	Probes                 []Probe
+}
+
+// Phase contains the start & end time of a phase of the experiment
+type Phase struct {
+	Name      string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Parameter contains the value of a tunable env of the experiment
+type Parameter struct {
+	Name  string
+	Value string
+}
+
+// Probe contains the result of a probe
+type Probe struct {
+	Name     string
+	Type     string
+	RunCount int
+	// Status contains the status of the probe, keyed by the phase/mode
+	Status   map[string]string
+	Artifact string
+	// FailureReason contains the error of the probe, if it failed
+	FailureReason string
+}
+
+// Passed returns true, if the probe passed in all the phases/modes
+// the probe without any status never ran, so it is not reported as passed
+func (p Probe) Passed() bool {
+	if len(p.Status) == 0 {
+		return false
+	}
+	for _, status := range p.Status {
+		if !strings.HasPrefix(status, "Passed") {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	mu             sync.Mutex
+	timeline       []Phase
+	targets        []types.TargetDetails
+	experimentName string
+	resultDetails  *types.ResultDetails
+)
+
+// SetPhase marks the given phase as the current phase of the experiment, the previous phase ends at the same time
+func SetPhase(phase string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := time.Now()
+	endPhase(now)
+	timeline = append(timeline, Phase{Name: phase, StartTime: now})
+}
+
+// SetResult records the targets & result details of the experiment, once it completes, and ends the current phase
+func SetResult(chaosDetails *types.ChaosDetails, result types.ResultDetails) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	endPhase(time.Now())
+	experimentName = chaosDetails.ExperimentName
+	targets = append([]types.TargetDetails{}, chaosDetails.Targets...)
+	resultDetails = &result
+}
+
+// Build returns the report of the experiment, from the recorded phases, targets & result details
+// it returns an error, if the experiment didn't complete and the result details are not recorded
+func Build(parameters []Parameter) (*Report, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if resultDetails == nil {
+		return nil, errors.Errorf("result of the experiment is not available")
+	}
+
+	r := &Report{
+		Experiment: experimentName,
+		Verdict:    resultDetails.Verdict,
+		FailStep:   resultDetails.FailStep,
+		Timeline:   append([]Phase{}, timeline...),
+		Targets:    targets,
+		Parameters: parameters,
+	}
+	if len(timeline) != 0 {
+		r.StartTime, r.EndTime = timeline[0].StartTime, timeline[len(timeline)-1].EndTime
+	}
+	if len(resultDetails.ProbeDetails) != 0 {
+		r.ProbeSuccessPercentage = strconv.Itoa((resultDetails.PassedProbeCount * 100) / len(resultDetails.ProbeDetails))
+	}
+
+	for _, p := range resultDetails.ProbeDetails {
+		probe := Probe{
+			Name:     p.Name,
+			Type:     p.Type,
+			RunCount: p.RunCount,
+			Status:   p.Status,
+			Artifact: resultDetails.ProbeArtifacts[p.Name].ProbeArtifacts.Register,
+		}
+		if p.IsProbeFailedWithError != nil {
+			probe.FailureReason = p.IsProbeFailedWithError.Error()
+		} else if !probe.Passed() {
+			probe.FailureReason = "probe status: " + statusString(p.Status)
+		}
+		r.Probes = append(r.Probes, probe)
+	}
+	return r, nil
+}
+
+// Format returns the format of the report, derived from the extension of the report file
+// .md for markdown, .html for html and .xml for junit xml
+func Format(reportFile string) (string, error) {
+	switch strings.ToLower(filepath.Ext(reportFile)) {
+	case ".md", ".markdown":
+		return MarkdownFormat, nil
+	case ".html", ".htm":
+		return HTMLFormat, nil
+	case ".xml":
+		return JUnitFormat, nil
+	}
+	return "", errors.Errorf("unsupported report file %v, supported extensions are .md, .html and .xml", reportFile)
+}
+
+// endPhase ends the current phase at the given time, if it is not ended already
+func endPhase(now time.Time) {
+	if len(timeline) != 0 && timeline[len(timeline)-1].EndTime.IsZero() {
+		timeline[len(timeline)-1].EndTime = now
+	}
+}
+
+// statusString returns the status of all the phases/modes of a probe, sorted by the phase/mode, or "Not Run" if it has none
+func statusString(status map[string]string) string {
+	if len(status) == 0 {
+		return "Not Run"
+	}
+	var list []string
+	for phase, s := range status {
+		list = append(list, phase+": "+s)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
+// reset removes all the recorded details, used by the tests
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	timeline, targets, experimentName, resultDetails = nil, nil, "", nil
+}
@@ -0,0 +1,138 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func newReport(t *testing.T) *Report {
+	reset()
+	defer reset()
+
+	SetPhase("PreReq")
+	SetPhase("ChaosInjection")
+	SetPhase("PostChaosCheck")
+
+	resultDetails := types.ResultDetails{
+		Verdict:          types.FailVerdict,
+		FailStep:         "Failed while running probes",
+		PassedProbeCount: 1,
+		ProbeDetails: []types.ProbeDetails{
+			{Name: "check-frontend", Type: "httpProbe", RunCount: 3, Status: map[string]string{"Continuous": "Passed 👍"}},
+			{Name: "check-orders", Type: "cmdProbe", RunCount: 2, Status: map[string]string{"PostChaos": "Better Luck Next Time 👎"},
+				IsProbeFailedWithError: errors.Errorf("unable to match the output")},
+		},
+		ProbeArtifacts: map[string]types.ProbeArtifact{
+			"check-orders": {ProbeArtifacts: types.RegisterDetails{Register: "0 orders"}},
+		},
+	}
+	chaosDetails := &types.ChaosDetails{
+		ExperimentName: "pod-delete",
+		Targets: []types.TargetDetails{
+			{Kind: "pod", Name: "frontend-0", Namespace: "shop", ChaosStatus: types.RevertedStatus},
+			{Kind: "pod", Name: "frontend-1", Namespace: "shop", ChaosStatus: types.RevertedStatus},
+		},
+	}
+	SetResult(chaosDetails, resultDetails)
+
+	r, err := Build([]Parameter{{Name: "TOTAL_CHAOS_DURATION", Value: "30"}})
+	if err != nil {
+		t.Fatalf("unable to build the report, err: %v", err)
+	}
+	return r
+}
+
+func TestBuild(t *testing.T) {
+	r := newReport(t)
+
+	if r.Experiment != "pod-delete" || r.ProbeSuccessPercentage != "50" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if len(r.Timeline) != 3 || r.Timeline[2].EndTime.IsZero() || r.EndTime.IsZero() {
+		t.Fatalf("expected all the phases to be ended, found %+v", r.Timeline)
+	}
+	if len(r.Targets) != 2 {
+		t.Fatalf("expected the targets to be recorded, found %+v", r.Targets)
+	}
+	if !r.Probes[0].Passed() || r.Probes[1].Passed() {
+		t.Fatalf("unexpected probe results %+v", r.Probes)
+	}
+	if (Probe{Name: "check-cart"}).Passed() {
+		t.Fatalf("expected the probe without any status to be reported as not passed")
+	}
+	if r.Probes[1].FailureReason != "unable to match the output" || r.Probes[1].Artifact != "0 orders" {
+		t.Fatalf("unexpected probe details %+v", r.Probes[1])
+	}
+}
+
+func TestBuildWithoutResult(t *testing.T) {
+	reset()
+	if _, err := Build(nil); err == nil {
+		t.Fatalf("expected an error, as the result is not recorded")
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	r := newReport(t)
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, r); err != nil {
+		t.Fatalf("unable to write the junit report, err: %v", err)
+	}
+	suites := junitTestSuites{}
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("unable to parse the junit report, err: %v", err)
+	}
+	suite := suites.Suites[0]
+	if suite.Name != "pod-delete" || suite.Tests != 3 || suite.Failures != 2 || len(suite.Properties) != 3 {
+		t.Fatalf("unexpected test suite %+v", suite)
+	}
+	if suite.TestCases[0].Failure == nil || suite.TestCases[0].Failure.Message != "Failed while running probes" {
+		t.Fatalf("expected the verdict to be reported as failure, found %+v", suite.TestCases[0])
+	}
+	if suite.TestCases[1].Failure != nil || suite.TestCases[2].Failure == nil {
+		t.Fatalf("unexpected probe test cases %+v", suite.TestCases[1:])
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	r := newReport(t)
+
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, r); err != nil {
+		t.Fatalf("unable to write the markdown report, err: %v", err)
+	}
+	for _, s := range []string{"## Timeline", "| ChaosInjection |", "| pod | frontend-1 | shop |", "| TOTAL_CHAOS_DURATION | 30 |", "unable to match the output"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("expected the report to contain %q", s)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := map[string]string{"report.md": MarkdownFormat, "report.HTML": HTMLFormat, "junit.xml": JUnitFormat, "report.json": ""}
+	for file, format := range tests {
+		found, err := Format(file)
+		if found != format || (format == "") != (err != nil) {
+			t.Errorf("%v: expected %q format, found %q (err: %v)", file, format, found, err)
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	r := newReport(t)
+	r.Probes[1].Artifact = "<script>"
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, r); err != nil {
+		t.Fatalf("unable to write the html report, err: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<td class="Fail">Fail</td>`) || strings.Contains(buf.String(), "<script>") {
+		t.Fatalf("unexpected html report %v", buf.String())
+	}
+}
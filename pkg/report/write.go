@@ -0,0 +1,314 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Write writes the report to the given file, in the format derived from the extension of the file
+func Write(r *Report, reportFile string) error {
+
+	format, err := Format(reportFile)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	switch format {
+	case MarkdownFormat:
+		err = WriteMarkdown(&buf, r)
+	case HTMLFormat:
+		err = WriteHTML(&buf, r)
+	case JUnitFormat:
+		err = WriteJUnit(&buf, r)
+	}
+	if err != nil {
+		return errors.Errorf("unable to generate the %v report, err: %v", format, err)
+	}
+	if err := ioutil.WriteFile(reportFile, buf.Bytes(), 0644); err != nil {
+		return errors.Errorf("unable to write the report file %v, err: %v", reportFile, err)
+	}
+	return nil
+}
+
+// WriteMarkdown writes the report in markdown format
+func WriteMarkdown(w io.Writer, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %v\n\n", r.Experiment)
+	fmt.Fprintf(&b, "- **Verdict:** %v\n", r.Verdict)
+	if r.Verdict != types.PassVerdict {
+		fmt.Fprintf(&b, "- **Fail Step:** %v\n", escapeMarkdown(r.FailStep))
+	}
+	if r.ProbeSuccessPercentage != "" {
+		fmt.Fprintf(&b, "- **Probe Success Percentage:** %v\n", r.ProbeSuccessPercentage)
+	}
+	fmt.Fprintf(&b, "- **Start Time:** %v\n", timestamp(r.StartTime))
+	fmt.Fprintf(&b, "- **End Time:** %v\n\n", timestamp(r.EndTime))
+
+	b.WriteString("## Timeline\n\n")
+	b.WriteString("| Phase | Start Time | End Time | Duration |\n")
+	b.WriteString("| ----- | ---------- | -------- | -------- |\n")
+	for _, p := range r.Timeline {
+		fmt.Fprintf(&b, "| %v | %v | %v | %v |\n", p.Name, timestamp(p.StartTime), timestamp(p.EndTime), duration(p.StartTime, p.EndTime))
+	}
+
+	b.WriteString("\n## Targets\n\n")
+	if len(r.Targets) == 0 {
+		b.WriteString("No targets recorded.\n")
+	} else {
+		b.WriteString("| Kind | Name | Namespace | Node | Container | Chaos Start Time | Chaos End Time | Chaos Status |\n")
+		b.WriteString("| ---- | ---- | --------- | ---- | --------- | ---------------- | -------------- | ------------ |\n")
+		for _, t := range r.Targets {
+			fmt.Fprintf(&b, "| %v | %v | %v | %v | %v | %v | %v | %v |\n", t.Kind, t.Name, t.Namespace, t.Node, t.Container, t.ChaosStartTime, t.ChaosEndTime, t.ChaosStatus)
+		}
+	}
+
+	b.WriteString("\n## Parameters\n\n")
+	if len(r.Parameters) == 0 {
+		b.WriteString("No parameters recorded.\n")
+	} else {
+		b.WriteString("| Name | Value |\n")
+		b.WriteString("| ---- | ----- |\n")
+		for _, p := range r.Parameters {
+			fmt.Fprintf(&b, "| %v | %v |\n", p.Name, escapeMarkdown(p.Value))
+		}
+	}
+
+	b.WriteString("\n## Probes\n\n")
+	if len(r.Probes) == 0 {
+		b.WriteString("No probes defined.\n")
+	} else {
+		b.WriteString("| Name | Type | Run Count | Status | Failure Reason | Artifact |\n")
+		b.WriteString("| ---- | ---- | --------- | ------ | -------------- | -------- |\n")
+		for _, p := range r.Probes {
+			fmt.Fprintf(&b, "| %v | %v | %v | %v | %v | %v |\n", p.Name, p.Type, p.RunCount, escapeMarkdown(statusString(p.Status)),
+				escapeMarkdown(p.FailureReason), escapeMarkdown(p.Artifact))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"timestamp": timestamp,
+	"duration":  duration,
+	"status":    statusString,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Experiment}} - {{.Verdict}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+pre { margin: 0; white-space: pre-wrap; }
+.Pass { color: #2e7d32; }
+.Fail { color: #c62828; }
+.Stopped, .Awaited { color: #ef6c00; }
+</style>
+</head>
+<body>
+<h1>{{.Experiment}}</h1>
+<table>
+<tr><th>Verdict</th><td class="{{.Verdict}}">{{.Verdict}}</td></tr>
+{{- if ne .Verdict "Pass"}}
+<tr><th>Fail Step</th><td>{{.FailStep}}</td></tr>
+{{- end}}
+{{- if .ProbeSuccessPercentage}}
+<tr><th>Probe Success Percentage</th><td>{{.ProbeSuccessPercentage}}</td></tr>
+{{- end}}
+<tr><th>Start Time</th><td>{{timestamp .StartTime}}</td></tr>
+<tr><th>End Time</th><td>{{timestamp .EndTime}}</td></tr>
+</table>
+<h2>Timeline</h2>
+<table>
+<tr><th>Phase</th><th>Start Time</th><th>End Time</th><th>Duration</th></tr>
+{{- range .Timeline}}
+<tr><td>{{.Name}}</td><td>{{timestamp .StartTime}}</td><td>{{timestamp .EndTime}}</td><td>{{duration .StartTime .EndTime}}</td></tr>
+{{- end}}
+</table>
+<h2>Targets</h2>
+{{- if .Targets}}
+<table>
+<tr><th>Kind</th><th>Name</th><th>Namespace</th><th>Node</th><th>Container</th><th>Chaos Start Time</th><th>Chaos End Time</th><th>Chaos Status</th></tr>
+{{- range .Targets}}
+<tr><td>{{.Kind}}</td><td>{{.Name}}</td><td>{{.Namespace}}</td><td>{{.Node}}</td><td>{{.Container}}</td><td>{{.ChaosStartTime}}</td><td>{{.ChaosEndTime}}</td><td>{{.ChaosStatus}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No targets recorded.</p>
+{{- end}}
+<h2>Parameters</h2>
+{{- if .Parameters}}
+<table>
+<tr><th>Name</th><th>Value</th></tr>
+{{- range .Parameters}}
+<tr><td>{{.Name}}</td><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No parameters recorded.</p>
+{{- end}}
+<h2>Probes</h2>
+{{- if .Probes}}
+<table>
+<tr><th>Name</th><th>Type</th><th>Run Count</th><th>Status</th><th>Failure Reason</th><th>Artifact</th></tr>
+{{- range .Probes}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.RunCount}}</td><td>{{status .Status}}</td><td>{{.FailureReason}}</td><td><pre>{{.Artifact}}</pre></td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No probes defined.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a html page
+func WriteHTML(w io.Writer, r *Report) error {
+	return htmlTemplate.Execute(w, r)
+}
+
+// junit xml schema, as consumed by the CI systems
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report in junit xml format
+// the experiment verdict and each of the probes are reported as a test case of the experiment test suite,
+// a failed verdict or probe is reported as a failure, while a stopped experiment is reported as an error
+// the targets & parameters are added as the properties and the timeline as the system-out of the test suite
+func WriteJUnit(w io.Writer, r *Report) error {
+
+	suite := junitTestSuite{
+		Name:      r.Experiment,
+		Time:      seconds(r.StartTime, r.EndTime),
+		Timestamp: timestamp(r.StartTime),
+	}
+	for _, t := range r.Targets {
+		name := t.Name
+		if t.Namespace != "" {
+			name = t.Namespace + "/" + t.Name
+		}
+		suite.Properties = append(suite.Properties, junitProperty{Name: "target." + t.Kind, Value: name + " (" + t.ChaosStatus + ")"})
+	}
+	for _, p := range r.Parameters {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "parameter." + p.Name, Value: p.Value})
+	}
+	var timeline strings.Builder
+	for _, p := range r.Timeline {
+		fmt.Fprintf(&timeline, "%v: %v - %v (%v)\n", p.Name, timestamp(p.StartTime), timestamp(p.EndTime), duration(p.StartTime, p.EndTime))
+	}
+	suite.SystemOut = timeline.String()
+
+	verdict := junitTestCase{Name: "verdict", ClassName: r.Experiment, Time: suite.Time}
+	switch r.Verdict {
+	case types.PassVerdict:
+	case types.FailVerdict:
+		verdict.Failure = &junitMessage{Message: r.FailStep, Type: r.Verdict, Body: r.FailStep}
+		suite.Failures++
+	default:
+		verdict.Error = &junitMessage{Message: "experiment verdict: " + r.Verdict, Type: r.Verdict, Body: r.FailStep}
+		suite.Errors++
+	}
+	suite.TestCases = append(suite.TestCases, verdict)
+
+	for _, p := range r.Probes {
+		testCase := junitTestCase{
+			Name:      "probe/" + p.Name,
+			ClassName: r.Experiment + "." + p.Type,
+			Time:      "0",
+			SystemOut: p.Artifact,
+		}
+		if !p.Passed() {
+			testCase.Failure = &junitMessage{Message: p.FailureReason, Type: p.Type, Body: statusString(p.Status)}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// timestamp returns the given time in RFC3339 format, it returns empty string for the zero time
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// duration returns the duration between the given times, rounded to seconds
+func duration(start, end time.Time) string {
+	if start.IsZero() || end.IsZero() {
+		return ""
+	}
+	return end.Sub(start).Round(time.Second).String()
+}
+
+// seconds returns the duration between the given times in seconds, as expected by the junit time attribute
+func seconds(start, end time.Time) string {
+	if start.IsZero() || end.IsZero() {
+		return "0"
+	}
+	return fmt.Sprintf("%.3f", end.Sub(start).Seconds())
+}
+
+// escapeMarkdown escapes the pipes & newlines, which breaks the markdown tables
+func escapeMarkdown(value string) string {
+	value = strings.Replace(value, "|", "\\|", -1)
+	return strings.Replace(value, "\n", "<br>", -1)
+}
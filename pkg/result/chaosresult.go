@@ -10,7 +10,9 @@ import (
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/events"
+	"github.com/litmuschaos/litmus-go/pkg/notify"
 	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/standalone"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/openebs/maya/pkg/util/retry"
 	"github.com/pkg/errors"
@@ -20,6 +22,12 @@ import (
 
 //ChaosResult Create and Update the chaos result
 func ChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, state string) error {
+
+	// the chaosresult is written to the result file in the standalone mode
+	if standalone.Enabled() {
+		return standaloneChaosResult(chaosDetails, resultDetails, state)
+	}
+
 	experimentLabel := map[string]string{}
 
 	// It will list all the chaos-result with matching label
@@ -78,32 +86,7 @@ func ChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, r
 //InitializeChaosResult create the chaos result
 func InitializeChaosResult(chaosDetails *types.ChaosDetails, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosResultLabel map[string]string) error {
 
-	probeStatus := GetProbeStatus(resultDetails)
-	chaosResult := &v1alpha1.ChaosResult{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      resultDetails.Name,
-			Namespace: chaosDetails.ChaosNamespace,
-			Labels:    chaosResultLabel,
-		},
-		Spec: v1alpha1.ChaosResultSpec{
-			EngineName:     chaosDetails.EngineName,
-			ExperimentName: chaosDetails.ExperimentName,
-			InstanceID:     chaosDetails.InstanceID,
-		},
-		Status: v1alpha1.ChaosResultStatus{
-			ExperimentStatus: v1alpha1.TestStatus{
-				Phase:                  resultDetails.Phase,
-				Verdict:                resultDetails.Verdict,
-				ProbeSuccessPercentage: "Awaited",
-			},
-			ProbeStatus: probeStatus,
-			History: v1alpha1.HistoryDetails{
-				PassedRuns:  0,
-				FailedRuns:  0,
-				StoppedRuns: 0,
-			},
-		},
-	}
+	chaosResult := newChaosResult(chaosDetails, resultDetails, chaosResultLabel)
 
 	// It will create a new chaos-result CR
 	_, err := clients.LitmusClient.ChaosResults(chaosDetails.ChaosNamespace).Create(chaosResult)
@@ -130,6 +113,35 @@ func InitializeChaosResult(chaosDetails *types.ChaosDetails, clients clients.Cli
 	return nil
 }
 
+// newChaosResult returns the chaosresult, initialised with the current chaos result details
+func newChaosResult(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, chaosResultLabel map[string]string) *v1alpha1.ChaosResult {
+	return &v1alpha1.ChaosResult{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resultDetails.Name,
+			Namespace: chaosDetails.ChaosNamespace,
+			Labels:    chaosResultLabel,
+		},
+		Spec: v1alpha1.ChaosResultSpec{
+			EngineName:     chaosDetails.EngineName,
+			ExperimentName: chaosDetails.ExperimentName,
+			InstanceID:     chaosDetails.InstanceID,
+		},
+		Status: v1alpha1.ChaosResultStatus{
+			ExperimentStatus: v1alpha1.TestStatus{
+				Phase:                  resultDetails.Phase,
+				Verdict:                resultDetails.Verdict,
+				ProbeSuccessPercentage: "Awaited",
+			},
+			ProbeStatus: GetProbeStatus(resultDetails),
+			History: v1alpha1.HistoryDetails{
+				PassedRuns:  0,
+				FailedRuns:  0,
+				StoppedRuns: 0,
+			},
+		},
+	}
+}
+
 //GetProbeStatus fetch status of all probes
 func GetProbeStatus(resultDetails *types.ResultDetails) []v1alpha1.ProbeStatus {
 
@@ -147,6 +159,25 @@ func GetProbeStatus(resultDetails *types.ResultDetails) []v1alpha1.ProbeStatus {
 //PatchChaosResult Update the chaos result
 func PatchChaosResult(result *v1alpha1.ChaosResult, clients clients.ClientSets, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, chaosResultLabel map[string]string) error {
 
+	updateResultAttributes(result, chaosDetails, resultDetails, chaosResultLabel)
+
+	// It will update the existing chaos-result CR with new values
+	// it will retries until it will able to update successfully or met the timeout(3 mins)
+	return retry.
+		Times(90).
+		Wait(2 * time.Second).
+		Try(func(attempt uint) error {
+			_, err := clients.LitmusClient.ChaosResults(result.Namespace).Update(result)
+			if err != nil {
+				return errors.Errorf("Unable to update the chaosresult, err: %v", err)
+			}
+			return nil
+		})
+}
+
+// updateResultAttributes sets the current chaos result details inside the chaosresult
+func updateResultAttributes(result *v1alpha1.ChaosResult, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, chaosResultLabel map[string]string) {
+
 	result.Status.ExperimentStatus.Phase = resultDetails.Phase
 	result.Status.ExperimentStatus.Verdict = resultDetails.Verdict
 	result.Spec.InstanceID = chaosDetails.InstanceID
@@ -154,6 +185,7 @@ func PatchChaosResult(result *v1alpha1.ChaosResult, clients clients.ClientSets,
 	// for existing chaos result resource it will patch the label
 	result.ObjectMeta.Labels = chaosResultLabel
 	result.Status.ProbeStatus = GetProbeStatus(resultDetails)
+	setChaosRecord(result, chaosDetails, resultDetails)
 
 	switch strings.ToLower(resultDetails.Phase) {
 	case "completed":
@@ -181,24 +213,42 @@ func PatchChaosResult(result *v1alpha1.ChaosResult, clients clients.ClientSets,
 	default:
 		result.Status.ExperimentStatus.ProbeSuccessPercentage = "Awaited"
 	}
+}
 
-	// It will update the existing chaos-result CR with new values
-	// it will retries until it will able to update successfully or met the timeout(3 mins)
-	return retry.
-		Times(90).
-		Wait(2 * time.Second).
-		Try(func(attempt uint) error {
-			_, err := clients.LitmusClient.ChaosResults(result.Namespace).Update(result)
-			if err != nil {
-				return errors.Errorf("Unable to update the chaosresult, err: %v", err)
-			}
-			return nil
-		})
+// standaloneChaosResult creates and updates the chaosresult inside the result file
+// it follows the same flow as the chaosresult CR, so the history of the earlier runs is retained
+func standaloneChaosResult(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, state string) error {
+
+	chaosResultLabel := map[string]string{"name": resultDetails.Name}
+
+	result, err := standalone.ReadResult()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case result == nil:
+		result = newChaosResult(chaosDetails, resultDetails, chaosResultLabel)
+	case state == "SOT":
+		updateHistory(result)
+		updateResultAttributes(result, chaosDetails, resultDetails, chaosResultLabel)
+	default:
+		// it will update the chaos-result in the end of experiment
+		resultDetails.Phase = "Completed"
+		updateResultAttributes(result, chaosDetails, resultDetails, chaosResultLabel)
+	}
+
+	return standalone.WriteResult(result)
 }
 
 // SetResultUID sets the ResultUID into the ResultDetails structure
 func SetResultUID(resultDetails *types.ResultDetails, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
+	// there is no chaosresult CR in the standalone mode
+	if standalone.Enabled() {
+		return nil
+	}
+
 	result, err := clients.LitmusClient.ChaosResults(chaosDetails.ChaosNamespace).Get(resultDetails.Name, metav1.GetOptions{})
 
 	if err != nil {
@@ -227,6 +277,39 @@ func RecordAfterFailure(chaosDetails *types.ChaosDetails, resultDetails *types.R
 		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
 	}
 
+	notify.Send(notify.Notification{
+		Event:    notify.EOTEvent,
+		Message:  chaosDetails.ExperimentName + " experiment has been failed",
+		Verdict:  resultDetails.Verdict,
+		FailStep: failStep,
+		Targets:  chaosDetails.Targets,
+	})
+}
+
+//RecordAfterAbort update the chaosresult and create the summary events, once the experiment is aborted
+func RecordAfterAbort(chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails, clients clients.ClientSets, eventsDetails *types.EventDetails) {
+
+	// updating the chaosresult after stopped
+	types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", "Chaos injection stopped!")
+	ChaosResult(chaosDetails, clients, resultDetails, "EOT")
+
+	// generating summary event in chaosengine
+	msg := chaosDetails.ExperimentName + " experiment has been aborted"
+	if chaosDetails.EngineName != "" {
+		types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Warning", chaosDetails)
+		events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+	}
+
+	// generating summary event in chaosresult
+	types.SetResultEventAttributes(eventsDetails, types.Summary, msg, "Warning", resultDetails)
+	events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosResult")
+
+	notify.Send(notify.Notification{
+		Event:   notify.AbortEvent,
+		Message: msg,
+		Verdict: resultDetails.Verdict,
+		Targets: chaosDetails.Targets,
+	})
 }
 
 // updateHistory initialise the history for the older results
@@ -0,0 +1,181 @@
+package result
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testNamespace = "litmus"
+
+func newProbeDetails(name string, status map[string]string) types.ProbeDetails {
+	return types.ProbeDetails{Name: name, Type: "cmdProbe", Status: status}
+}
+
+func TestPatchChaosResult(t *testing.T) {
+	tests := []struct {
+		name           string
+		phase          string
+		verdict        string
+		probes         []types.ProbeDetails
+		passedProbes   int
+		wantPercentage string
+		wantHistory    v1alpha1.HistoryDetails
+		wantProbeState string
+	}{
+		{
+			name:           "awaited",
+			phase:          "Running",
+			verdict:        "Awaited",
+			wantPercentage: "Awaited",
+		},
+		{
+			name:           "passed",
+			phase:          "Completed",
+			verdict:        "Pass",
+			probes:         []types.ProbeDetails{newProbeDetails("probe", map[string]string{"PostChaos": "Passed"})},
+			passedProbes:   1,
+			wantPercentage: "100",
+			wantHistory:    v1alpha1.HistoryDetails{PassedRuns: 1},
+			wantProbeState: "Passed",
+		},
+		{
+			name:    "failed",
+			phase:   "Completed",
+			verdict: "Fail",
+			probes: []types.ProbeDetails{
+				newProbeDetails("probe", map[string]string{"PostChaos": "Passed"}),
+				newProbeDetails("failed-probe", map[string]string{"PostChaos": "Awaited"}),
+			},
+			passedProbes:   1,
+			wantPercentage: "50",
+			wantHistory:    v1alpha1.HistoryDetails{FailedRuns: 1},
+			wantProbeState: "Better Luck Next Time",
+		},
+		{
+			name:           "failed without probes",
+			phase:          "Completed",
+			verdict:        "Fail",
+			wantPercentage: "0",
+			wantHistory:    v1alpha1.HistoryDetails{FailedRuns: 1},
+		},
+		{
+			name:    "stopped",
+			phase:   "Completed",
+			verdict: "Stopped",
+			probes: []types.ProbeDetails{
+				newProbeDetails("failed-probe", map[string]string{"PostChaos": "Awaited"}),
+			},
+			wantPercentage: "0",
+			wantHistory:    v1alpha1.HistoryDetails{StoppedRuns: 1},
+			wantProbeState: "Better Luck Next Time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &v1alpha1.ChaosResult{
+				ObjectMeta: metav1.ObjectMeta{Name: "engine-pod-delete", Namespace: testNamespace},
+			}
+			clients := clients.NewFakeClientSets(nil, []runtime.Object{existing}, nil)
+
+			chaosDetails := &types.ChaosDetails{InstanceID: "instance"}
+			resultDetails := &types.ResultDetails{
+				Name:             "engine-pod-delete",
+				Phase:            tt.phase,
+				Verdict:          tt.verdict,
+				FailStep:         "N/A",
+				ProbeDetails:     tt.probes,
+				PassedProbeCount: tt.passedProbes,
+			}
+			labels := map[string]string{"name": "engine-pod-delete"}
+
+			if err := PatchChaosResult(existing.DeepCopy(), clients, chaosDetails, resultDetails, labels); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result, err := clients.LitmusClient.ChaosResults(testNamespace).Get("engine-pod-delete", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unable to get the chaosresult: %v", err)
+			}
+			status := result.Status
+			if status.ExperimentStatus.Verdict != tt.verdict || status.ExperimentStatus.Phase != tt.phase {
+				t.Errorf("expected %v verdict in %v phase, found %v in %v", tt.verdict, tt.phase, status.ExperimentStatus.Verdict, status.ExperimentStatus.Phase)
+			}
+			if status.ExperimentStatus.ProbeSuccessPercentage != tt.wantPercentage {
+				t.Errorf("expected %v probe success percentage, found %v", tt.wantPercentage, status.ExperimentStatus.ProbeSuccessPercentage)
+			}
+			if status.History.PassedRuns != tt.wantHistory.PassedRuns || status.History.FailedRuns != tt.wantHistory.FailedRuns || status.History.StoppedRuns != tt.wantHistory.StoppedRuns {
+				t.Errorf("expected %+v history, found %+v", tt.wantHistory, status.History)
+			}
+			if result.Spec.InstanceID != "instance" || result.Labels["name"] != "engine-pod-delete" {
+				t.Errorf("expected the instance id and labels to be patched, found %v and %v", result.Spec.InstanceID, result.Labels)
+			}
+			if len(status.ProbeStatus) != len(tt.probes) {
+				t.Fatalf("expected %v probe status, found %v", len(tt.probes), len(status.ProbeStatus))
+			}
+			if tt.wantProbeState != "" {
+				last := status.ProbeStatus[len(status.ProbeStatus)-1]
+				if got := last.Status["PostChaos"]; !strings.HasPrefix(got, tt.wantProbeState) {
+					t.Errorf("expected %v probe status, found %v", tt.wantProbeState, got)
+				}
+			}
+		})
+	}
+}
+
+func TestChaosRecord(t *testing.T) {
+	existing := &v1alpha1.ChaosResult{
+		ObjectMeta: metav1.ObjectMeta{Name: "engine-pod-delete", Namespace: testNamespace},
+	}
+	clients := clients.NewFakeClientSets(nil, []runtime.Object{existing}, nil)
+	resultDetails := &types.ResultDetails{Name: "engine-pod-delete", Phase: "Completed", Verdict: "Pass"}
+	resultDetails.ProbeDetails = []types.ProbeDetails{{
+		Name:    "check-latency",
+		Type:    "httpProbe",
+		Status:  map[string]string{"PostChaos": "Passed 👍"},
+		Latency: &types.LatencySummary{Requests: 10, P50: 20 * time.Millisecond},
+	}}
+	chaosDetails := &types.ChaosDetails{
+		ChaosWindow: types.ChaosWindow{StartTime: "2021-01-01T10:00:00Z", EndTime: "2021-01-01T10:01:00Z"},
+		Parameters:  map[string]string{"netemCommand": "delay 2000ms"},
+		Targets:     []types.TargetDetails{{Kind: "pod", Name: "frontend-0", Namespace: "shop", ChaosStatus: types.RevertedStatus}},
+	}
+
+	if err := PatchChaosResult(existing.DeepCopy(), clients, chaosDetails, resultDetails, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := clients.LitmusClient.ChaosResults(testNamespace).Get("engine-pod-delete", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unable to get the chaosresult: %v", err)
+	}
+	record, err := GetChaosRecord(result)
+	if err != nil || record == nil {
+		t.Fatalf("expected the chaos record, found %v (err: %v)", record, err)
+	}
+	if record.ChaosWindow != chaosDetails.ChaosWindow || record.Parameters["netemCommand"] != "delay 2000ms" || len(record.Targets) != 1 || record.Targets[0] != chaosDetails.Targets[0] {
+		t.Fatalf("unexpected chaos record %+v", record)
+	}
+	// the latency summary is recorded in the chaos record, instead of the phase verdicts of the probe
+	if !strings.HasPrefix(record.ProbeLatency["check-latency"], "p50: 20.0ms") {
+		t.Fatalf("expected the latency summary of the probe, found %+v", record.ProbeLatency)
+	}
+	if status := result.Status.ProbeStatus[0].Status; len(status) != 1 || status["PostChaos"] == "" {
+		t.Fatalf("expected only the phase verdicts in the probe status, found %v", status)
+	}
+
+	// the chaos record of the earlier run is removed, once the next run starts
+	resultDetails.Phase, resultDetails.Verdict, resultDetails.ProbeDetails = "Running", "Awaited", nil
+	if err := PatchChaosResult(result, clients, &types.ChaosDetails{}, resultDetails, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record, _ := GetChaosRecord(result); record != nil {
+		t.Fatalf("expected the chaos record to be removed, found %+v", record)
+	}
+}
@@ -0,0 +1,71 @@
+package result
+
+import (
+	"encoding/json"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// the chaosresult CRD doesn't contain the targets of the run, so they are recorded as json inside the chaosresult annotation
+// the annotation is replaced by every run of the experiment, along with the status of the chaosresult
+
+// ChaosRecordAnnotation is the chaosresult annotation, which contains the chaos record of the latest run
+const ChaosRecordAnnotation string = "litmuschaos.io/chaos-record"
+
+// ChaosRecord contains the targets, the injected parameters and the chaos window of a run
+// along with the latency summary of the continuous & onchaos http probes, keyed by the probe name
+type ChaosRecord struct {
+	ChaosWindow  types.ChaosWindow     `json:"chaosWindow"`
+	Parameters   map[string]string     `json:"parameters,omitempty"`
+	Targets      []types.TargetDetails `json:"targets,omitempty"`
+	ProbeLatency map[string]string     `json:"probeLatency,omitempty"`
+}
+
+// GetChaosRecord returns the chaos record of the latest run from the chaosresult annotation
+// it returns nil, if the chaosresult doesn't contain the chaos record
+func GetChaosRecord(result *v1alpha1.ChaosResult) (*ChaosRecord, error) {
+	value, ok := result.Annotations[ChaosRecordAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	record := &ChaosRecord{}
+	if err := json.Unmarshal([]byte(value), record); err != nil {
+		return nil, errors.Errorf("unable to parse the %v annotation, err: %v", ChaosRecordAnnotation, err)
+	}
+	return record, nil
+}
+
+// setChaosRecord records the targets, parameters, chaos window & probe latency of the current run inside the chaosresult annotation
+// the chaos record of the earlier run is removed, if the chaos is not injected yet
+func setChaosRecord(result *v1alpha1.ChaosResult, chaosDetails *types.ChaosDetails, resultDetails *types.ResultDetails) {
+
+	probeLatency := map[string]string{}
+	for _, probe := range resultDetails.ProbeDetails {
+		if probe.Latency != nil {
+			probeLatency[probe.Name] = probe.Latency.String()
+		}
+	}
+
+	if len(chaosDetails.Targets) == 0 && chaosDetails.ChaosWindow.StartTime == "" && len(probeLatency) == 0 {
+		delete(result.Annotations, ChaosRecordAnnotation)
+		return
+	}
+
+	data, err := json.Marshal(ChaosRecord{
+		ChaosWindow:  chaosDetails.ChaosWindow,
+		Parameters:   chaosDetails.Parameters,
+		Targets:      chaosDetails.Targets,
+		ProbeLatency: probeLatency,
+	})
+	if err != nil {
+		log.Errorf("Unable to record the targets in the chaosresult, err: %v", err)
+		return
+	}
+	if result.Annotations == nil {
+		result.Annotations = map[string]string{}
+	}
+	result.Annotations[ChaosRecordAnnotation] = string(data)
+}
@@ -0,0 +1,143 @@
+package standalone
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// the standalone mode runs the experiment without the chaosengine & chaosresult CRDs
+// the experiment spec (env & probes) is read from a local spec file and
+// the chaosresult is written to a local result file instead of the chaosresult CR
+var (
+	mu         sync.Mutex
+	spec       *types.Experiment
+	resultFile string
+)
+
+// Load reads the experiment spec from the given spec file and enables the standalone mode
+// the spec follows the schema of the experiments entry inside the chaosengine (name, spec.components.env & spec.probe)
+// the env defined inside the spec is exported to the experiment, unless it is already set in the environment
+func Load(specFile, resultPath string) error {
+
+	data, err := ioutil.ReadFile(specFile)
+	if err != nil {
+		return errors.Errorf("unable to read the spec file %v, err: %v", specFile, err)
+	}
+
+	experiment := &types.Experiment{}
+	if err := yaml.UnmarshalStrict(data, experiment); err != nil {
+		return errors.Errorf("unable to parse the spec file %v, err: %v", specFile, err)
+	}
+	if experiment.Name == "" {
+		return errors.Errorf("experiment name is not provided in the spec file %v", specFile)
+	}
+
+	for _, env := range experiment.Spec.Components.ENV {
+		if env.ValueFrom != nil {
+			return errors.Errorf("valueFrom is not supported for the %v env in standalone mode, provide the value instead", env.Name)
+		}
+		if _, ok := os.LookupEnv(env.Name); ok {
+			continue
+		}
+		if err := os.Setenv(env.Name, env.Value); err != nil {
+			return errors.Errorf("unable to set the %v env, err: %v", env.Name, err)
+		}
+	}
+	if _, ok := os.LookupEnv("EXPERIMENT_NAME"); !ok {
+		os.Setenv("EXPERIMENT_NAME", experiment.Name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	spec = experiment
+	resultFile = resultPath
+	return nil
+}
+
+// Enabled returns true, if the experiment is running in standalone mode
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return spec != nil
+}
+
+// ExperimentName returns the name of the experiment, defined inside the spec file
+func ExperimentName() string {
+	mu.Lock()
+	defer mu.Unlock()
+	if spec == nil {
+		return ""
+	}
+	return spec.Name
+}
+
+// Probes returns the probes, defined inside the spec file
+func Probes() []types.ProbeAttributes {
+	mu.Lock()
+	defer mu.Unlock()
+	if spec == nil {
+		return nil
+	}
+	return spec.Spec.Probe
+}
+
+// ReadResult reads the chaosresult from the result file
+// it returns nil, if the result file doesn't exist yet
+func ReadResult() (*v1alpha1.ChaosResult, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	data, err := ioutil.ReadFile(resultFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Errorf("unable to read the result file %v, err: %v", resultFile, err)
+	}
+
+	result := &v1alpha1.ChaosResult{}
+	if err := yaml.Unmarshal(data, result); err != nil {
+		return nil, errors.Errorf("unable to parse the result file %v, err: %v", resultFile, err)
+	}
+	return result, nil
+}
+
+// WriteResult writes the chaosresult to the result file
+// the result is written in json format, if the file has .json extension, else in yaml format
+func WriteResult(result *v1alpha1.ChaosResult) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	result.Kind = "ChaosResult"
+
+	var data []byte
+	var err error
+	if strings.ToLower(filepath.Ext(resultFile)) == ".json" {
+		data, err = json.MarshalIndent(result, "", "  ")
+	} else {
+		data, err = yaml.Marshal(result)
+	}
+	if err != nil {
+		return errors.Errorf("unable to marshal the chaosresult, err: %v", err)
+	}
+
+	// write to a temporary file first, so that an abort during the write doesn't leave a partial result behind
+	tmpFile := resultFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0644); err != nil {
+		return errors.Errorf("unable to write the result file %v, err: %v", resultFile, err)
+	}
+	if err := os.Rename(tmpFile, resultFile); err != nil {
+		return errors.Errorf("unable to write the result file %v, err: %v", resultFile, err)
+	}
+	return nil
+}
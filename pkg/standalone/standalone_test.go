@@ -0,0 +1,215 @@
+package standalone
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const validSpec = `
+name: pod-delete
+spec:
+  components:
+    env:
+    - name: TEST_TOTAL_CHAOS_DURATION
+      value: "60"
+    - name: TEST_APP_NAMESPACE
+      value: shop
+  probe:
+  - name: check-frontend
+    type: httpProbe
+    mode: Continuous
+`
+
+// reset disables the standalone mode, used by the tests
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	spec, resultFile = nil, ""
+}
+
+// writeSpec writes the given spec inside a temporary directory and returns the directory & spec file
+func writeSpec(t *testing.T, content string) (string, string) {
+	dir, err := ioutil.TempDir("", "standalone")
+	if err != nil {
+		t.Fatalf("unable to create the temporary directory, err: %v", err)
+	}
+	specFile := filepath.Join(dir, "spec.yaml")
+	if err := ioutil.WriteFile(specFile, []byte(content), 0644); err != nil {
+		t.Fatalf("unable to write the spec file, err: %v", err)
+	}
+	return dir, specFile
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		env     map[string]string
+		wantErr string
+		wantEnv map[string]string
+	}{
+		{
+			name:    "engine-less spec",
+			spec:    validSpec,
+			wantEnv: map[string]string{"TEST_TOTAL_CHAOS_DURATION": "60", "TEST_APP_NAMESPACE": "shop", "EXPERIMENT_NAME": "pod-delete"},
+		},
+		{
+			name:    "environment takes precedence",
+			spec:    validSpec,
+			env:     map[string]string{"TEST_TOTAL_CHAOS_DURATION": "30", "EXPERIMENT_NAME": "pod-delete-custom"},
+			wantEnv: map[string]string{"TEST_TOTAL_CHAOS_DURATION": "30", "TEST_APP_NAMESPACE": "shop", "EXPERIMENT_NAME": "pod-delete-custom"},
+		},
+		{
+			name:    "missing name",
+			spec:    "spec:\n  components:\n    env: []\n",
+			wantErr: "experiment name is not provided",
+		},
+		{
+			name:    "unknown field",
+			spec:    "name: pod-delete\nspec:\n  component: {}\n",
+			wantErr: "unable to parse the spec file",
+		},
+		{
+			name:    "valueFrom env",
+			spec:    "name: pod-delete\nspec:\n  components:\n    env:\n    - name: TEST_APP_NAMESPACE\n      valueFrom:\n        fieldRef:\n          fieldPath: metadata.namespace\n",
+			wantErr: "valueFrom is not supported for the TEST_APP_NAMESPACE env",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			defer reset()
+			for _, name := range []string{"TEST_TOTAL_CHAOS_DURATION", "TEST_APP_NAMESPACE", "EXPERIMENT_NAME"} {
+				os.Unsetenv(name)
+				defer os.Unsetenv(name)
+			}
+			for name, value := range tt.env {
+				os.Setenv(name, value)
+			}
+			dir, specFile := writeSpec(t, tt.spec)
+			defer os.RemoveAll(dir)
+
+			err := Load(specFile, filepath.Join(dir, "result.yaml"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected %q error, found %v", tt.wantErr, err)
+				}
+				if Enabled() {
+					t.Fatalf("expected the standalone mode to be disabled, as the spec is invalid")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !Enabled() || ExperimentName() != "pod-delete" {
+				t.Fatalf("expected the standalone mode to be enabled for pod-delete, found %v", ExperimentName())
+			}
+			if probes := Probes(); len(probes) != 1 || probes[0].Name != "check-frontend" || probes[0].Mode != "Continuous" {
+				t.Fatalf("unexpected probes %+v", probes)
+			}
+			for name, value := range tt.wantEnv {
+				if got := os.Getenv(name); got != value {
+					t.Errorf("expected %v env to be %q, found %q", name, value, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	reset()
+	if err := Load(filepath.Join(os.TempDir(), "missing-standalone-spec.yaml"), "result.yaml"); err == nil {
+		t.Fatalf("expected an error, as the spec file doesn't exist")
+	}
+}
+
+func TestResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		resultFile string
+		wantPrefix string
+	}{
+		{name: "yaml", resultFile: "result.yaml", wantPrefix: "apiVersion: litmuschaos.io/v1alpha1"},
+		{name: "json", resultFile: "result.json", wantPrefix: "{"},
+		{name: "uppercase json extension", resultFile: "result.JSON", wantPrefix: "{"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			defer reset()
+			os.Unsetenv("EXPERIMENT_NAME")
+			defer os.Unsetenv("EXPERIMENT_NAME")
+			dir, specFile := writeSpec(t, "name: pod-delete\n")
+			defer os.RemoveAll(dir)
+
+			resultFile := filepath.Join(dir, tt.resultFile)
+			if err := Load(specFile, resultFile); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// there is no result, until it is written
+			result, err := ReadResult()
+			if err != nil || result != nil {
+				t.Fatalf("expected no result, found %v, err: %v", result, err)
+			}
+
+			written := &v1alpha1.ChaosResult{
+				ObjectMeta: metav1.ObjectMeta{Name: "engine-pod-delete", Labels: map[string]string{"name": "engine-pod-delete"}},
+				Status: v1alpha1.ChaosResultStatus{
+					ExperimentStatus: v1alpha1.TestStatus{Phase: "Completed", Verdict: "Pass", ProbeSuccessPercentage: "100"},
+				},
+			}
+			if err := WriteResult(written); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			data, err := ioutil.ReadFile(resultFile)
+			if err != nil {
+				t.Fatalf("unable to read the result file, err: %v", err)
+			}
+			if !strings.HasPrefix(string(data), tt.wantPrefix) {
+				t.Fatalf("expected the result to start with %q, found %s", tt.wantPrefix, data)
+			}
+			if _, err := os.Stat(resultFile + ".tmp"); !os.IsNotExist(err) {
+				t.Fatalf("expected the temporary result file to be removed, err: %v", err)
+			}
+
+			result, err = ReadResult()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Kind != "ChaosResult" || result.Name != "engine-pod-delete" || result.Status.ExperimentStatus.Verdict != "Pass" {
+				t.Fatalf("unexpected result %+v", result)
+			}
+		})
+	}
+}
+
+func TestReadInvalidResult(t *testing.T) {
+	reset()
+	defer reset()
+	os.Unsetenv("EXPERIMENT_NAME")
+	defer os.Unsetenv("EXPERIMENT_NAME")
+	dir, specFile := writeSpec(t, "name: pod-delete\n")
+	defer os.RemoveAll(dir)
+
+	resultFile := filepath.Join(dir, "result.yaml")
+	if err := Load(specFile, resultFile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ioutil.WriteFile(resultFile, []byte("status: ["), 0644); err != nil {
+		t.Fatalf("unable to write the result file, err: %v", err)
+	}
+	if _, err := ReadResult(); err == nil {
+		t.Fatalf("expected an error, as the result file is invalid")
+	}
+}
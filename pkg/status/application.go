@@ -1,11 +1,13 @@
 package status
 
 import (
+	"context"
 	"strings"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/annotation"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
@@ -18,11 +20,11 @@ import (
 // AUTStatusCheck checks the status of application under test
 // if annotationCheck is true, it will check the status of the annotated pod only
 // else it will check status of all pods with matching label
-func AUTStatusCheck(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func AUTStatusCheck(ctx context.Context, appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	switch chaosDetails.AppDetail.AnnotationCheck {
 	case true:
-		return AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName, timeout, delay, clients, chaosDetails)
+		return AnnotatedApplicationsStatusCheck(ctx, appNs, appLabel, containerName, timeout, delay, clients, chaosDetails)
 	default:
 		switch appLabel {
 		case "":
@@ -31,12 +33,12 @@ func AUTStatusCheck(appNs, appLabel, containerName string, timeout, delay int, c
 		default:
 			// Checking whether application containers are in ready state
 			log.Info("[Status]: Checking whether application containers are in ready state")
-			if err := CheckContainerStatus(appNs, appLabel, containerName, timeout, delay, clients); err != nil {
+			if err := CheckContainerStatus(ctx, appNs, appLabel, containerName, timeout, delay, clients); err != nil {
 				return err
 			}
 			// Checking whether application pods are in running state
 			log.Info("[Status]: Checking whether application pods are in running state")
-			if err := CheckPodStatus(appNs, appLabel, timeout, delay, clients); err != nil {
+			if err := CheckPodStatus(ctx, appNs, appLabel, timeout, delay, clients); err != nil {
 				return err
 			}
 		}
@@ -45,12 +47,12 @@ func AUTStatusCheck(appNs, appLabel, containerName string, timeout, delay int, c
 }
 
 // AnnotatedApplicationsStatusCheck checks the status of all the annotated applications with matching label
-func AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
+func AnnotatedApplicationsStatusCheck(ctx context.Context, appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) error {
 
 	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
+		Times(uint(timeout/delay)).
+		Wait(time.Duration(delay)*time.Second).
+		Do(ctx, func(ctx context.Context, attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil || len(podList.Items) == 0 {
 				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
@@ -65,7 +67,7 @@ func AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName string, tim
 					case "":
 						for _, container := range pod.Status.ContainerStatuses {
 							if container.State.Terminated != nil {
-								return errors.Errorf("container is in terminated state")
+								return retry.Permanent(errors.Errorf("container is in terminated state"))
 							}
 							if !container.Ready {
 								return errors.Errorf("containers are not yet in running state")
@@ -77,7 +79,7 @@ func AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName string, tim
 						for _, container := range pod.Status.ContainerStatuses {
 							if containerName == container.Name {
 								if container.State.Terminated != nil {
-									return errors.Errorf("container is in terminated state")
+									return retry.Permanent(errors.Errorf("container is in terminated state"))
 								}
 								if !container.Ready {
 									return errors.Errorf("containers are not yet in running state")
@@ -99,7 +101,7 @@ func AnnotatedApplicationsStatusCheck(appNs, appLabel, containerName string, tim
 }
 
 // CheckApplicationStatus checks the status of the AUT
-func CheckApplicationStatus(appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
+func CheckApplicationStatus(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
 
 	switch appLabel {
 	case "":
@@ -108,12 +110,12 @@ func CheckApplicationStatus(appNs, appLabel string, timeout, delay int, clients
 	default:
 		// Checking whether application containers are in ready state
 		log.Info("[Status]: Checking whether application containers are in ready state")
-		if err := CheckContainerStatus(appNs, appLabel, "", timeout, delay, clients); err != nil {
+		if err := CheckContainerStatus(ctx, appNs, appLabel, "", timeout, delay, clients); err != nil {
 			return err
 		}
 		// Checking whether application pods are in running state
 		log.Info("[Status]: Checking whether application pods are in running state")
-		if err := CheckPodStatus(appNs, appLabel, timeout, delay, clients); err != nil {
+		if err := CheckPodStatus(ctx, appNs, appLabel, timeout, delay, clients); err != nil {
 			return err
 		}
 	}
@@ -121,13 +123,13 @@ func CheckApplicationStatus(appNs, appLabel string, timeout, delay int, clients
 }
 
 // CheckAuxiliaryApplicationStatus checks the status of the Auxiliary applications
-func CheckAuxiliaryApplicationStatus(AuxiliaryAppDetails string, timeout, delay int, clients clients.ClientSets) error {
+func CheckAuxiliaryApplicationStatus(ctx context.Context, AuxiliaryAppDetails string, timeout, delay int, clients clients.ClientSets) error {
 
 	AuxiliaryAppInfo := strings.Split(AuxiliaryAppDetails, ",")
 
 	for _, val := range AuxiliaryAppInfo {
 		AppInfo := strings.Split(val, ":")
-		if err := CheckApplicationStatus(AppInfo[0], AppInfo[1], timeout, delay, clients); err != nil {
+		if err := CheckApplicationStatus(ctx, AppInfo[0], AppInfo[1], timeout, delay, clients); err != nil {
 			return err
 		}
 	}
@@ -135,11 +137,11 @@ func CheckAuxiliaryApplicationStatus(AuxiliaryAppDetails string, timeout, delay
 }
 
 // CheckPodStatusPhase checks the status of the application pod
-func CheckPodStatusPhase(appNs, appLabel string, timeout, delay int, clients clients.ClientSets, states ...string) error {
+func CheckPodStatusPhase(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets, states ...string) error {
 	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
+		Times(uint(timeout/delay)).
+		Wait(time.Duration(delay)*time.Second).
+		Do(ctx, func(ctx context.Context, attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil || len(podList.Items) == 0 {
 				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
@@ -167,17 +169,17 @@ func isOneOfState(podState string, states []string) bool {
 }
 
 // CheckPodStatus checks the running status of the application pod
-func CheckPodStatus(appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
-	return CheckPodStatusPhase(appNs, appLabel, timeout, delay, clients, "Running")
+func CheckPodStatus(ctx context.Context, appNs, appLabel string, timeout, delay int, clients clients.ClientSets) error {
+	return CheckPodStatusPhase(ctx, appNs, appLabel, timeout, delay, clients, "Running")
 }
 
 // CheckContainerStatus checks the status of the application container
-func CheckContainerStatus(appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets) error {
+func CheckContainerStatus(ctx context.Context, appNs, appLabel, containerName string, timeout, delay int, clients clients.ClientSets) error {
 
 	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
+		Times(uint(timeout/delay)).
+		Wait(time.Duration(delay)*time.Second).
+		Do(ctx, func(ctx context.Context, attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil || len(podList.Items) == 0 {
 				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
@@ -203,7 +205,7 @@ func validateContainerStatus(containerName, podName string, ContainerStatuses []
 	for _, container := range ContainerStatuses {
 		if container.Name == containerName {
 			if container.State.Terminated != nil {
-				return errors.Errorf("container is in terminated state")
+				return retry.Permanent(errors.Errorf("container is in terminated state"))
 			}
 			if !container.Ready {
 				return errors.Errorf("containers are not yet in running state")
@@ -226,15 +228,16 @@ func validateAllContainerStatus(podName string, ContainerStatuses []v1.Container
 }
 
 // WaitForCompletion wait until the completion of pod
-func WaitForCompletion(appNs, appLabel string, clients clients.ClientSets, duration int, containerName string) (string, error) {
+// it stops waiting, once the context is cancelled
+func WaitForCompletion(ctx context.Context, appNs, appLabel string, clients clients.ClientSets, duration int, containerName string) (string, error) {
 	var podStatus string
 	failedPods := 0
 	// It will wait till the completion of target container
 	// it will retries until the target container completed or met the timeout(chaos duration)
 	err := retry.
 		Times(uint(duration)).
-		Wait(1 * time.Second).
-		Try(func(attempt uint) error {
+		Wait(1*time.Second).
+		Do(ctx, func(ctx context.Context, attempt uint) error {
 			podList, err := clients.KubeClient.CoreV1().Pods(appNs).List(metav1.ListOptions{LabelSelector: appLabel})
 			if err != nil || len(podList.Items) == 0 {
 				return errors.Errorf("Unable to find the pods with matching labels, err: %v", err)
@@ -267,6 +270,7 @@ func WaitForCompletion(appNs, appLabel string, clients clients.ClientSets, durat
 			return nil
 		})
 	if failedPods > 0 {
+		metrics.HelperPodsFailed(failedPods)
 		return "Failed", err
 	}
 	return podStatus, err
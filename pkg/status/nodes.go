@@ -1,12 +1,13 @@
 package status
 
 import (
+	"context"
 	"strings"
 	"time"
 
 	clients "github.com/litmuschaos/litmus-go/pkg/clients"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/openebs/maya/pkg/util/retry"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
 	"github.com/pkg/errors"
 	logrus "github.com/sirupsen/logrus"
 	apiv1 "k8s.io/api/core/v1"
@@ -14,13 +15,13 @@ import (
 )
 
 // CheckNodeStatus checks the status of the node
-func CheckNodeStatus(nodes string, timeout, delay int, clients clients.ClientSets) error {
-
-	nodeList := apiv1.NodeList{}
+func CheckNodeStatus(ctx context.Context, nodes string, timeout, delay int, clients clients.ClientSets) error {
 	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
+		Times(uint(timeout/delay)).
+		Wait(time.Duration(delay)*time.Second).
+		Do(ctx, func(ctx context.Context, attempt uint) error {
+			// the node list is built afresh in every attempt
+			nodeList := apiv1.NodeList{}
 			if nodes != "" {
 				targetNodes := strings.Split(nodes, ",")
 				for index := range targetNodes {
@@ -57,11 +58,11 @@ func CheckNodeStatus(nodes string, timeout, delay int, clients clients.ClientSet
 }
 
 // CheckNodeNotReadyState check for node to be in not ready state
-func CheckNodeNotReadyState(nodeName string, timeout, delay int, clients clients.ClientSets) error {
+func CheckNodeNotReadyState(ctx context.Context, nodeName string, timeout, delay int, clients clients.ClientSets) error {
 	return retry.
-		Times(uint(timeout / delay)).
-		Wait(time.Duration(delay) * time.Second).
-		Try(func(attempt uint) error {
+		Times(uint(timeout/delay)).
+		Wait(time.Duration(delay)*time.Second).
+		Do(ctx, func(ctx context.Context, attempt uint) error {
 			node, err := clients.KubeClient.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
 			if err != nil {
 				return err
@@ -0,0 +1,300 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+)
+
+// the probes are decoded with the extended probe schema, which contains the attributes not known to the chaos-operator types yet
+// the attributes of the chaos-operator schema are inlined, so that the existing probe definitions keep working as before
+
+// Experiment is the experiment entry inside the chaosengine or the standalone spec file
+type Experiment struct {
+	Name string         `json:"name"`
+	Spec ExperimentSpec `json:"spec"`
+}
+
+// ExperimentSpec contains the components & probes of the experiment
+type ExperimentSpec struct {
+	v1alpha1.ExperimentAttributes `json:",inline"`
+	Probe                         []ProbeAttributes `json:"probe,omitempty"`
+}
+
+// ProbeAttributes contains the details of the probe
+type ProbeAttributes struct {
+	v1alpha1.ProbeAttributes `json:",inline"`
+	RunProperties            RunProperty `json:"runProperties,omitempty"`
+	// inputs needed for the http probe, it extends the http probe inputs of the chaos-operator schema
+	HTTPProbeInputs HTTPProbeInputs `json:"httpProbe/inputs,omitempty"`
+	// inputs needed for the k8s probe, it extends the k8s probe inputs of the chaos-operator schema
+	K8sProbeInputs K8sProbeInputs `json:"k8sProbe/inputs,omitempty"`
+	// inputs needed for the prom probe, it extends the prom probe inputs of the chaos-operator schema
+	PromProbeInputs PromProbeInputs `json:"promProbe/inputs,omitempty"`
+	// inputs needed for the grpc probe
+	GRPCProbeInputs GRPCProbeInputs `json:"grpcProbe/inputs,omitempty"`
+	// inputs needed for the tcp probe
+	TCPProbeInputs TCPProbeInputs `json:"tcpProbe/inputs,omitempty"`
+	// inputs needed for the log probe
+	LogProbeInputs LogProbeInputs `json:"logProbe/inputs,omitempty"`
+	// inputs needed for the event probe
+	EventProbeInputs EventProbeInputs `json:"eventProbe/inputs,omitempty"`
+	// inputs needed for the sql probe
+	SQLProbeInputs SQLProbeInputs `json:"sqlProbe/inputs,omitempty"`
+}
+
+// K8sProbeInputs contains all the inputs required for the k8s probe
+type K8sProbeInputs struct {
+	v1alpha1.K8sProbeInputs `json:",inline"`
+	// JSONPath is evaluated over each matched resource by the check operation, e.g. {.status.availableReplicas}
+	// the check operation verifies the number of the matched resources, if it is empty
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Comparator verifies the value of the jsonpath of every matched resource, or the number of the matched resources
+	Comparator v1alpha1.ComparatorInfo `json:"comparator,omitempty"`
+}
+
+// HTTPProbeInputs contains all the inputs required for the http probe
+type HTTPProbeInputs struct {
+	v1alpha1.HTTPProbeInputs `json:",inline"`
+	// Method define the http method, it can be get, post, put, patch, delete or head
+	Method HTTPMethod `json:"method,omitempty"`
+	// Headers contains the request headers
+	Headers []HTTPHeader `json:"headers,omitempty"`
+	// Auth contains the bearer token or the basic auth credentials
+	Auth HTTPAuth `json:"auth,omitempty"`
+	// TLS contains the client certificates (mTLS) and the CA certificate
+	TLS TLSConfig `json:"tls,omitempty"`
+	// ResponseBody matches the response body with the string comparator
+	ResponseBody v1alpha1.ComparatorInfo `json:"responseBody,omitempty"`
+	// JSONPath matches the fields of the json response body, extracted with the jsonpath, with the comparators
+	JSONPath []JSONPathCheck `json:"jsonPath,omitempty"`
+	// SLO contains the latency & error rate criteria of the continuous & onchaos probes, verified over the chaos window
+	// the failed requests are counted in the error rate, instead of failing the probe, if the errorRate slo is provided
+	SLO []SLOCheck `json:"slo,omitempty"`
+}
+
+// SLOCheck contains the criteria of the latency or the error rate of the http probe
+type SLOCheck struct {
+	// Metric can be p50, p95, p99, max (latency in milliseconds) or errorRate (percentage of the failed requests)
+	Metric string `json:"metric"`
+	// Criteria for matching data, it supports >=, <=, ==, >, <, !=
+	Criteria string `json:"criteria"`
+	// Value contains relative value for criteria
+	Value string `json:"value"`
+}
+
+// LatencySummary contains the latency percentiles & the error rate of the http probe requests over the chaos window
+type LatencySummary struct {
+	Requests  int
+	Errors    int
+	ErrorRate float64
+	P50       time.Duration
+	P95       time.Duration
+	P99       time.Duration
+	Max       time.Duration
+}
+
+// String returns the latency summary, which is stored inside the probe status
+func (s LatencySummary) String() string {
+	return fmt.Sprintf("p50: %v, p95: %v, p99: %v, max: %v, errorRate: %.2f%% (%d/%d requests)",
+		milliseconds(s.P50), milliseconds(s.P95), milliseconds(s.P99), milliseconds(s.Max), s.ErrorRate, s.Errors, s.Requests)
+}
+
+// milliseconds returns the duration in milliseconds, with one decimal
+func milliseconds(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 1, 64) + "ms"
+}
+
+// HTTPMethod define the http method details
+type HTTPMethod struct {
+	v1alpha1.HTTPMethod `json:",inline"`
+	Put                 v1alpha1.PostMethod `json:"put,omitempty"`
+	Patch               v1alpha1.PostMethod `json:"patch,omitempty"`
+	Delete              v1alpha1.GetMethod  `json:"delete,omitempty"`
+	Head                v1alpha1.GetMethod  `json:"head,omitempty"`
+}
+
+// HTTPHeader contains the name and the value of the request header
+type HTTPHeader struct {
+	Name        string `json:"name"`
+	ValueSource `json:",inline"`
+}
+
+// HTTPAuth contains the authentication details of the http request
+// the bearer token is used, if both are provided
+type HTTPAuth struct {
+	// BearerToken is sent as the "Authorization: Bearer <token>" header
+	BearerToken *ValueSource `json:"bearerToken,omitempty"`
+	// BasicAuth contains the username and the password of the basic auth
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+}
+
+// BasicAuth contains the username and the password of the basic auth
+type BasicAuth struct {
+	Username ValueSource `json:"username,omitempty"`
+	Password ValueSource `json:"password,omitempty"`
+}
+
+// JSONPathCheck contains the jsonpath of the field and the comparator for its value
+type JSONPathCheck struct {
+	// Path contains the jsonpath of the field, e.g. {.status} or .status
+	Path       string                  `json:"path"`
+	Comparator v1alpha1.ComparatorInfo `json:"comparator"`
+}
+
+// ValueSource contains the literal value or the reference of the secret key, which contains the value
+type ValueSource struct {
+	Value        string        `json:"value,omitempty"`
+	SecretKeyRef *SecretKeyRef `json:"secretKeyRef,omitempty"`
+}
+
+// SecretKeyRef refers to the key of the secret, the secret is read from the chaos namespace
+type SecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// TLSConfig contains the client certificates (mTLS) and the CA certificate
+// the certificates are read from the secret, if the secret name is provided, or else from the given paths
+type TLSConfig struct {
+	// SecretName contains the name of the secret in the chaos namespace, with the tls.crt, tls.key & ca.crt keys
+	SecretName string `json:"secretName,omitempty"`
+	// CACertPath contains the path of the CA certificate, which is used to verify the server certificate
+	CACertPath string `json:"caCertPath,omitempty"`
+	// CertPath contains the path of the client certificate
+	CertPath string `json:"certPath,omitempty"`
+	// KeyPath contains the path of the client key
+	KeyPath string `json:"keyPath,omitempty"`
+	// ServerName overrides the server name, which is used to verify the server certificate
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify flag to skip certificate checks
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// PromProbeInputs contains all the inputs required for the prom probe
+type PromProbeInputs struct {
+	v1alpha1.PromProbeInputs `json:",inline"`
+	// Auth contains the bearer token or the basic auth credentials of the prometheus api
+	Auth HTTPAuth `json:"auth,omitempty"`
+	// TLS contains the client certificates (mTLS) and the CA certificate of the prometheus api
+	TLS TLSConfig `json:"tls,omitempty"`
+	// Match defines whether all (default) or any of the series must satisfy the comparator
+	Match string `json:"match,omitempty"`
+	// QueryType can be instant (default) or range
+	// the range query is evaluated over the chaos window and every sample of the series must satisfy the comparator
+	QueryType string `json:"queryType,omitempty"`
+	// Step contains the resolution of the range query, it defaults to 15s
+	Step string `json:"step,omitempty"`
+	// ResponseTimeout contains the response timeout of the prometheus api, in milliseconds
+	ResponseTimeout int `json:"responseTimeout,omitempty"`
+}
+
+// GRPCProbeInputs contains all the inputs required for the grpc probe
+type GRPCProbeInputs struct {
+	// Endpoint of the grpc server, in the host:port format
+	Endpoint string `json:"endpoint,omitempty"`
+	// Service name, whose health is checked via grpc.health.v1.Health/Check
+	// it checks the overall health of the server, if it is empty
+	Service string `json:"service,omitempty"`
+	// Insecure flag to use the plaintext connection instead of TLS
+	Insecure bool `json:"insecure,omitempty"`
+	// InsecureSkipVerify flag to skip certificate checks
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// CACertPath contains the path of the CA certificate, which is used to verify the server certificate
+	CACertPath string `json:"caCertPath,omitempty"`
+	// ServerName overrides the server name, which is used to verify the server certificate
+	ServerName string `json:"serverName,omitempty"`
+	// ExpectedStatus contains the expected serving status, it defaults to SERVING
+	ExpectedStatus string `json:"expectedStatus,omitempty"`
+	// ResponseTimeout contains the grpc response timeout, in milliseconds
+	ResponseTimeout int `json:"responseTimeout,omitempty"`
+}
+
+// RunProperty contains timeout, retry, interval and the failure policy of the probe
+type RunProperty struct {
+	v1alpha1.RunProperty `json:",inline"`
+	// StopOnFailure halts the experiment and reverts the chaos, as soon as the continuous or onchaos probe fails
+	StopOnFailure bool `json:"stopOnFailure,omitempty"`
+}
+
+// TCPProbeInputs contains all the inputs required for the tcp probe
+type TCPProbeInputs struct {
+	// Endpoint which needs to be dialled, in the host:port format
+	Endpoint string `json:"endpoint,omitempty"`
+	// Protocol contains the network protocol, it supports tcp (default) and udp (requires the payload & comparator)
+	Protocol string `json:"protocol,omitempty"`
+	// Payload is sent once the connection is established, e.g. "PING\r\n"
+	Payload string `json:"payload,omitempty"`
+	// Comparator matches the response (or the banner, if payload is empty) with the string operators
+	// the response isn't read, if the criteria is empty
+	Comparator v1alpha1.ComparatorInfo `json:"comparator,omitempty"`
+	// ResponseTimeout contains the deadline of the dial, write & read operations, in milliseconds
+	ResponseTimeout int `json:"responseTimeout,omitempty"`
+}
+
+// LogProbeInputs contains all the inputs required for the log probe
+type LogProbeInputs struct {
+	// Namespace of the pods, it defaults to the application namespace
+	Namespace string `json:"namespace,omitempty"`
+	// LabelSelector of the pods, whose logs are read, it defaults to the application label
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Container whose logs are read, the logs of all the containers are read, if it is empty
+	Container string `json:"container,omitempty"`
+	// Pattern contains the regex, which is matched with every log line
+	Pattern string `json:"pattern,omitempty"`
+	// Criteria can be matches (the pattern appears at least once), notMatches (the pattern doesn't appear)
+	// or atMost (the pattern appears at most count times)
+	Criteria string `json:"criteria,omitempty"`
+	// Count contains the maximum number of the matched log lines, for the atMost criteria
+	Count int `json:"count,omitempty"`
+}
+
+// EventProbeInputs contains all the inputs required for the event probe
+type EventProbeInputs struct {
+	// Namespaces whose events are verified, it defaults to the application namespace and is required, if there is none
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Reasons contains the unexpected event reasons, e.g. OOMKilling, BackOff, FailedScheduling, Unhealthy
+	// every Warning event is unexpected, if it is empty
+	Reasons []string `json:"reasons,omitempty"`
+	// AllowList contains the involved objects, whose events are expected, e.g. the target pods
+	AllowList []EventFilter `json:"allowList,omitempty"`
+	// DenyList contains the involved objects, whose events are always unexpected
+	// it takes precedence over the allow list
+	DenyList []EventFilter `json:"denyList,omitempty"`
+}
+
+// EventFilter matches the events with the kind & name of the involved object and the reason
+type EventFilter struct {
+	// Kind of the involved object, e.g. Pod, it matches all the kinds, if it is empty
+	Kind string `json:"kind,omitempty"`
+	// Name contains the regex of the involved object name, e.g. checkout-.*, it matches all the names, if it is empty
+	Name string `json:"name,omitempty"`
+	// Reasons of the events, it matches all the reasons, if it is empty
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// SQLProbeInputs contains all the inputs required for the sql probe
+type SQLProbeInputs struct {
+	// Driver contains the database driver, it supports postgres and mysql
+	Driver string `json:"driver,omitempty"`
+	// Endpoint of the database server, in the host:port format
+	Endpoint string `json:"endpoint,omitempty"`
+	// Database contains the name of the database
+	Database string `json:"database,omitempty"`
+	// Username & Password contains the credentials of the database, which can be read from the secret
+	Username ValueSource `json:"username,omitempty"`
+	Password ValueSource `json:"password,omitempty"`
+	// Params contains the connection parameters of the driver, e.g. sslmode for postgres or tls for mysql
+	Params map[string]string `json:"params,omitempty"`
+	// DataSource contains the entire connection string of the driver, which can be read from the secret
+	// the endpoint, database, credentials & params are ignored, if it is provided
+	DataSource ValueSource `json:"dataSource,omitempty"`
+	// Query is executed on every probe iteration, the first column of the first row is compared with the comparator
+	Query string `json:"query,omitempty"`
+	// Comparator check for the correctness of the first column of the query result
+	Comparator v1alpha1.ComparatorInfo `json:"comparator,omitempty"`
+	// ResponseTimeout contains the deadline of the connection & the query, in milliseconds
+	ResponseTimeout int `json:"responseTimeout,omitempty"`
+}
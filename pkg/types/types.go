@@ -21,6 +21,14 @@ const (
 	FailVerdict string = "Fail"
 	// StoppedVerdict marked the verdict as stopped in the end of experiment
 	StoppedVerdict string = "Stopped"
+	// TargetedStatus marks the target as selected, the chaos is not injected yet
+	TargetedStatus string = "Targeted"
+	// InjectedStatus marks the chaos as injected on the target
+	InjectedStatus string = "Injected"
+	// RevertedStatus marks the chaos as reverted (or completed) on the target
+	RevertedStatus string = "Reverted"
+	// FailedStatus marks the chaos as failed on the target, it might not be reverted
+	FailedStatus string = "Failed"
 )
 
 // ResultDetails is for collecting all the chaos-result-related details
@@ -53,6 +61,8 @@ type ProbeDetails struct {
 	IsProbeFailedWithError error
 	RunID                  string
 	RunCount               int
+	// Latency contains the latency summary of the continuous & onchaos http probes
+	Latency *LatencySummary
 }
 
 // EventDetails is for collecting all the events-related details
@@ -79,6 +89,44 @@ type ChaosDetails struct {
 	JobCleanupPolicy     string
 	ProbeImagePullPolicy string
 	Randomness           bool
+	Guardrails           Guardrails
+	// Targets, Parameters and ChaosWindow are recorded in the chaosresult, for the post-mortem of the run
+	Targets     []TargetDetails
+	Parameters  map[string]string
+	ChaosWindow ChaosWindow
+}
+
+// TargetDetails contains the details of a target, selected by the experiment, along with the status of the chaos on it
+type TargetDetails struct {
+	// Kind is the kind of the target, e.g. pod, node, ec2-instance or ebs-volume
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Node      string `json:"node,omitempty"`
+	Container string `json:"container,omitempty"`
+	// ChaosStartTime and ChaosEndTime are in RFC3339 format
+	ChaosStartTime string `json:"chaosStartTime,omitempty"`
+	ChaosEndTime   string `json:"chaosEndTime,omitempty"`
+	// ChaosStatus is one of Targeted, Injected, Reverted and Failed
+	ChaosStatus string `json:"chaosStatus"`
+}
+
+// ChaosWindow contains the start & end time of the chaos injection, in RFC3339 format
+type ChaosWindow struct {
+	StartTime string `json:"startTime,omitempty"`
+	EndTime   string `json:"endTime,omitempty"`
+}
+
+// Guardrails contains the blast radius policies, evaluated against the selected targets before the chaos injection
+// the namespaces & labels are comma separated, the labels are in key or key=value format
+// "none" disables the default protected namespaces & labels, 0 disables the max targets limits
+type Guardrails struct {
+	ProtectedNamespaces  string `env:"GUARDRAIL_PROTECTED_NAMESPACES" default:"kube-system"`
+	ProtectedLabels      string `env:"GUARDRAIL_PROTECTED_LABELS" default:"node-role.kubernetes.io/master,node-role.kubernetes.io/control-plane"`
+	MaxTargets           int    `env:"GUARDRAIL_MAX_TARGETS" default:"0" min:"0"`
+	MaxTargetsPercentage int    `env:"GUARDRAIL_MAX_TARGETS_PERC" default:"0" min:"0" max:"100"`
+	AllowAllReplicas     bool   `env:"GUARDRAIL_ALLOW_ALL_REPLICAS" default:"false"`
+	RespectPDB           bool   `env:"GUARDRAIL_RESPECT_PDB" default:"true"`
 }
 
 // AppDetails contains all the application related envs
@@ -0,0 +1,122 @@
+package annotation
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testNamespace = "app-ns"
+	annotationKey = "litmuschaos.io/chaos"
+)
+
+var (
+	appLabels = map[string]string{"app": "nginx"}
+	annotated = map[string]string{annotationKey: "true"}
+)
+
+func objectMeta(name string, annotations map[string]string, owners ...metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       testNamespace,
+		Labels:          appLabels,
+		Annotations:     annotations,
+		OwnerReferences: owners,
+	}
+}
+
+func owner(kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{Kind: kind, Name: name}
+}
+
+func newUnstructured(apiVersion, kind, name string, annotations map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetName(name)
+	u.SetNamespace(testNamespace)
+	u.SetLabels(appLabels)
+	u.SetAnnotations(annotations)
+	return u
+}
+
+func TestIsPodParentAnnotated(t *testing.T) {
+	kubeObjects := []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: objectMeta("deploy", annotated)},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("deploy-rs", nil, owner("Deployment", "deploy"))},
+		&appsv1.StatefulSet{ObjectMeta: objectMeta("sts", annotated)},
+		&appsv1.DaemonSet{ObjectMeta: objectMeta("ds", nil)},
+		&corev1.ReplicationController{ObjectMeta: objectMeta("dc-rc", nil, owner("DeploymentConfig", "dc"))},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("ro-rs", nil, owner("Rollout", "ro"))},
+	}
+	dynamicObjects := []runtime.Object{
+		newUnstructured("apps.openshift.io/v1", "DeploymentConfig", "dc", annotated),
+		newUnstructured("argoproj.io/v1alpha1", "Rollout", "ro", nil),
+	}
+
+	tests := []struct {
+		name    string
+		kind    string
+		owner   metav1.OwnerReference
+		want    bool
+		wantErr bool
+	}{
+		{name: "annotated deployment", kind: "deployment", owner: owner("ReplicaSet", "deploy-rs"), want: true},
+		{name: "pod of another replicaset", kind: "deployment", owner: owner("ReplicaSet", "ro-rs"), want: false},
+		{name: "annotated statefulset", kind: "statefulset", owner: owner("StatefulSet", "sts"), want: true},
+		{name: "pod of another statefulset", kind: "statefulsets", owner: owner("StatefulSet", "other"), want: false},
+		{name: "non-annotated daemonset", kind: "daemonset", owner: owner("DaemonSet", "ds"), want: false},
+		{name: "annotated deploymentconfig", kind: "deploymentconfig", owner: owner("ReplicationController", "dc-rc"), want: true},
+		{name: "non-annotated rollout", kind: "rollout", owner: owner("ReplicaSet", "ro-rs"), want: false},
+		{name: "unsupported app kind", kind: "job", owner: owner("Job", "job"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := clients.NewFakeClientSets(kubeObjects, nil, dynamicObjects)
+			chaosDetails := &types.ChaosDetails{
+				AppDetail: types.AppDetails{
+					Namespace:       testNamespace,
+					Label:           "app=nginx",
+					Kind:            tt.kind,
+					AnnotationKey:   annotationKey,
+					AnnotationValue: "true",
+				},
+			}
+			pod := corev1.Pod{ObjectMeta: objectMeta("pod", nil, tt.owner)}
+
+			got, err := IsPodParentAnnotated(clients, pod, chaosDetails)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, found %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %v, found %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsPodParentAnnotatedWithoutParent(t *testing.T) {
+	clients := clients.NewFakeClientSets(nil, nil, nil)
+	chaosDetails := &types.ChaosDetails{
+		AppDetail: types.AppDetails{Namespace: testNamespace, Label: "app=nginx", Kind: "deployment"},
+	}
+	pod := corev1.Pod{ObjectMeta: objectMeta("pod", nil)}
+
+	if _, err := IsPodParentAnnotated(clients, pod, chaosDetails); err == nil {
+		t.Fatalf("expected an error, if no deployment matches the app label")
+	}
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"math/rand"
 	"os"
 	"os/signal"
@@ -9,11 +10,7 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/litmuschaos/litmus-go/pkg/clients"
-	"github.com/litmuschaos/litmus-go/pkg/events"
 	"github.com/litmuschaos/litmus-go/pkg/log"
-	"github.com/litmuschaos/litmus-go/pkg/result"
-	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/pkg/errors"
 )
 
@@ -23,7 +20,8 @@ func WaitForDuration(duration int) {
 }
 
 // RandomInterval wait for the random interval lies between lower & upper bounds
-func RandomInterval(interval string) error {
+// it returns ErrChaosStopped, if the abort signal is received in between
+func RandomInterval(ctx context.Context, interval string) error {
 	intervals := strings.Split(interval, "-")
 	var lowerBound, upperBound int
 	switch len(intervals) {
@@ -39,8 +37,7 @@ func RandomInterval(interval string) error {
 	rand.Seed(time.Now().UnixNano())
 	waitTime := lowerBound + rand.Intn(upperBound-lowerBound)
 	log.Infof("[Wait]: Wait for the random chaos interval %vs", waitTime)
-	WaitForDuration(waitTime)
-	return nil
+	return WaitForDurationWithContext(ctx, waitTime)
 }
 
 // GetRunID generate a random string
@@ -54,40 +51,51 @@ func GetRunID() string {
 	return string(runID)
 }
 
-// AbortWatcher continuosly watch for the abort signals
-// it will update chaosresult w/ failed step and create an abort event, if it recieved abort signal during chaos
-func AbortWatcher(expname string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
-	AbortWatcherWithoutExit(expname, clients, resultDetails, chaosDetails, eventsDetails)
-	os.Exit(1)
-}
+// ErrChaosStopped is returned by the chaoslib, once the chaos is stopped (and reverted) after receiving the abort signal
+var ErrChaosStopped = errors.New("chaos injection stopped")
+
+// AbortContext returns a copy of the parent context, which is cancelled once the abort signal is received
+// the chaoslib watch for the context to stop & revert the chaos, instead of exiting the process
+func AbortContext(parent context.Context) (context.Context, context.CancelFunc) {
 
-// AbortWatcherWithoutExit continuosly watch for the abort signals
-func AbortWatcherWithoutExit(expname string, clients clients.ClientSets, resultDetails *types.ResultDetails, chaosDetails *types.ChaosDetails, eventsDetails *types.EventDetails) {
+	ctx, cancel := context.WithCancel(parent)
 
 	// signChan channel is used to transmit signal notifications.
 	signChan := make(chan os.Signal, 1)
 	// Catch and relay certain signal(s) to signChan channel.
 	signal.Notify(signChan, os.Interrupt, syscall.SIGTERM)
 
-loop:
-	for {
+	go func() {
+		defer signal.Stop(signChan)
 		select {
 		case <-signChan:
 			log.Info("[Chaos]: Chaos Experiment Abortion started because of terminated signal received")
-			// updating the chaosresult after stopped
-			failStep := "Chaos injection stopped!"
-			types.SetResultAfterCompletion(resultDetails, "Stopped", "Stopped", failStep)
-			result.ChaosResult(chaosDetails, clients, resultDetails, "EOT")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
 
-			// generating summary event in chaosengine
-			msg := expname + " experiment has been aborted"
-			types.SetEngineEventAttributes(eventsDetails, types.Summary, msg, "Warning", chaosDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosEngine")
+// CheckAbort returns ErrChaosStopped, if the abort signal is already received
+func CheckAbort(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ErrChaosStopped
+	default:
+		return nil
+	}
+}
 
-			// generating summary event in chaosresult
-			types.SetResultEventAttributes(eventsDetails, types.Summary, msg, "Warning", resultDetails)
-			events.GenerateEvents(eventsDetails, clients, chaosDetails, "ChaosResult")
-			break loop
-		}
+// WaitForDurationWithContext waits for the given time duration (in seconds)
+// it returns ErrChaosStopped, if the abort signal is received in between
+func WaitForDurationWithContext(ctx context.Context, duration int) error {
+	timer := time.NewTimer(time.Duration(duration) * time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ErrChaosStopped
 	}
 }
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaitForDurationWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := WaitForDurationWithContext(ctx, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the wait is stopped, as soon as the context is cancelled
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	if err := WaitForDurationWithContext(ctx, 60); err != ErrChaosStopped {
+		t.Fatalf("expected the chaos stopped error, found %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the wait to stop on the cancellation, took %v", elapsed)
+	}
+	if err := CheckAbort(ctx); err != ErrChaosStopped {
+		t.Fatalf("expected the chaos stopped error, found %v", err)
+	}
+}
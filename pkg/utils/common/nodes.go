@@ -6,20 +6,29 @@ import (
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
+	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 //GetNodeList check for the availibilty of the application node for the chaos execution
 // if the application node is not defined it will derive the random target node list using node affected percentage
-func GetNodeList(nodeName string, nodeAffPerc int, clients clients.ClientSets) ([]string, error) {
+func GetNodeList(nodeName string, nodeAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) ([]string, error) {
 
 	var nodeList []string
 
 	if nodeName != "" {
 		nodeList = append(nodeList, nodeName)
+		// verify the target node against the blast radius guardrails, before injecting the chaos
+		if err := guardrails.CheckNodes(clients, chaosDetails.Guardrails, nodeList); err != nil {
+			return nil, err
+		}
+		metrics.SetTargetedNodes(len(nodeList))
+		SetTargets(chaosDetails, types.TargetedStatus, Targets("node", nodeList...)...)
 		return nodeList, nil
 	}
 	nodes, err := clients.KubeClient.CoreV1().Nodes().List(v1.ListOptions{})
@@ -27,18 +36,30 @@ func GetNodeList(nodeName string, nodeAffPerc int, clients clients.ClientSets) (
 		return nil, errors.Errorf("Failed to find the nodes, err: %v", err)
 	}
 
-	newNodeListLength := math.Maximum(1, math.Adjustment(nodeAffPerc, len(nodes.Items)))
+	// the nodes with the protected labels are filtered out, before selecting the target nodes
+	candidates, err := guardrails.UnprotectedNodes(chaosDetails.Guardrails, nodes.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	newNodeListLength := math.Minimum(len(candidates), math.Maximum(1, math.Adjustment(nodeAffPerc, len(nodes.Items))))
 
 	// it will generate the random nodelist
 	// it starts from the random index and choose requirement no of pods next to that index in a circular way.
 	rand.Seed(time.Now().UnixNano())
-	index := rand.Intn(len(nodes.Items))
+	index := rand.Intn(len(candidates))
 	for i := 0; i < newNodeListLength; i++ {
-		nodeList = append(nodeList, nodes.Items[index].Name)
-		index = (index + 1) % len(nodes.Items)
+		nodeList = append(nodeList, candidates[index].Name)
+		index = (index + 1) % len(candidates)
 	}
 
+	// verify the number of the selected nodes against the blast radius guardrails, before injecting the chaos
+	if err := guardrails.CheckNodeLimits(chaosDetails.Guardrails, len(nodes.Items), nodeList); err != nil {
+		return nil, err
+	}
 	log.Infof("[Chaos]:Number of nodes targeted: %v", strconv.Itoa(newNodeListLength))
+	metrics.SetTargetedNodes(newNodeListLength)
+	SetTargets(chaosDetails, types.TargetedStatus, Targets("node", nodeList...)...)
 
 	return nodeList, nil
 }
@@ -53,6 +74,7 @@ func GetNodeName(namespace, labels string, clients clients.ClientSets) (string,
 	rand.Seed(time.Now().Unix())
 	randomIndex := rand.Intn(len(podList.Items))
 	nodeName := podList.Items[randomIndex].Spec.NodeName
+	metrics.SetTargetedNodes(1)
 
 	return nodeName, nil
 }
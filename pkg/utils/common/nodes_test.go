@@ -0,0 +1,49 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetNodeList(t *testing.T) {
+	fakeClients := clients.NewFakeClientSets([]runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "master", Labels: map[string]string{"node-role.kubernetes.io/master": ""}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+	}, nil, nil)
+	policy := types.Guardrails{ProtectedLabels: "node-role.kubernetes.io/master"}
+
+	// the protected nodes are never selected, irrespective of the random index
+	for i := 0; i < 20; i++ {
+		nodes, err := GetNodeList("", 100, fakeClients, &types.ChaosDetails{Guardrails: policy})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(nodes) != 2 {
+			t.Fatalf("expected the 2 unprotected nodes, got %v", nodes)
+		}
+		for _, node := range nodes {
+			if node == "master" {
+				t.Fatalf("the protected node is selected: %v", nodes)
+			}
+		}
+	}
+
+	// the explicit target node is still verified against the protected labels
+	if _, err := GetNodeList("master", 0, fakeClients, &types.ChaosDetails{Guardrails: policy}); err == nil {
+		t.Error("expected the violation for the protected target node")
+	}
+
+	// the max targets percentage is verified against all the nodes
+	policy.MaxTargetsPercentage = 50
+	_, err := GetNodeList("", 100, fakeClients, &types.ChaosDetails{Guardrails: policy})
+	if violation, ok := guardrails.AsViolation(err); !ok || violation.Policy != guardrails.MaxTargetsPercentage {
+		t.Errorf("expected the %v violation, got %v", guardrails.MaxTargetsPercentage, err)
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/guardrails"
 	"github.com/litmuschaos/litmus-go/pkg/log"
 	"github.com/litmuschaos/litmus-go/pkg/math"
+	"github.com/litmuschaos/litmus-go/pkg/metrics"
 	"github.com/litmuschaos/litmus-go/pkg/types"
 	"github.com/litmuschaos/litmus-go/pkg/utils/annotation"
 	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
@@ -127,8 +129,10 @@ func VerifyExistanceOfPods(namespace, pods string, clients clients.ClientSets) (
 func GetPodList(targetPods string, podAffPerc int, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
 	realpods := core_v1.PodList{}
 	podList, err := clients.KubeClient.CoreV1().Pods(chaosDetails.AppDetail.Namespace).List(v1.ListOptions{LabelSelector: chaosDetails.AppDetail.Label})
-	if err != nil || len(podList.Items) == 0 {
+	if err != nil {
 		return core_v1.PodList{}, errors.Wrapf(err, "Failed to find the pod with matching labels in %v namespace", chaosDetails.AppDetail.Namespace)
+	} else if len(podList.Items) == 0 {
+		return core_v1.PodList{}, errors.Errorf("Failed to find the pod with matching labels in %v namespace", chaosDetails.AppDetail.Namespace)
 	}
 
 	isPodsAvailable, err := VerifyExistanceOfPods(chaosDetails.AppDetail.Namespace, targetPods, clients)
@@ -152,7 +156,14 @@ func GetPodList(targetPods string, podAffPerc int, clients clients.ClientSets, c
 			return core_v1.PodList{}, err
 		}
 	}
+
+	// verify the selected pods against the blast radius guardrails, before injecting the chaos
+	if err := guardrails.CheckPods(clients, chaosDetails.Guardrails, len(podList.Items), realpods.Items); err != nil {
+		return core_v1.PodList{}, err
+	}
 	log.Infof("[Chaos]:Number of pods targeted: %v", strconv.Itoa(len(realpods.Items)))
+	metrics.SetTargetedPods(len(realpods.Items))
+	SetTargets(chaosDetails, types.TargetedStatus, PodTargets(realpods.Items...)...)
 
 	return realpods, nil
 }
@@ -192,8 +203,10 @@ func FilterNonChaosPods(podList core_v1.PodList, chaosDetails *types.ChaosDetail
 // GetTargetPodsWhenTargetPodsENVSet derive the specific target pods, if TARGET_PODS env is set
 func GetTargetPodsWhenTargetPodsENVSet(targetPods string, clients clients.ClientSets, chaosDetails *types.ChaosDetails) (core_v1.PodList, error) {
 	podList, err := clients.KubeClient.CoreV1().Pods(chaosDetails.AppDetail.Namespace).List(v1.ListOptions{LabelSelector: chaosDetails.AppDetail.Label})
-	if err != nil || len(podList.Items) == 0 {
+	if err != nil {
 		return core_v1.PodList{}, errors.Wrapf(err, "Failed to find the pods with matching labels in %v namespace", chaosDetails.AppDetail.Namespace)
+	} else if len(podList.Items) == 0 {
+		return core_v1.PodList{}, errors.Errorf("Failed to find the pods with matching labels in %v namespace", chaosDetails.AppDetail.Namespace)
 	}
 
 	targetPodsList := strings.Split(targetPods, ",")
@@ -278,3 +291,18 @@ func DeleteAllHelperPodBasedOnJobCleanupPolicy(podLabel string, chaosDetails *ty
 		}
 	}
 }
+
+// StopHelperPods deletes all the helper pods w/ matching label, irrespective of the jobCleanupPolicy
+// the helper pods revert the chaos on termination, so it is used to stop the chaos once the abort signal is received
+// the given targets are marked as reverted once the helper pods are deleted, otherwise as failed
+func StopHelperPods(podLabel string, chaosDetails *types.ChaosDetails, clients clients.ClientSets, targets ...types.TargetDetails) error {
+
+	log.Info("[Abort]: Deleting all the helper pods to revert the chaos")
+	if err := DeleteAllPod(podLabel, chaosDetails.ChaosNamespace, chaosDetails.Timeout, chaosDetails.Delay, clients); err != nil {
+		log.Errorf("Unable to delete the helper pods, err: %v", err)
+		SetTargets(chaosDetails, types.FailedStatus, targets...)
+		return ErrChaosStopped
+	}
+	SetTargets(chaosDetails, types.RevertedStatus, targets...)
+	return ErrChaosStopped
+}
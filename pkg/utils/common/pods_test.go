@@ -0,0 +1,160 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testNamespace = "app-ns"
+
+func newPod(name string, labels map[string]string, owners ...metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       testNamespace,
+			Labels:          labels,
+			OwnerReferences: owners,
+		},
+	}
+}
+
+func newChaosDetails(label string) *types.ChaosDetails {
+	return &types.ChaosDetails{
+		ChaosUID: "chaos-uid",
+		AppDetail: types.AppDetails{
+			Namespace:       testNamespace,
+			Label:           label,
+			Kind:            "deployment",
+			AnnotationKey:   "litmuschaos.io/chaos",
+			AnnotationValue: "true",
+		},
+	}
+}
+
+func podNames(podList corev1.PodList) map[string]bool {
+	names := map[string]bool{}
+	for _, pod := range podList.Items {
+		names[pod.Name] = true
+	}
+	return names
+}
+
+func TestGetPodList(t *testing.T) {
+	appLabels := map[string]string{"app": "nginx"}
+	objects := []runtime.Object{
+		newPod("nginx-1", appLabels),
+		newPod("nginx-2", appLabels),
+		newPod("nginx-3", appLabels),
+		newPod("nginx-4", appLabels),
+		newPod("other", map[string]string{"app": "other"}),
+	}
+
+	tests := []struct {
+		name       string
+		targetPods string
+		podAffPerc int
+		label      string
+		wantCount  int
+		wantPods   []string
+		wantErr    bool
+	}{
+		{name: "minimum one pod is targeted", podAffPerc: 0, label: "app=nginx", wantCount: 1},
+		{name: "pods affected percentage", podAffPerc: 50, label: "app=nginx", wantCount: 2},
+		{name: "all the pods are targeted", podAffPerc: 100, label: "app=nginx", wantCount: 4},
+		{name: "target pods", targetPods: "nginx-2,nginx-4", label: "app=nginx", wantCount: 2, wantPods: []string{"nginx-2", "nginx-4"}},
+		{name: "target pods outside app label are skipped", targetPods: "other", label: "app=nginx", wantCount: 0},
+		{name: "unknown target pods fallback to random pods", targetPods: "unknown", podAffPerc: 100, label: "app=nginx", wantCount: 4},
+		{name: "no pods with matching labels", label: "app=unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := clients.NewFakeClientSets(objects, nil, nil)
+			podList, err := GetPodList(tt.targetPods, tt.podAffPerc, clients, newChaosDetails(tt.label))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, found %v pods", len(podList.Items))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			names := podNames(podList)
+			if len(podList.Items) != tt.wantCount || len(names) != tt.wantCount {
+				t.Fatalf("expected %v unique pods, found %v", tt.wantCount, podList.Items)
+			}
+			for _, name := range tt.wantPods {
+				if !names[name] {
+					t.Errorf("expected %v pod to be targeted, found %v", name, names)
+				}
+			}
+			for name := range names {
+				if name == "other" {
+					t.Errorf("pod without the app label is targeted")
+				}
+			}
+		})
+	}
+}
+
+func TestGetPodListFiltersChaosPods(t *testing.T) {
+	objects := []runtime.Object{
+		newPod("app", map[string]string{"app": "nginx"}),
+		newPod("runner", map[string]string{"chaosUID": "chaos-uid"}),
+		newPod("operator", map[string]string{"name": "chaos-operator"}),
+	}
+
+	// the chaos pods are filtered, if the app label is not defined
+	clients := clients.NewFakeClientSets(objects, nil, nil)
+	podList, err := GetPodList("", 100, clients, newChaosDetails(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := podNames(podList); len(names) != 1 || !names["app"] {
+		t.Fatalf("expected only the app pod to be targeted, found %v", names)
+	}
+}
+
+func TestGetPodListWithAnnotationCheck(t *testing.T) {
+	appLabels := map[string]string{"app": "nginx"}
+	newDeployment := func(name string, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: appLabels, Annotations: annotations}}
+	}
+	newReplicaSet := func(name, deployment string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: deployment}}}}
+	}
+
+	objects := []runtime.Object{
+		newDeployment("annotated", map[string]string{"litmuschaos.io/chaos": "true"}),
+		newDeployment("plain", nil),
+		newReplicaSet("annotated-rs", "annotated"),
+		newReplicaSet("plain-rs", "plain"),
+		newPod("annotated-pod", appLabels, metav1.OwnerReference{Kind: "ReplicaSet", Name: "annotated-rs"}),
+		newPod("plain-pod", appLabels, metav1.OwnerReference{Kind: "ReplicaSet", Name: "plain-rs"}),
+	}
+	clients := clients.NewFakeClientSets(objects, nil, nil)
+
+	chaosDetails := newChaosDetails("app=nginx")
+	chaosDetails.AppDetail.AnnotationCheck = true
+
+	podList, err := GetPodList("", 100, clients, chaosDetails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := podNames(podList); len(names) != 1 || !names["annotated-pod"] {
+		t.Fatalf("expected only the annotated pod to be targeted, found %v", names)
+	}
+
+	// the target pods should be annotated, if the annotation check is enabled
+	if _, err := GetPodList("plain-pod", 100, clients, chaosDetails); err == nil {
+		t.Fatalf("expected an error for the non-annotated target pod")
+	}
+}
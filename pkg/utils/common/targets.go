@@ -0,0 +1,108 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	core_v1 "k8s.io/api/core/v1"
+)
+
+// targetsMu guards the targets of the chaos details, as they are read by the probes during the chaos
+var targetsMu sync.Mutex
+
+// SetTargets records the given targets with the given chaos status, the already recorded targets are updated
+// the chaos start time is set once the chaos is injected and the end time once it is reverted or failed
+// the targets, which are selected again (e.g. in the next iteration), keep their chaos status until the chaos is injected again
+func SetTargets(chaosDetails *types.ChaosDetails, status string, targets ...types.TargetDetails) {
+	targetsMu.Lock()
+	defer targetsMu.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, target := range targets {
+		index := targetIndex(chaosDetails.Targets, target)
+		if index == -1 {
+			chaosDetails.Targets = append(chaosDetails.Targets, target)
+			index = len(chaosDetails.Targets) - 1
+		} else if status == types.TargetedStatus {
+			continue
+		}
+		t := &chaosDetails.Targets[index]
+		t.ChaosStatus = status
+		switch status {
+		case types.InjectedStatus:
+			if t.ChaosStartTime == "" {
+				t.ChaosStartTime = now
+			}
+		case types.RevertedStatus, types.FailedStatus:
+			t.ChaosEndTime = now
+		}
+	}
+}
+
+// CompleteTargets marks the injected targets, which are not reverted or failed yet, with the given chaos status
+// it is called once the chaos injection completes, the end of the chaos window is used for the missing end time
+// the targets, which are never injected, keep the targeted status
+func CompleteTargets(chaosDetails *types.ChaosDetails, status string) {
+	targetsMu.Lock()
+	defer targetsMu.Unlock()
+
+	for index := range chaosDetails.Targets {
+		t := &chaosDetails.Targets[index]
+		if t.ChaosStatus != types.InjectedStatus {
+			continue
+		}
+		t.ChaosStatus = status
+		if t.ChaosEndTime == "" {
+			t.ChaosEndTime = chaosDetails.ChaosWindow.EndTime
+		}
+	}
+}
+
+// GetTargets returns a copy of the recorded targets
+func GetTargets(chaosDetails *types.ChaosDetails) []types.TargetDetails {
+	targetsMu.Lock()
+	defer targetsMu.Unlock()
+	return append([]types.TargetDetails{}, chaosDetails.Targets...)
+}
+
+// SetParameters records the parameters of the injected chaos, e.g. the netem arguments
+func SetParameters(chaosDetails *types.ChaosDetails, parameters map[string]string) {
+	targetsMu.Lock()
+	defer targetsMu.Unlock()
+
+	if chaosDetails.Parameters == nil {
+		chaosDetails.Parameters = map[string]string{}
+	}
+	for key, value := range parameters {
+		chaosDetails.Parameters[key] = value
+	}
+}
+
+// PodTargets returns the target details of the given pods
+func PodTargets(pods ...core_v1.Pod) []types.TargetDetails {
+	var targets []types.TargetDetails
+	for _, pod := range pods {
+		targets = append(targets, types.TargetDetails{Kind: "pod", Name: pod.Name, Namespace: pod.Namespace, Node: pod.Spec.NodeName})
+	}
+	return targets
+}
+
+// Targets returns the target details of the given kind, for the non-namespaced targets like nodes & ec2 instances
+func Targets(kind string, names ...string) []types.TargetDetails {
+	var targets []types.TargetDetails
+	for _, name := range names {
+		targets = append(targets, types.TargetDetails{Kind: kind, Name: name})
+	}
+	return targets
+}
+
+// targetIndex returns the index of the given target in the list, -1 if it is not present
+func targetIndex(list []types.TargetDetails, target types.TargetDetails) int {
+	for index, t := range list {
+		if t.Kind == target.Kind && t.Namespace == target.Namespace && t.Name == target.Name {
+			return index
+		}
+	}
+	return -1
+}
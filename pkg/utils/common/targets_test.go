@@ -0,0 +1,53 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetTargets(t *testing.T) {
+	chaosDetails := &types.ChaosDetails{ChaosWindow: types.ChaosWindow{StartTime: "2021-01-01T10:00:00Z", EndTime: "2021-01-01T10:01:00Z"}}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "frontend-0", Namespace: "shop"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "frontend-1", Namespace: "shop"}, Spec: corev1.PodSpec{NodeName: "node-2"}},
+	}
+
+	SetTargets(chaosDetails, types.TargetedStatus, PodTargets(pods...)...)
+	SetTargets(chaosDetails, types.InjectedStatus, PodTargets(pods[0])...)
+	SetTargets(chaosDetails, types.RevertedStatus, PodTargets(pods[0])...)
+	if len(chaosDetails.Targets) != 2 {
+		t.Fatalf("expected the targets to be updated in place, found %+v", chaosDetails.Targets)
+	}
+	first := chaosDetails.Targets[0]
+	if first.ChaosStatus != types.RevertedStatus || first.ChaosStartTime == "" || first.ChaosEndTime == "" || first.Node != "node-1" {
+		t.Fatalf("unexpected target %+v", first)
+	}
+
+	// the target, which is selected again, keeps its chaos status
+	SetTargets(chaosDetails, types.TargetedStatus, PodTargets(pods[0])...)
+	if chaosDetails.Targets[0] != first {
+		t.Fatalf("expected the reverted target not to be modified, found %+v", chaosDetails.Targets[0])
+	}
+
+	// the target, which is never injected, keeps the targeted status
+	CompleteTargets(chaosDetails, types.FailedStatus)
+	second := chaosDetails.Targets[1]
+	if second.ChaosStatus != types.TargetedStatus || second.ChaosStartTime != "" || second.ChaosEndTime != "" {
+		t.Fatalf("expected the uninjected target not to be modified, found %+v", second)
+	}
+
+	// the injected target is marked with the given status, the chaos window is used for the missing end time
+	SetTargets(chaosDetails, types.InjectedStatus, PodTargets(pods[1])...)
+	CompleteTargets(chaosDetails, types.FailedStatus)
+	second = chaosDetails.Targets[1]
+	if second.ChaosStatus != types.FailedStatus || second.ChaosStartTime == "" || second.ChaosEndTime != "2021-01-01T10:01:00Z" {
+		t.Fatalf("expected the chaos window to be used for the target, found %+v", second)
+	}
+	// the already reverted targets keep their status & times
+	if chaosDetails.Targets[0] != first {
+		t.Fatalf("expected the reverted target not to be modified, found %+v", chaosDetails.Targets[0])
+	}
+}
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// the experiment details are populated from the env variables, with the help of the struct tags
+// env      - name of the env variable, a field without env tag is skipped
+//            env:",inline" loads the nested struct (or pointer to struct) field
+// default  - default value, used when the env is not set or empty
+// required - the env should be set to a non-empty value, if required:"true"
+// min, max - lower and upper bound of an integer or float value
+// enum     - allowed values, separated by "|", e.g. enum:"serial|parallel"
+// the string, bool, int and float fields (including the named types, like types.UID) are supported
+
+// Defaults overrides the default values of the struct tags, keyed by the env name
+// it is used by the experiments, which shares the experiment details with other experiments
+type Defaults map[string]string
+
+// Tunable contains the details of an env variable, which can be tuned for the experiment
+type Tunable struct {
+	Name     string
+	Type     string
+	Default  string
+	Required bool
+	Min      string
+	Max      string
+	Enum     []string
+}
+
+// Errors contains all the validation errors of the env variables
+type Errors []error
+
+// Error returns all the validation errors, separated by "; "
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Load populates the tagged fields of the given struct pointer from the env variables
+// all the valid fields are populated even if some of them are invalid, so that the experiment can still update the chaosresult
+// it returns the validation errors of all the invalid env variables together
+func Load(obj interface{}, defaults Defaults) error {
+	var errs Errors
+	err := walk(obj, defaults, func(field reflect.Value, tunable Tunable) {
+		value := os.Getenv(tunable.Name)
+		if value == "" {
+			value = tunable.Default
+		}
+		if err := set(field, tunable, value); err != nil {
+			errs = append(errs, err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// Describe returns the tunables of the given struct pointer, in the order of the fields
+func Describe(obj interface{}, defaults Defaults) ([]Tunable, error) {
+	var tunables []Tunable
+	err := walk(obj, defaults, func(field reflect.Value, tunable Tunable) {
+		tunables = append(tunables, tunable)
+	})
+	return tunables, err
+}
+
+// WriteMarkdown writes the tunables of an experiment as a markdown table
+func WriteMarkdown(w io.Writer, experimentName string, tunables []Tunable) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %v\n\n", experimentName)
+	b.WriteString("| Name | Type | Default | Required | Constraints |\n")
+	b.WriteString("| ---- | ---- | ------- | -------- | ----------- |\n")
+	for _, t := range tunables {
+		required := ""
+		if t.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(&b, "| %v | %v | %v | %v | %v |\n", t.Name, t.Type, escape(t.Default), required, escape(constraints(t)))
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// walk calls fn for all the tagged fields of the given struct pointer
+// it returns an error, if the obj is not a struct pointer or the struct tags are invalid
+func walk(obj interface{}, defaults Defaults, fn func(field reflect.Value, tunable Tunable)) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("expected a non-nil struct pointer, found %T", obj)
+	}
+	return walkStruct(v.Elem(), defaults, fn)
+}
+
+func walkStruct(v reflect.Value, defaults Defaults, fn func(field reflect.Value, tunable Tunable)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, ok := sf.Tag.Lookup("env")
+		if !ok || name == "-" {
+			continue
+		}
+		field := v.Field(i)
+
+		if name == ",inline" {
+			if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+				field = field.Elem()
+			}
+			if field.Kind() != reflect.Struct {
+				return errors.Errorf("inline field %v should be a struct or a struct pointer", sf.Name)
+			}
+			if err := walkStruct(field, defaults, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tunable, err := parseTags(sf, defaults)
+		if err != nil {
+			return err
+		}
+		fn(field, tunable)
+	}
+	return nil
+}
+
+// parseTags derives the tunable from the struct tags of the given field
+func parseTags(sf reflect.StructField, defaults Defaults) (Tunable, error) {
+	tunable := Tunable{
+		Name:    sf.Tag.Get("env"),
+		Default: sf.Tag.Get("default"),
+		Min:     sf.Tag.Get("min"),
+		Max:     sf.Tag.Get("max"),
+	}
+	if value, ok := defaults[tunable.Name]; ok {
+		tunable.Default = value
+	}
+	if enum := sf.Tag.Get("enum"); enum != "" {
+		tunable.Enum = strings.Split(enum, "|")
+	}
+	if required := sf.Tag.Get("required"); required != "" {
+		var err error
+		if tunable.Required, err = strconv.ParseBool(required); err != nil {
+			return tunable, errors.Errorf("invalid required tag of %v field, err: %v", sf.Name, err)
+		}
+	}
+
+	switch sf.Type.Kind() {
+	case reflect.String:
+		tunable.Type = "string"
+	case reflect.Bool:
+		tunable.Type = "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		tunable.Type = "int"
+	case reflect.Float32, reflect.Float64:
+		tunable.Type = "float"
+	default:
+		return tunable, errors.Errorf("unsupported type %v of %v field", sf.Type, sf.Name)
+	}
+	if (tunable.Min != "" || tunable.Max != "") && tunable.Type != "int" && tunable.Type != "float" {
+		return tunable, errors.Errorf("min/max tags are not supported for the %v type of %v field", tunable.Type, sf.Name)
+	}
+	return tunable, nil
+}
+
+// set parses and validates the value of the env and sets it to the given field
+// the field is left to its zero value, if the value is invalid
+func set(field reflect.Value, tunable Tunable, value string) error {
+	if value == "" {
+		if tunable.Required {
+			return errors.Errorf("%v is required", tunable.Name)
+		}
+		return nil
+	}
+
+	if len(tunable.Enum) != 0 && !contains(tunable.Enum, value) {
+		return errors.Errorf("%v should be one of %v, found %q", tunable.Name, strings.Join(tunable.Enum, "|"), value)
+	}
+
+	switch tunable.Type {
+	case "string":
+		field.SetString(value)
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.Errorf("%v should be a boolean, found %q", tunable.Name, value)
+		}
+		field.SetBool(b)
+	case "int":
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return errors.Errorf("%v should be an integer, found %q", tunable.Name, value)
+		}
+		if err := checkBounds(tunable, float64(n), value); err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case "float":
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return errors.Errorf("%v should be a number, found %q", tunable.Name, value)
+		}
+		if err := checkBounds(tunable, f, value); err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	}
+	return nil
+}
+
+// checkBounds verifies that the value lies between the min and max tags of the tunable
+func checkBounds(tunable Tunable, n float64, value string) error {
+	if tunable.Min != "" {
+		min, err := strconv.ParseFloat(tunable.Min, 64)
+		if err != nil {
+			return errors.Errorf("invalid min tag of %v, err: %v", tunable.Name, err)
+		}
+		if n < min {
+			return errors.Errorf("%v should be greater than or equal to %v, found %v", tunable.Name, tunable.Min, value)
+		}
+	}
+	if tunable.Max != "" {
+		max, err := strconv.ParseFloat(tunable.Max, 64)
+		if err != nil {
+			return errors.Errorf("invalid max tag of %v, err: %v", tunable.Name, err)
+		}
+		if n > max {
+			return errors.Errorf("%v should be less than or equal to %v, found %v", tunable.Name, tunable.Max, value)
+		}
+	}
+	return nil
+}
+
+// constraints returns the min, max and enum constraints of the tunable in human readable form
+func constraints(t Tunable) string {
+	var c []string
+	if t.Min != "" {
+		c = append(c, "min: "+t.Min)
+	}
+	if t.Max != "" {
+		c = append(c, "max: "+t.Max)
+	}
+	if len(t.Enum) != 0 {
+		c = append(c, "one of: "+strings.Join(t.Enum, ", "))
+	}
+	return strings.Join(c, ", ")
+}
+
+// escape makes the value safe to be used inside a markdown table cell
+func escape(value string) string {
+	value = strings.Replace(value, "|", "\\|", -1)
+	if strings.ContainsAny(value, "`*_$") {
+		return "`` " + value + " ``"
+	}
+	return value
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type sample struct {
+	ExperimentName  string  `env:"TEST_EXPERIMENT_NAME" default:"pod-delete"`
+	ChaosDuration   int     `env:"TEST_TOTAL_CHAOS_DURATION" default:"30" min:"1"`
+	PodsAffected    int     `env:"TEST_PODS_AFFECTED_PERC" default:"0" min:"0" max:"100"`
+	Ratio           float64 `env:"TEST_RATIO" default:"0.5" min:"0" max:"1"`
+	Sequence        string  `env:"TEST_SEQUENCE" default:"parallel" enum:"serial|parallel"`
+	AppNS           string  `env:"TEST_APP_NAMESPACE" required:"true"`
+	AnnotationCheck bool    `env:"TEST_ANNOTATION_CHECK" default:"false"`
+	Nested          *nested `env:",inline"`
+	Skipped         string
+}
+
+type nested struct {
+	Randomness bool `env:"TEST_RANDOMNESS" default:"false"`
+}
+
+// setEnv sets the given env variables and returns a func, which unsets them
+func setEnv(env map[string]string) func() {
+	for name, value := range env {
+		os.Setenv(name, value)
+	}
+	return func() {
+		for name := range env {
+			os.Unsetenv(name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	defer setEnv(map[string]string{
+		"TEST_APP_NAMESPACE":    "shop",
+		"TEST_SEQUENCE":         "serial",
+		"TEST_ANNOTATION_CHECK": "true",
+		"TEST_RANDOMNESS":       "true",
+	})()
+
+	details := sample{}
+	if err := Load(&details, Defaults{"TEST_TOTAL_CHAOS_DURATION": "60"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if details.ExperimentName != "pod-delete" || details.ChaosDuration != 60 || details.Ratio != 0.5 {
+		t.Errorf("expected the default values, got %v, %v & %v", details.ExperimentName, details.ChaosDuration, details.Ratio)
+	}
+	if details.AppNS != "shop" || details.Sequence != "serial" || !details.AnnotationCheck {
+		t.Errorf("expected the env values, got %v, %v & %v", details.AppNS, details.Sequence, details.AnnotationCheck)
+	}
+	if details.Nested == nil || !details.Nested.Randomness {
+		t.Errorf("expected the inline field to be populated, got %v", details.Nested)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		err  string
+	}{
+		{
+			name: "bad int",
+			env:  map[string]string{"TEST_TOTAL_CHAOS_DURATION": "60s"},
+			err:  `TEST_TOTAL_CHAOS_DURATION should be an integer, found "60s"`,
+		},
+		{
+			name: "bad bool",
+			env:  map[string]string{"TEST_ANNOTATION_CHECK": "ture"},
+			err:  `TEST_ANNOTATION_CHECK should be a boolean, found "ture"`,
+		},
+		{
+			name: "below min",
+			env:  map[string]string{"TEST_TOTAL_CHAOS_DURATION": "0"},
+			err:  "TEST_TOTAL_CHAOS_DURATION should be greater than or equal to 1, found 0",
+		},
+		{
+			name: "above max",
+			env:  map[string]string{"TEST_PODS_AFFECTED_PERC": "101"},
+			err:  "TEST_PODS_AFFECTED_PERC should be less than or equal to 100, found 101",
+		},
+		{
+			name: "float above max",
+			env:  map[string]string{"TEST_RATIO": "1.5"},
+			err:  "TEST_RATIO should be less than or equal to 1, found 1.5",
+		},
+		{
+			name: "enum",
+			env:  map[string]string{"TEST_SEQUENCE": "random"},
+			err:  `TEST_SEQUENCE should be one of serial|parallel, found "random"`,
+		},
+		{
+			name: "required",
+			env:  map[string]string{"TEST_APP_NAMESPACE": ""},
+			err:  "TEST_APP_NAMESPACE is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"TEST_APP_NAMESPACE": "shop"}
+			for name, value := range tt.env {
+				env[name] = value
+			}
+			defer setEnv(env)()
+
+			err := Load(&sample{}, nil)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("expected %q error, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestLoadAggregatesErrors(t *testing.T) {
+	defer setEnv(map[string]string{
+		"TEST_TOTAL_CHAOS_DURATION": "60s",
+		"TEST_SEQUENCE":             "random",
+		"TEST_RANDOMNESS":           "yes",
+	})()
+
+	details := sample{}
+	err := Load(&details, nil)
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected the validation errors, got %v", err)
+	}
+	if len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %v: %v", len(errs), err)
+	}
+	for _, name := range []string{"TEST_TOTAL_CHAOS_DURATION", "TEST_SEQUENCE", "TEST_APP_NAMESPACE", "TEST_RANDOMNESS"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected the error of %v, got %v", name, err)
+		}
+	}
+	// the valid fields are still populated
+	if details.ExperimentName != "pod-delete" || details.PodsAffected != 0 || details.Ratio != 0.5 {
+		t.Errorf("expected the valid fields to be populated, got %+v", details)
+	}
+}
+
+func TestLoadInvalidTags(t *testing.T) {
+	if err := Load(sample{}, nil); err == nil {
+		t.Error("expected error for the non-pointer struct")
+	}
+	invalid := struct {
+		Labels map[string]string `env:"TEST_LABELS"`
+	}{}
+	if err := Load(&invalid, nil); err == nil {
+		t.Error("expected error for the unsupported field type")
+	}
+	bounds := struct {
+		Name string `env:"TEST_NAME" min:"1"`
+	}{}
+	if err := Load(&bounds, nil); err == nil {
+		t.Error("expected error for the min tag of a string field")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tunables, err := Describe(&sample{}, Defaults{"TEST_TOTAL_CHAOS_DURATION": "60"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tunables) != 8 {
+		t.Fatalf("expected 8 tunables, got %v", len(tunables))
+	}
+	duration := tunables[1]
+	if duration.Name != "TEST_TOTAL_CHAOS_DURATION" || duration.Type != "int" || duration.Default != "60" || duration.Min != "1" {
+		t.Errorf("unexpected tunable: %+v", duration)
+	}
+	if !tunables[5].Required || len(tunables[4].Enum) != 2 {
+		t.Errorf("expected the required & enum constraints, got %+v & %+v", tunables[5], tunables[4])
+	}
+	if tunables[7].Name != "TEST_RANDOMNESS" {
+		t.Errorf("expected the inline tunable, got %v", tunables[7].Name)
+	}
+}
@@ -0,0 +1,264 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Backoff is used to define the exponential backoff, the wait duration is multiplied by the factor after each iteration
+// the wait duration doesn't exceed the max wait, if it is provided
+// it will run if the instance of model is not present before
+func Backoff(factor float64, maxWait time.Duration) *Model {
+	model := Model{}
+	return model.Backoff(factor, maxWait)
+}
+
+// Backoff is used to define the exponential backoff, the wait duration is multiplied by the factor after each iteration
+// the wait duration doesn't exceed the max wait, if it is provided
+// it will run if the instance of model is already present
+func (model *Model) Backoff(factor float64, maxWait time.Duration) *Model {
+	model.factor = factor
+	model.maxWait = maxWait
+	return model
+}
+
+// Jitter is used to randomise the wait duration by the given fraction, e.g. 0.2 waits for 80% to 120% of the wait duration
+// it will run if the instance of model is not present before
+func Jitter(fraction float64) *Model {
+	model := Model{}
+	return model.Jitter(fraction)
+}
+
+// Jitter is used to randomise the wait duration by the given fraction, e.g. 0.2 waits for 80% to 120% of the wait duration
+// it will run if the instance of model is already present
+func (model *Model) Jitter(fraction float64) *Model {
+	model.jitter = fraction
+	return model
+}
+
+// AttemptTimeout is used to define the deadline of each iteration, the context of the action is cancelled once it expires
+// the iteration fails once the deadline expires, but the action which ignores the context (e.g. the client-go calls) keeps running
+// in background, so such actions must not update the shared state; they should return their result via DoWithResult instead
+// it will run if the instance of model is not present before
+func AttemptTimeout(timeout time.Duration) *Model {
+	model := Model{}
+	return model.AttemptTimeout(timeout)
+}
+
+// AttemptTimeout is used to define the deadline of each iteration, the context of the action is cancelled once it expires
+// see AttemptTimeout for the actions, which ignore the context
+// it will run if the instance of model is already present
+func (model *Model) AttemptTimeout(timeout time.Duration) *Model {
+	model.attemptTimeout = timeout
+	return model
+}
+
+// Poll is used to rerun the failed action after the given interval, until the attempt timeout expires
+// it will run if the instance of model is not present before
+func Poll(interval time.Duration) *Model {
+	model := Model{}
+	return model.Poll(interval)
+}
+
+// Poll is used to rerun the failed action after the given interval, until the attempt timeout expires
+// it will run if the instance of model is already present
+func (model *Model) Poll(interval time.Duration) *Model {
+	model.poll = interval
+	return model
+}
+
+// Do is used to run a context aware action with retries, with the (backoff) wait duration between the iterations
+// it stops retrying, once the action succeeds or returns a permanent error or the retries are exhausted or the context is cancelled
+// the iteration fails, once its deadline expires, even if the action doesn't return
+func (model Model) Do(ctx context.Context, action ContextAction) error {
+	if action == nil {
+		return fmt.Errorf("no action specified")
+	}
+	_, err := model.DoWithResult(ctx, func(ctx context.Context, attempt uint) (interface{}, error) {
+		return nil, action(ctx, attempt)
+	})
+	return err
+}
+
+// DoWithResult runs the action like Do and returns the result of the successful iteration
+// the result is handed over only by the iteration which succeeds within its deadline, the results of the timed out
+// iterations are discarded, so that the actions which ignore the context don't race with the next iterations
+func (model Model) DoWithResult(ctx context.Context, action ResultAction) (interface{}, error) {
+	if action == nil {
+		return nil, fmt.Errorf("no action specified")
+	}
+	if ctx == nil {
+		ctx = model.ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var err error
+	for attempt := uint(0); attempt <= model.retry; attempt++ {
+		if attempt > 0 {
+			if !sleepWithContext(ctx, model.backoff(attempt)) {
+				return nil, ctx.Err()
+			}
+			model.retried()
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		var result interface{}
+		if result, err = model.attempt(ctx, action, attempt); err == nil {
+			return result, nil
+		}
+		if IsPermanent(err) {
+			return nil, errors.Cause(err)
+		}
+	}
+	return nil, err
+}
+
+// outcome is the result of an iteration, it is handed over via the channel of the iteration
+type outcome struct {
+	result interface{}
+	err    error
+}
+
+// attempt runs a single iteration of the action, within the attempt timeout
+func (model Model) attempt(ctx context.Context, action ResultAction, attempt uint) (interface{}, error) {
+	if model.attemptTimeout <= 0 {
+		return action(ctx, attempt)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, model.attemptTimeout)
+	defer cancel()
+
+	// the action runs in background, so that the iteration doesn't exceed its deadline, even if the action ignores the context
+	// the channel is scoped to the iteration, so the outcome of an abandoned action is never read
+	done := make(chan outcome, 1)
+	lastErr := &polledError{}
+	go func() {
+		result, err := model.pollAction(attemptCtx, action, attempt, lastErr)
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case out := <-done:
+		if out.err != nil && attemptCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			return nil, errors.Errorf("attempt timed out after %v, err: %v", model.attemptTimeout, out.err)
+		}
+		return out.result, out.err
+	case <-attemptCtx.Done():
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := lastErr.get(); err != nil {
+			return nil, errors.Errorf("attempt timed out after %v, err: %v", model.attemptTimeout, err)
+		}
+		return nil, errors.Errorf("attempt timed out after %v", model.attemptTimeout)
+	}
+}
+
+// pollAction runs the action once, if the poll interval isn't provided
+// otherwise it reruns the failed action after each poll interval, until it succeeds or returns a permanent error or the context is cancelled
+func (model Model) pollAction(ctx context.Context, action ResultAction, attempt uint, lastErr *polledError) (interface{}, error) {
+	for trial := 0; ; trial++ {
+		if trial > 0 {
+			model.retried()
+		}
+		result, err := action(ctx, attempt)
+		if err == nil || IsPermanent(err) || model.poll <= 0 {
+			return result, err
+		}
+		lastErr.set(err)
+		if !sleepWithContext(ctx, model.poll) {
+			return nil, err
+		}
+	}
+}
+
+// polledError contains the last error of the polled action, it is read once the attempt times out
+type polledError struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (e *polledError) set(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *polledError) get() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+// backoff returns the wait duration before the given iteration
+func (model Model) backoff(attempt uint) time.Duration {
+	wait := float64(model.waitTime)
+	if model.factor > 1 {
+		for i := uint(1); i < attempt; i++ {
+			wait *= model.factor
+			if model.maxWait > 0 && wait >= float64(model.maxWait) {
+				wait = float64(model.maxWait)
+				break
+			}
+		}
+	}
+	if model.jitter > 0 {
+		wait += wait * model.jitter * (2*rand.Float64() - 1)
+	}
+	if model.maxWait > 0 && wait > float64(model.maxWait) {
+		wait = float64(model.maxWait)
+	}
+	return time.Duration(wait)
+}
+
+// sleepWithContext waits for the given duration, it returns false if the context is cancelled in between
+func sleepWithContext(ctx context.Context, duration time.Duration) bool {
+	if duration <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// permanentError marks the error as terminal, the retries are stopped once the action returns it
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+// Cause returns the underlying error, used by errors.Cause
+func (e *permanentError) Cause() error { return e.err }
+
+// Unwrap returns the underlying error, used by errors.Is & errors.As
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps the error, so that the retries are stopped once the action returns it
+// the retry returns the underlying error
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent returns true, if the error is marked as permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
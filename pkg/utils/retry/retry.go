@@ -1,7 +1,9 @@
 package retry
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,11 +12,64 @@ import (
 // Action defines the prototype of action function, function as a value
 type Action func(attempt uint) error
 
+// ContextAction defines the prototype of the action function, which honours the cancellation of the given context
+type ContextAction func(ctx context.Context, attempt uint) error
+
+// ResultAction defines the prototype of the context aware action function, which returns the result of the iteration
+type ResultAction func(ctx context.Context, attempt uint) (interface{}, error)
+
 // Model defines the schema, contains all the attributes need for retry
 type Model struct {
-	retry    uint
-	waitTime time.Duration
-	timeout  int64
+	retry          uint
+	waitTime       time.Duration
+	timeout        int64
+	ctx            context.Context
+	factor         float64
+	maxWait        time.Duration
+	jitter         float64
+	attemptTimeout time.Duration
+	poll           time.Duration
+	onRetry        func()
+}
+
+var (
+	hookMu    sync.RWMutex
+	retryHook func()
+)
+
+// SetRetryHook sets the func, which is called before every retried iteration of the models without the OnRetry hook
+// it is used by the upper layers to record the retries (e.g. metrics), without making the retry depend on them
+func SetRetryHook(hook func()) {
+	hookMu.Lock()
+	defer hookMu.Unlock()
+	retryHook = hook
+}
+
+// OnRetry is used to define the func, which is called before every retried iteration
+// it will run if the instance of model is not present before
+func OnRetry(hook func()) *Model {
+	model := Model{}
+	return model.OnRetry(hook)
+}
+
+// OnRetry is used to define the func, which is called before every retried iteration
+// it will run if the instance of model is already present
+func (model *Model) OnRetry(hook func()) *Model {
+	model.onRetry = hook
+	return model
+}
+
+// retried calls the retry hook of the model, or the one set by SetRetryHook
+func (model Model) retried() {
+	hook := model.onRetry
+	if hook == nil {
+		hookMu.RLock()
+		hook = retryHook
+		hookMu.RUnlock()
+	}
+	if hook != nil {
+		hook()
+	}
 }
 
 // Times is used to define the retry count
@@ -59,6 +114,42 @@ func (model *Model) Timeout(timeout int64) *Model {
 	return model
 }
 
+// Context is used to define the context, which stops the retries once it is cancelled
+// it will run if the instance of model is not present before
+func Context(ctx context.Context) *Model {
+	model := Model{}
+	return model.Context(ctx)
+}
+
+// Context is used to define the context, which stops the retries once it is cancelled
+// it will run if the instance of model is already present
+func (model *Model) Context(ctx context.Context) *Model {
+	model.ctx = ctx
+	return model
+}
+
+// done returns the error of the context, if it is cancelled
+func (model Model) done() error {
+	if model.ctx == nil {
+		return nil
+	}
+	return model.ctx.Err()
+}
+
+// sleep waits for the given duration or until the context is cancelled
+func (model Model) sleep(duration time.Duration) {
+	if model.ctx == nil {
+		time.Sleep(duration)
+		return
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-model.ctx.Done():
+	}
+}
+
 // Try is used to run a action with retries and some delay after each iteration
 func (model Model) Try(action Action) error {
 	if action == nil {
@@ -67,12 +158,18 @@ func (model Model) Try(action Action) error {
 
 	var err error
 	for attempt := uint(0); (attempt == 0 || err != nil) && attempt <= model.retry; attempt++ {
+		if ctxErr := model.done(); ctxErr != nil {
+			return ctxErr
+		}
+		if attempt > 0 {
+			model.retried()
+		}
 		err = action(attempt)
-		if model.waitTime > 0 {
-			time.Sleep(model.waitTime)
+		if IsPermanent(err) {
+			return errors.Cause(err)
 		}
-		if err == errors.Errorf("container is in terminated state") {
-			break
+		if model.waitTime > 0 {
+			model.sleep(model.waitTime)
 		}
 	}
 
@@ -81,6 +178,7 @@ func (model Model) Try(action Action) error {
 
 // TryWithTimeout is used to run a action with retries
 // for each iteration of retry there will be some timeout
+// Deprecated: the action runs synchronously and it isn't cancelled once the timeout expires, use Do with the AttemptTimeout instead
 func (model Model) TryWithTimeout(action Action) error {
 	if action == nil {
 		return fmt.Errorf("no action specified")
@@ -91,9 +189,15 @@ func (model Model) TryWithTimeout(action Action) error {
 		startTime := time.Now().Unix()
 		currentTime := time.Now().Unix()
 		for trial := uint(0); (trial == 0 || err != nil) && currentTime < startTime+model.timeout; trial++ {
+			if ctxErr := model.done(); ctxErr != nil {
+				return ctxErr
+			}
+			if attempt > 0 || trial > 0 {
+				model.retried()
+			}
 			err = action(attempt)
 			if model.waitTime > 0 {
-				time.Sleep(model.waitTime)
+				model.sleep(model.waitTime)
 			}
 			currentTime = time.Now().Unix()
 		}
@@ -0,0 +1,221 @@
+package retry
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	attempts := 0
+	err := Times(3).Wait(time.Millisecond).Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		attempts++
+		if attempt < 2 {
+			return errors.Errorf("not ready")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %v", attempts)
+	}
+}
+
+func TestDoReturnsLastError(t *testing.T) {
+	attempts := 0
+	err := Times(2).Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		attempts++
+		return errors.Errorf("attempt %v failed", attempt)
+	})
+	if err == nil || err.Error() != "attempt 2 failed" {
+		t.Fatalf("expected the error of the last attempt, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %v", attempts)
+	}
+}
+
+func TestDoStopsOnPermanentError(t *testing.T) {
+	cause := errors.Errorf("container is in terminated state")
+	attempts := 0
+	err := Times(5).Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		attempts++
+		return Permanent(cause)
+	})
+	if err != cause {
+		t.Fatalf("expected the underlying error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected 1 attempt, got %v", attempts)
+	}
+}
+
+func TestTryStopsOnPermanentError(t *testing.T) {
+	attempts := 0
+	err := Times(5).Try(func(attempt uint) error {
+		attempts++
+		return Permanent(errors.Errorf("container is in terminated state"))
+	})
+	if err == nil || IsPermanent(err) {
+		t.Fatalf("expected the underlying error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected 1 attempt, got %v", attempts)
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	if IsPermanent(errors.Errorf("transient")) || IsPermanent(nil) {
+		t.Fatal("expected the error not to be permanent")
+	}
+	if !IsPermanent(errors.Wrap(Permanent(errors.Errorf("terminal")), "wrapped")) {
+		t.Fatal("expected the wrapped error to be permanent")
+	}
+	if Permanent(nil) != nil {
+		t.Fatal("expected nil for the nil error")
+	}
+}
+
+func TestDoAttemptTimeout(t *testing.T) {
+	cancelled := make(chan struct{}, 2)
+	start := time.Now()
+	err := Times(1).AttemptTimeout(20*time.Millisecond).Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		<-ctx.Done()
+		cancelled <- struct{}{}
+		// the action ignores the cancellation for a while, the iteration shouldn't wait for it
+		time.Sleep(time.Second)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the attempt to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the attempts to be abandoned after the timeout, took %v", elapsed)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatalf("expected the context of both attempts to be cancelled, got %v", i)
+		}
+	}
+}
+
+func TestDoPollsWithinAttempt(t *testing.T) {
+	var trials int32
+	// the failed action is rerun after each poll interval, within the same attempt
+	err := Times(0).AttemptTimeout(time.Second).Poll(10*time.Millisecond).Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		if atomic.AddInt32(&trials, 1) < 4 {
+			return errors.Errorf("not ready")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trials != 4 {
+		t.Fatalf("expected 4 trials, got %v", trials)
+	}
+
+	// the attempt polls until its timeout expires and it returns the last error
+	atomic.StoreInt32(&trials, 0)
+	start := time.Now()
+	err = Times(1).AttemptTimeout(300*time.Millisecond).Poll(50*time.Millisecond).Wait(100*time.Millisecond).Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		atomic.AddInt32(&trials, 1)
+		return errors.Errorf("not ready")
+	})
+	elapsed := time.Since(start)
+	if err == nil || !strings.Contains(err.Error(), "not ready") {
+		t.Fatalf("expected the last error of the action, got %v", err)
+	}
+	if elapsed < 700*time.Millisecond || elapsed > 1500*time.Millisecond {
+		t.Fatalf("expected 2 attempts of 300ms with a wait of 100ms, took %v", elapsed)
+	}
+	if n := atomic.LoadInt32(&trials); n < 8 || n > 14 {
+		t.Fatalf("expected ~6 trials per attempt, got %v", n)
+	}
+}
+
+func TestDoWithResultDiscardsTimedOutAttempt(t *testing.T) {
+	// the first attempt ignores the cancellation and its result is discarded, once it times out
+	result, err := Times(1).AttemptTimeout(50*time.Millisecond).DoWithResult(context.Background(), func(ctx context.Context, attempt uint) (interface{}, error) {
+		if attempt == 0 {
+			time.Sleep(200 * time.Millisecond)
+			return "stale", nil
+		}
+		return "fresh", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "fresh" {
+		t.Fatalf("expected the result of the second attempt, got %v", result)
+	}
+
+	// the result is discarded, if the attempt fails
+	result, err = Times(0).DoWithResult(context.Background(), func(ctx context.Context, attempt uint) (interface{}, error) {
+		return "partial", errors.Errorf("not ready")
+	})
+	if err == nil || result != nil {
+		t.Fatalf("expected the error without the result, got %v, err: %v", result, err)
+	}
+}
+
+func TestRetryHook(t *testing.T) {
+	var global, local int32
+	SetRetryHook(func() { atomic.AddInt32(&global, 1) })
+	defer SetRetryHook(nil)
+
+	fail := func(ctx context.Context, attempt uint) error {
+		return errors.Errorf("not ready")
+	}
+	Times(2).Do(context.Background(), fail)
+	if global != 2 {
+		t.Fatalf("expected the global hook to be called for 2 retries, got %v", global)
+	}
+
+	// the hook of the model takes precedence over the global hook
+	OnRetry(func() { atomic.AddInt32(&local, 1) }).Times(3).Do(context.Background(), fail)
+	if local != 3 || global != 2 {
+		t.Fatalf("expected only the model hook to be called for 3 retries, got %v & %v", local, global)
+	}
+}
+
+func TestDoContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := Times(10).Wait(time.Hour).Do(ctx, func(ctx context.Context, attempt uint) error {
+		attempts++
+		cancel()
+		return errors.Errorf("not ready")
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected 1 attempt, got %v", attempts)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	model := Times(5).Wait(100*time.Millisecond).Backoff(2, 500*time.Millisecond)
+	expected := []time.Duration{100, 200, 400, 500, 500}
+	for i, want := range expected {
+		if got := model.backoff(uint(i + 1)); got != want*time.Millisecond {
+			t.Fatalf("attempt %v: expected %v, got %v", i+1, want*time.Millisecond, got)
+		}
+	}
+
+	model = Wait(time.Second).Jitter(0.5)
+	for i := 0; i < 100; i++ {
+		if got := model.backoff(1); got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("expected the wait within the jitter range, got %v", got)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package workflow
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// ExecRunner returns a runner, which runs every experiment in a separate go-runner process in the standalone mode
+// the experiment spec (env & probes) is passed through a spec file and the chaosresult is read from the result file
+// the process is terminated (SIGTERM) once the context is cancelled, so that it stops & reverts the chaos like the abort
+func ExecRunner(executable string) Runner {
+	return func(ctx context.Context, experiment types.Experiment) (v1alpha1.ChaosResultStatus, error) {
+
+		dir, err := ioutil.TempDir("", "workflow-"+experiment.Name+"-")
+		if err != nil {
+			return v1alpha1.ChaosResultStatus{}, errors.Errorf("unable to create the work directory, err: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		data, err := yaml.Marshal(experiment)
+		if err != nil {
+			return v1alpha1.ChaosResultStatus{}, errors.Errorf("unable to marshal the experiment spec, err: %v", err)
+		}
+		specFile, resultFile := filepath.Join(dir, "spec.yaml"), filepath.Join(dir, "chaosresult.yaml")
+		if err := ioutil.WriteFile(specFile, data, 0644); err != nil {
+			return v1alpha1.ChaosResultStatus{}, errors.Errorf("unable to write the experiment spec, err: %v", err)
+		}
+
+		cmd := exec.Command(executable, "-name", experiment.Name, "-spec", specFile, "-result", resultFile)
+		// the experiment name is derived from the spec, rather than the env of the workflow
+		cmd.Env = withoutEnv(os.Environ(), "EXPERIMENT_NAME")
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return v1alpha1.ChaosResultStatus{}, err
+		}
+		cmd.Stderr = cmd.Stdout
+
+		log.Infof("[Workflow]: Starting the %v experiment", experiment.Name)
+		if err := cmd.Start(); err != nil {
+			return v1alpha1.ChaosResultStatus{}, errors.Errorf("unable to start the %v experiment, err: %v", experiment.Name, err)
+		}
+		logsDone := make(chan struct{})
+		go func() {
+			prefixLines(stdout, os.Stdout, "["+experiment.Name+"] ")
+			close(logsDone)
+		}()
+
+		done := make(chan error, 1)
+		go func() {
+			<-logsDone
+			done <- cmd.Wait()
+		}()
+
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			log.Infof("[Workflow]: Stopping the %v experiment", experiment.Name)
+			if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+				log.Errorf("Unable to stop the %v experiment, err: %v", experiment.Name, err)
+			}
+			err = <-done
+		}
+		if err != nil {
+			log.Errorf("The %v experiment exited with error, err: %v", experiment.Name, err)
+		}
+
+		return readResult(resultFile)
+	}
+}
+
+// readResult returns the chaosresult status from the given result file
+func readResult(resultFile string) (v1alpha1.ChaosResultStatus, error) {
+	data, err := ioutil.ReadFile(resultFile)
+	if err != nil {
+		return v1alpha1.ChaosResultStatus{}, errors.Errorf("unable to read the chaosresult, err: %v", err)
+	}
+	result := v1alpha1.ChaosResult{}
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return v1alpha1.ChaosResultStatus{}, errors.Errorf("unable to parse the chaosresult, err: %v", err)
+	}
+	return result.Status, nil
+}
+
+// prefixLines copies the lines from the reader to the writer, with the given prefix
+// it keeps the logs of the parallel experiments distinguishable
+func prefixLines(r io.Reader, w io.Writer, prefix string) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		io.WriteString(w, prefix+scanner.Text()+"\n")
+	}
+	// drain the remaining output, if the scanner stopped in between, so that the process doesn't block on the writes
+	io.Copy(ioutil.Discard, r)
+}
+
+// withoutEnv returns the env list without the given env
+func withoutEnv(env []string, name string) []string {
+	var list []string
+	for _, e := range env {
+		if !strings.HasPrefix(e, name+"=") {
+			list = append(list, e)
+		}
+	}
+	return list
+}
@@ -0,0 +1,359 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/log"
+	"github.com/litmuschaos/litmus-go/pkg/probe"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/common"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	clientTypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// the workflow runs an ordered list of steps in a single runner invocation, a step runs an experiment,
+// a group of experiments in parallel or pauses for the given duration. The shared probes span the whole workflow,
+// they are checked before the first step (SOT, Edge), after the last step (EOT, Edge) and all along the steps (Continuous)
+
+// SkippedVerdict marks the steps, which are not run as an earlier step has failed or the workflow is stopped
+const SkippedVerdict string = "Skipped"
+
+// Spec contains the details of the workflow
+type Spec struct {
+	Name string `json:"name"`
+	// Env is shared by all the experiments, the env of the experiment takes the precedence over it
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Probe contains the shared probes, which span the whole workflow
+	Probe []types.ProbeAttributes `json:"probe,omitempty"`
+	Steps []Step                  `json:"steps"`
+}
+
+// Step contains the details of a workflow step, exactly one of experiment, parallel and pause should be provided
+type Step struct {
+	Name string `json:"name,omitempty"`
+	// Experiment runs a single experiment
+	Experiment *types.Experiment `json:"experiment,omitempty"`
+	// Parallel runs all the experiments at the same time and waits for all of them
+	Parallel []types.Experiment `json:"parallel,omitempty"`
+	// Pause waits for the given duration, e.g. 30s or 2m
+	Pause string `json:"pause,omitempty"`
+}
+
+// Result contains the aggregated result of the workflow
+type Result struct {
+	Name                   string                 `json:"name"`
+	Verdict                string                 `json:"verdict"`
+	FailStep               string                 `json:"failStep,omitempty"`
+	ProbeSuccessPercentage string                 `json:"probeSuccessPercentage,omitempty"`
+	StartTime              string                 `json:"startTime"`
+	EndTime                string                 `json:"endTime"`
+	ProbeStatus            []v1alpha1.ProbeStatus `json:"probeStatus,omitempty"`
+	Steps                  []StepResult           `json:"steps"`
+}
+
+// StepResult contains the result of a workflow step
+type StepResult struct {
+	Name        string             `json:"name"`
+	Verdict     string             `json:"verdict"`
+	StartTime   string             `json:"startTime,omitempty"`
+	EndTime     string             `json:"endTime,omitempty"`
+	Experiments []ExperimentResult `json:"experiments,omitempty"`
+}
+
+// ExperimentResult contains the chaosresult status of an experiment, run by the workflow
+type ExperimentResult struct {
+	Name   string                     `json:"name"`
+	Status v1alpha1.ChaosResultStatus `json:"status"`
+	// Error contains the error, if the experiment couldn't be run or didn't produce the chaosresult
+	Error string `json:"error,omitempty"`
+}
+
+// Runner runs the given experiment till completion and returns its chaosresult status
+// the experiment should stop & revert the chaos and return, once the context is cancelled
+type Runner func(ctx context.Context, experiment types.Experiment) (v1alpha1.ChaosResultStatus, error)
+
+// Load reads the workflow spec from the given file and validates it
+func Load(specFile string) (*Spec, error) {
+
+	data, err := ioutil.ReadFile(specFile)
+	if err != nil {
+		return nil, errors.Errorf("unable to read the workflow file %v, err: %v", specFile, err)
+	}
+	spec := &Spec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, errors.Errorf("unable to parse the workflow file %v, err: %v", specFile, err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Errorf("invalid workflow file %v, err: %v", specFile, err)
+	}
+	return spec, nil
+}
+
+// Validate verifies the workflow spec and sets the default step names
+func (spec *Spec) Validate() error {
+
+	if spec.Name == "" {
+		return errors.Errorf("workflow name is not provided")
+	}
+	if len(spec.Steps) == 0 {
+		return errors.Errorf("workflow doesn't contain any step")
+	}
+	for _, p := range spec.Probe {
+		if p.Mode == "OnChaos" {
+			return errors.Errorf("OnChaos mode is not supported for the %v shared probe, use the Continuous mode instead", p.Name)
+		}
+	}
+
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		kinds := 0
+		if step.Experiment != nil {
+			kinds++
+		}
+		if len(step.Parallel) != 0 {
+			kinds++
+		}
+		if step.Pause != "" {
+			if _, err := time.ParseDuration(step.Pause); err != nil {
+				return errors.Errorf("invalid pause '%v' of step %v, err: %v", step.Pause, i+1, err)
+			}
+			kinds++
+		}
+		if kinds != 1 {
+			return errors.Errorf("step %v should contain exactly one of experiment, parallel and pause", i+1)
+		}
+		for _, experiment := range step.Experiments() {
+			if experiment.Name == "" {
+				return errors.Errorf("experiment name is not provided in step %v", i+1)
+			}
+		}
+		if step.Name == "" {
+			step.Name = "step-" + strconv.Itoa(i+1)
+		}
+	}
+	return nil
+}
+
+// Experiments returns all the experiments of the workflow, in the order of the steps
+func (spec *Spec) Experiments() []types.Experiment {
+	var experiments []types.Experiment
+	for _, step := range spec.Steps {
+		experiments = append(experiments, step.Experiments()...)
+	}
+	return experiments
+}
+
+// Experiments returns the experiments of the step, the experiments inherits the shared env of the workflow
+func (step Step) Experiments() []types.Experiment {
+	if step.Experiment != nil {
+		return []types.Experiment{*step.Experiment}
+	}
+	return step.Parallel
+}
+
+// Run runs the steps of the workflow in order, along with the shared probes, and returns the aggregated result
+// the remaining steps are skipped, once a step fails, the workflow is stopped or halted by a shared probe
+func Run(ctx context.Context, spec *Spec, runner Runner, clients clients.ClientSets, chaosDetails *types.ChaosDetails) *Result {
+
+	res := &Result{Name: spec.Name, StartTime: now()}
+	resultDetails := types.ResultDetails{}
+	eventsDetails := types.EventDetails{}
+	probe.InitializeProbes(spec.Probe, &resultDetails)
+
+	// the context is also cancelled, once a shared probe with stopOnFailure fails, which stops the running experiments
+	ctx, halt := probe.WithHalt(ctx)
+	defer halt()
+
+	failStep, stopped := "", false
+	log.Infof("[Workflow]: Running the shared probes of the %v workflow (PreChaos)", spec.Name)
+	if err := probe.RunProbeList(ctx, spec.Probe, chaosDetails, clients, &resultDetails, "PreChaos", &eventsDetails); err != nil {
+		log.Errorf("Shared probes failed, err: %v", err)
+		failStep = "Failed while running the shared probes (PreChaos)"
+	}
+
+	for _, step := range spec.Steps {
+		if failStep != "" || stopped || ctx.Err() != nil {
+			res.Steps = append(res.Steps, StepResult{Name: step.Name, Verdict: SkippedVerdict})
+			continue
+		}
+		stepResult := runStep(ctx, step, spec.Env, runner)
+		res.Steps = append(res.Steps, stepResult)
+		switch stepResult.Verdict {
+		case types.FailVerdict:
+			failStep = "Step " + step.Name + " failed"
+		case types.StoppedVerdict:
+			stopped = true
+		}
+	}
+
+	if failStep == "" && !stopped && ctx.Err() == nil {
+		log.Infof("[Workflow]: Running the shared probes of the %v workflow (PostChaos)", spec.Name)
+		if err := probe.RunProbeList(ctx, spec.Probe, chaosDetails, clients, &resultDetails, "PostChaos", &eventsDetails); err != nil {
+			log.Errorf("Shared probes failed, err: %v", err)
+			failStep = "Failed while running the shared probes (PostChaos)"
+		}
+	}
+
+	switch {
+	case probe.HaltedBy(ctx) != "":
+		res.Verdict, res.FailStep = types.FailVerdict, "halted by probe "+probe.HaltedBy(ctx)
+	case failStep != "":
+		res.Verdict, res.FailStep = types.FailVerdict, failStep
+	case stopped || ctx.Err() != nil:
+		res.Verdict, res.FailStep = types.StoppedVerdict, "Workflow stopped!"
+	default:
+		res.Verdict = types.PassVerdict
+	}
+
+	if res.Verdict != types.PassVerdict {
+		probe.SetProbeVerdictAfterFailure(&resultDetails)
+	}
+	if len(resultDetails.ProbeDetails) != 0 {
+		res.ProbeSuccessPercentage = strconv.Itoa((resultDetails.PassedProbeCount * 100) / len(resultDetails.ProbeDetails))
+	}
+	for _, p := range resultDetails.ProbeDetails {
+		res.ProbeStatus = append(res.ProbeStatus, v1alpha1.ProbeStatus{Name: p.Name, Type: p.Type, Status: p.Status})
+	}
+	res.EndTime = now()
+
+	log.InfoWithValues("[Workflow]: The workflow has been completed", logrus.Fields{
+		"Workflow": res.Name,
+		"Verdict":  res.Verdict,
+		"FailStep": res.FailStep,
+	})
+	return res
+}
+
+// runStep runs the experiments of the step or pauses for the given duration
+func runStep(ctx context.Context, step Step, env []corev1.EnvVar, runner Runner) StepResult {
+
+	stepResult := StepResult{Name: step.Name, StartTime: now()}
+
+	if step.Pause != "" {
+		duration, _ := time.ParseDuration(step.Pause)
+		log.Infof("[Workflow]: Pausing for %v (%v)", duration, step.Name)
+		select {
+		case <-time.After(duration):
+			stepResult.Verdict = types.PassVerdict
+		case <-ctx.Done():
+			stepResult.Verdict = types.StoppedVerdict
+		}
+		stepResult.EndTime = now()
+		return stepResult
+	}
+
+	experiments := step.Experiments()
+	log.Infof("[Workflow]: Running the %v experiments (%v)", experimentNames(experiments), step.Name)
+	stepResult.Experiments = make([]ExperimentResult, len(experiments))
+
+	var wg sync.WaitGroup
+	for i := range experiments {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			experiment := withEnv(experiments[i], env)
+			experimentResult := ExperimentResult{Name: experiment.Name}
+			status, err := runner(ctx, experiment)
+			if err != nil {
+				log.Errorf("Unable to run the %v experiment, err: %v", experiment.Name, err)
+				experimentResult.Error = err.Error()
+			}
+			experimentResult.Status = status
+			stepResult.Experiments[i] = experimentResult
+		}(i)
+	}
+	wg.Wait()
+
+	stepResult.Verdict = stepVerdict(stepResult.Experiments)
+	stepResult.EndTime = now()
+	return stepResult
+}
+
+// stepVerdict derives the verdict of the step from the verdicts of its experiments
+// the step fails, if any of the experiments failed or couldn't be run, and it is stopped if any of them is stopped
+func stepVerdict(experiments []ExperimentResult) string {
+	verdict := types.PassVerdict
+	for _, experiment := range experiments {
+		switch {
+		case experiment.Error != "" || experiment.Status.ExperimentStatus.Verdict == types.FailVerdict:
+			return types.FailVerdict
+		case experiment.Status.ExperimentStatus.Verdict != types.PassVerdict:
+			verdict = types.StoppedVerdict
+		}
+	}
+	return verdict
+}
+
+// withEnv returns a copy of the experiment, which inherits the given env, unless it is defined by the experiment
+func withEnv(experiment types.Experiment, env []corev1.EnvVar) types.Experiment {
+	defined := map[string]bool{}
+	for _, e := range experiment.Spec.Components.ENV {
+		defined[e.Name] = true
+	}
+	merged := append([]corev1.EnvVar{}, experiment.Spec.Components.ENV...)
+	for _, e := range env {
+		if !defined[e.Name] {
+			merged = append(merged, e)
+		}
+	}
+	experiment.Spec.Components.ENV = merged
+	return experiment
+}
+
+// experimentNames returns the names of the given experiments
+func experimentNames(experiments []types.Experiment) []string {
+	var names []string
+	for _, experiment := range experiments {
+		names = append(names, experiment.Name)
+	}
+	return names
+}
+
+// NewChaosDetails returns the chaos details, used by the shared probes of the workflow
+func NewChaosDetails(spec *Spec, chaosNamespace string) *types.ChaosDetails {
+	return &types.ChaosDetails{
+		ExperimentName:       spec.Name,
+		ChaosNamespace:       chaosNamespace,
+		ChaosUID:             clientTypes.UID(spec.Name + "-" + common.GetRunID()),
+		Timeout:              180,
+		Delay:                2,
+		ProbeImagePullPolicy: string(corev1.PullIfNotPresent),
+	}
+}
+
+// WriteResult writes the aggregated result of the workflow to the result file
+// the result is written in json format, if the file has .json extension, else in yaml format
+func WriteResult(res *Result, resultFile string) error {
+
+	var data []byte
+	var err error
+	if strings.ToLower(filepath.Ext(resultFile)) == ".json" {
+		data, err = json.MarshalIndent(res, "", "  ")
+	} else {
+		data, err = yaml.Marshal(res)
+	}
+	if err != nil {
+		return errors.Errorf("unable to marshal the workflow result, err: %v", err)
+	}
+	if err := ioutil.WriteFile(resultFile, data, 0644); err != nil {
+		return errors.Errorf("unable to write the result file %v, err: %v", resultFile, err)
+	}
+	return nil
+}
+
+// now returns the current time in RFC3339 format
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
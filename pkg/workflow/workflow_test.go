@@ -0,0 +1,180 @@
+package workflow
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus-go/pkg/clients"
+	"github.com/litmuschaos/litmus-go/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const workflowSpec = `
+name: resiliency-suite
+env:
+- name: TOTAL_CHAOS_DURATION
+  value: "30"
+steps:
+- experiment:
+    name: pod-delete
+    spec:
+      components:
+        env:
+        - name: TOTAL_CHAOS_DURATION
+          value: "60"
+- name: cool-down
+  pause: 1ms
+- parallel:
+  - name: pod-cpu-hog
+  - name: pod-memory-hog
+- experiment:
+    name: pod-network-loss
+`
+
+// fakeRunner records the experiments and returns the verdict of the experiment from the given verdicts
+type fakeRunner struct {
+	mu          sync.Mutex
+	verdicts    map[string]string
+	experiments map[string]types.Experiment
+}
+
+func (r *fakeRunner) run(ctx context.Context, experiment types.Experiment) (v1alpha1.ChaosResultStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.experiments[experiment.Name] = experiment
+	verdict, ok := r.verdicts[experiment.Name]
+	if !ok {
+		verdict = types.PassVerdict
+	}
+	status := v1alpha1.ChaosResultStatus{}
+	status.ExperimentStatus.Verdict = verdict
+	return status, nil
+}
+
+func loadSpec(t *testing.T) *Spec {
+	dir, err := ioutil.TempDir("", "workflow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specFile := filepath.Join(dir, "workflow.yaml")
+	if err := ioutil.WriteFile(specFile, []byte(workflowSpec), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := Load(specFile)
+	if err != nil {
+		t.Fatalf("unable to load the workflow, err: %v", err)
+	}
+	return spec
+}
+
+func envValue(experiment types.Experiment, name string) string {
+	for _, e := range experiment.Spec.Components.ENV {
+		if e.Name == name {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+func TestRun(t *testing.T) {
+	spec := loadSpec(t)
+	runner := &fakeRunner{experiments: map[string]types.Experiment{}}
+
+	res := Run(context.Background(), spec, runner.run, clients.ClientSets{}, NewChaosDetails(spec, "litmus"))
+	if res.Verdict != types.PassVerdict {
+		t.Fatalf("expected the workflow to pass, found %v (%v)", res.Verdict, res.FailStep)
+	}
+	if len(res.Steps) != 4 || res.Steps[0].Name != "step-1" || res.Steps[1].Name != "cool-down" {
+		t.Fatalf("unexpected steps %+v", res.Steps)
+	}
+	if len(res.Steps[2].Experiments) != 2 || len(runner.experiments) != 4 {
+		t.Fatalf("expected all the experiments to run, found %+v", res.Steps)
+	}
+	// the env of the experiment takes the precedence over the shared env
+	if value := envValue(runner.experiments["pod-delete"], "TOTAL_CHAOS_DURATION"); value != "60" {
+		t.Fatalf("expected the env of the experiment to be retained, found %q", value)
+	}
+	if value := envValue(runner.experiments["pod-cpu-hog"], "TOTAL_CHAOS_DURATION"); value != "30" {
+		t.Fatalf("expected the shared env to be inherited, found %q", value)
+	}
+}
+
+func TestRunSkipsAfterFailure(t *testing.T) {
+	spec := loadSpec(t)
+	runner := &fakeRunner{
+		verdicts:    map[string]string{"pod-memory-hog": types.FailVerdict},
+		experiments: map[string]types.Experiment{},
+	}
+
+	res := Run(context.Background(), spec, runner.run, clients.ClientSets{}, NewChaosDetails(spec, "litmus"))
+	if res.Verdict != types.FailVerdict || res.FailStep != "Step step-3 failed" {
+		t.Fatalf("expected the workflow to fail at step-3, found %v (%v)", res.Verdict, res.FailStep)
+	}
+	if res.Steps[3].Verdict != SkippedVerdict {
+		t.Fatalf("expected the last step to be skipped, found %v", res.Steps[3].Verdict)
+	}
+	if _, ok := runner.experiments["pod-network-loss"]; ok {
+		t.Fatalf("expected the experiment of the skipped step not to run")
+	}
+}
+
+func TestRunStopped(t *testing.T) {
+	spec := loadSpec(t)
+	runner := &fakeRunner{experiments: map[string]types.Experiment{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := Run(ctx, spec, runner.run, clients.ClientSets{}, NewChaosDetails(spec, "litmus"))
+	if res.Verdict != types.StoppedVerdict {
+		t.Fatalf("expected the workflow to be stopped, found %v", res.Verdict)
+	}
+	if len(runner.experiments) != 0 {
+		t.Fatalf("expected no experiment to run, found %v", len(runner.experiments))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	onChaos := types.ProbeAttributes{}
+	onChaos.Name, onChaos.Mode = "check-frontend", "OnChaos"
+
+	tests := map[string]Spec{
+		"missing name":  {Steps: []Step{{Pause: "1s"}}},
+		"missing steps": {Name: "suite"},
+		"onchaos probe": {Name: "suite", Probe: []types.ProbeAttributes{onChaos}, Steps: []Step{{Pause: "1s"}}},
+		"invalid pause": {Name: "suite", Steps: []Step{{Pause: "1 minute"}}},
+		"empty step":    {Name: "suite", Steps: []Step{{Name: "empty"}}},
+		"multiple kinds": {Name: "suite", Steps: []Step{{
+			Pause:      "1s",
+			Experiment: &types.Experiment{Name: "pod-delete"},
+		}}},
+		"missing experiment name": {Name: "suite", Steps: []Step{{
+			Parallel: []types.Experiment{{Name: "pod-delete"}, {}},
+		}}},
+	}
+	for name, spec := range tests {
+		if err := spec.Validate(); err == nil {
+			t.Errorf("%v: expected the validation to fail", name)
+		}
+	}
+}
+
+func TestWithEnv(t *testing.T) {
+	experiment := types.Experiment{Name: "pod-delete"}
+	experiment.Spec.Components.ENV = []corev1.EnvVar{{Name: "PODS_AFFECTED_PERC", Value: "50"}}
+	shared := []corev1.EnvVar{{Name: "PODS_AFFECTED_PERC", Value: "100"}, {Name: "APP_NAMESPACE", Value: "shop"}}
+
+	merged := withEnv(experiment, shared)
+	if envValue(merged, "PODS_AFFECTED_PERC") != "50" || envValue(merged, "APP_NAMESPACE") != "shop" {
+		t.Fatalf("unexpected env %+v", merged.Spec.Components.ENV)
+	}
+	// the experiment of the spec is not modified
+	if len(experiment.Spec.Components.ENV) != 1 {
+		t.Fatalf("expected the experiment env not to be modified, found %+v", experiment.Spec.Components.ENV)
+	}
+}